@@ -2,10 +2,13 @@ package main
 
 import (
 	"log"
+	"math/rand"
 	"net/http"
+	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/battle"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/play"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/clock"
 	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/battlestrg"
 	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/gamestrg"
 	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/monstrg"
@@ -60,10 +63,14 @@ func main() {
 		log.Fatalf("unable to initialize monster storage due: %v", err)
 	}
 
+	// initialize clock shared by services depending on current time
+	clk := clock.New()
+
 	// initialize play service
 	playSvc, err := play.NewService(play.ServiceConfig{
 		GameStorage:    gameStrg,
 		PartnerStorage: monStrg,
+		Clock:          clk,
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize play service due: %v", err)
@@ -74,6 +81,7 @@ func main() {
 		GameStorage:    gameStrg,
 		BattleStorage:  battleStrg,
 		MonsterStorage: monStrg,
+		Rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize battle service due: %v", err)
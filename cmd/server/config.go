@@ -1,8 +1,112 @@
 package main
 
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
 type config struct {
-	Port    string        `cfg:"port" cfgDefault:"9186"`
-	Storage storageConfig `cfg:"storage"`
+	Port      string          `cfg:"port" cfgDefault:"9186"`
+	Storage   storageConfig   `cfg:"storage"`
+	Session   sessionConfig   `cfg:"session"`
+	RateLimit rateLimitConfig `cfg:"rate_limit"`
+	Venue     venueConfig     `cfg:"venue"`
+}
+
+type venueConfig struct {
+	// AdminUserIDs is comma separated ids of the users who could manage the
+	// venues, e.g: 1,2,3
+	AdminUserIDs string `cfg:"admin_user_ids"`
+}
+
+// GetAdminUserIDs returns the parsed admin user ids.
+func (c venueConfig) GetAdminUserIDs() ([]int, error) {
+	var userIDs []int
+	for _, value := range strings.Split(c.AdminUserIDs, ",") {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 {
+			continue
+		}
+		userID, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid admin user id: %v", value)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, nil
+}
+
+type rateLimitConfig struct {
+	// Capacity is the number of requests a client could send in a burst
+	Capacity int `cfg:"capacity" cfgDefault:"100"`
+	// RefillIntervalMs is the interval of allowing one more request
+	RefillIntervalMs int `cfg:"refill_interval_ms" cfgDefault:"100"`
+	// TrustedProxies is comma separated ips or cidrs of the reverse proxies
+	// whose X-Forwarded-For header is honored, e.g: 10.0.0.0/8,192.168.1.1
+	TrustedProxies string `cfg:"trusted_proxies"`
+}
+
+// GetTrustedProxies returns the parsed trusted proxies, a single ip is
+// returned as network containing only that ip.
+func (c rateLimitConfig) GetTrustedProxies() ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, value := range strings.Split(c.TrustedProxies, ",") {
+		value = strings.TrimSpace(value)
+		if len(value) == 0 {
+			continue
+		}
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy: %v", value)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, proxy, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %v", value)
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies, nil
+}
+
+type sessionConfig struct {
+	TokenLifetimeSecs        int    `cfg:"token_lifetime_secs" cfgDefault:"86400"`
+	RefreshTokenLifetimeSecs int    `cfg:"refresh_token_lifetime_secs" cfgDefault:"2592000"`
+	Issuer                   string `cfg:"issuer" cfgDefault:"hex-monscape"`
+	Audience                 string `cfg:"audience" cfgDefault:"hex-monscape"`
+	SigningKeyID             string `cfg:"signing_key_id" cfgDefault:"default"`
+	SigningAlgorithm         string `cfg:"signing_algorithm" cfgDefault:"HS256"`
+	// SigningKey is the hmac secret for HS256 or PEM-encoded private key for
+	// RS256 & EdDSA, it is ignored when SigningKeyPath is set. Either of them
+	// must be set since there is no default key.
+	SigningKey     string `cfg:"signing_key"`
+	SigningKeyPath string `cfg:"signing_key_path"`
+	// VerificationKeys is JSON array of previous keys that are still accepted
+	// for verification, e.g: [{"id": "2023", "algorithm": "HS256", "key": "old-secret"}]
+	VerificationKeys string `cfg:"verification_keys"`
+	// login is locked after LoginMaxFailures consecutive failures on the
+	// same username or client ip, the lock duration starts from
+	// LoginLockoutSecs and doubles on every next failure up to
+	// LoginMaxLockoutSecs
+	LoginMaxFailures    int `cfg:"login_max_failures" cfgDefault:"5"`
+	LoginLockoutSecs    int `cfg:"login_lockout_secs" cfgDefault:"30"`
+	LoginMaxLockoutSecs int `cfg:"login_max_lockout_secs" cfgDefault:"900"`
+}
+
+type sessionKeyConfig struct {
+	ID        string `json:"id"`
+	Algorithm string `json:"algorithm"`
+	Key       string `json:"key"`
 }
 
 type storageConfig struct {
@@ -16,6 +120,12 @@ type storageMemoryConfig struct {
 	MonsterDataPath string `cfg:"monster_data_path" cfgDefault:"../../deploy/local/run/rest-memory/data.json"`
 	EventDataPath   string `cfg:"event_data_path" cfgDefault:"../../deploy/local/run/rest-memory/events.json"`
 	UserDataPath    string `cfg:"user_data_path" cfgDefault:"../../deploy/local/run/rest-memory/users.json"`
+	VenueDataPath   string `cfg:"venue_data_path" cfgDefault:"../../deploy/local/run/rest-memory/venues.json"`
+	// SnapshotPath is the JSON file where games, battles, users, venues &
+	// meetups are saved periodically & on shutdown, then restored on startup.
+	// The snapshot is disabled when it is empty.
+	SnapshotPath         string `cfg:"snapshot_path"`
+	SnapshotIntervalSecs int    `cfg:"snapshot_interval_secs" cfgDefault:"60"`
 }
 
 type storageDynamoDBConfig struct {
@@ -23,6 +133,11 @@ type storageDynamoDBConfig struct {
 	BattleTableName    string `cfg:"battle_table_name"`
 	GameTableName      string `cfg:"game_table_name"`
 	MonsterTableName   string `cfg:"monster_table_name"`
+	SessionTableName   string `cfg:"session_table_name"`
+	UserTableName      string `cfg:"user_table_name"`
+	EventTableName     string `cfg:"event_table_name"`
+	VenueTableName     string `cfg:"venue_table_name"`
+	MeetupTableName    string `cfg:"meetup_table_name"`
 }
 
 type storageMySQLConfig struct {
@@ -1,48 +1,103 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/battle"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/event"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/play"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/session"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/venue"
+	"github.com/Haraj-backend/hex-monscape/internal/driver/rest"
 	"github.com/aws/aws-sdk-go/aws"
 	awsSession "github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/jmoiron/sqlx"
 
-	memsessionstrg "github.com/Haraj-backend/hex-monscape/internal/driven/rest/token"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/clock"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/rest/token"
 	membattlestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/battlestrg"
 	memeventstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/eventstrg"
 	memgamestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/gamestrg"
+	memloginstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/loginstrg"
+	memmeetupstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/meetupstrg"
 	memmonstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/monstrg"
+	memratestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/ratestrg"
+	memsessionstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/sessionstrg"
+	memsnapshot "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/snapshot"
 	memuserstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/userstrg"
+	memvenuestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/venuestrg"
 
 	ddbbattlestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/battlestrg"
+	ddbeventstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/eventstrg"
 	ddbgamestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/gamestrg"
+	ddbmeetupstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/meetupstrg"
 	ddbmonstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/monstrg"
+	ddbsessionstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/sessionstrg"
+	ddbuserstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/userstrg"
+	ddbvenuestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/venuestrg"
 
 	sqlbattlestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/battlestrg"
+	sqleventstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/eventstrg"
 	sqlgamestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/gamestrg"
+	sqlmeetupstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/meetupstrg"
 	sqlmonstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/monstrg"
+	sqlsessionstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/sessionstrg"
+	sqluserstrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/userstrg"
+	sqlvenuestrg "github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/venuestrg"
 )
 
 type storageDeps struct {
-	BattleGameStorage     battle.GameStorage
-	BattleBattleStorage   battle.BattleStorage
-	BattleMonsterStorage  battle.MonsterStorage
-	PlayGameStorage       play.GameStorage
-	PlayPartnerStorage    play.PartnerStorage
-	EventEventStorage     event.EventStorage
-	SessionSessionStorage session.SessionStorage
-	SessionUserStorage    session.UserStorage
+	BattleGameStorage          battle.GameStorage
+	BattleBattleStorage        battle.BattleStorage
+	BattleMonsterStorage       battle.MonsterStorage
+	PlayGameStorage            play.GameStorage
+	PlayPartnerStorage         play.PartnerStorage
+	EventEventStorage          event.EventStorage
+	SessionSessionStorage      session.SessionStorage
+	SessionRefreshTokenStorage session.RefreshTokenStorage
+	SessionRevocationStorage   session.RevocationStorage
+	SessionUserStorage         session.UserStorage
+	SessionLoginAttemptStorage session.LoginAttemptStorage
+	UserUserStorage            user.UserStorage
+	VenueVenueStorage          venue.VenueStorage
+	VenueMeetupStorage         venue.MeetupStorage
+	MeetupMeetupStorage        meetup.MeetupStorage
+	MeetupVenueStorage         meetup.VenueStorage
+	RestRateLimitStorage       rest.RateLimitStorage
+	// MemorySnapshotter is only set for memory storage type with snapshot
+	// enabled, it must be run in background & saved on shutdown
+	MemorySnapshotter *memsnapshot.Snapshotter
 }
 
-func initStorageDeps(cfg config) (*storageDeps, error) {
+func initStorageDeps(cfg config, clk *clock.Clock) (*storageDeps, error) {
 	var deps storageDeps
 
+	// initialize session storage, it is the same for all storage types since
+	// the session is stored in the token itself
+	sessionStorage, err := initSessionStorage(cfg.Session, clk)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize session storage due: %v", err)
+	}
+	deps.SessionSessionStorage = sessionStorage
+
+	// initialize login attempt & rate limit storages, for now they are kept
+	// in memory for all storage types
+	deps.SessionLoginAttemptStorage = memloginstrg.New()
+	rateLimitStorage, err := memratestrg.New(memratestrg.Config{
+		Capacity:       cfg.RateLimit.Capacity,
+		RefillInterval: time.Duration(cfg.RateLimit.RefillIntervalMs) * time.Millisecond,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize rate limit storage due: %v", err)
+	}
+	deps.RestRateLimitStorage = rateLimitStorage
+
 	switch cfg.Storage.Type {
 	case storageTypeMemory:
 		// initialize monster storage
@@ -72,11 +127,8 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 			return nil, fmt.Errorf("unable to initialize event storage due: %v", err)
 		}
 
-		// initialize session storage
-		sessionStorage, err := memsessionstrg.New(memsessionstrg.Config{})
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize session storage due: %v", err)
-		}
+		// initialize refresh token & revocation storage
+		refreshTokenStorage := memsessionstrg.New()
 
 		userData, err := os.ReadFile(cfg.Storage.Memory.UserDataPath)
 		if err != nil {
@@ -89,6 +141,44 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 			return nil, fmt.Errorf("unable to initialize user storage due: %v", err)
 		}
 
+		venueData, err := os.ReadFile(cfg.Storage.Memory.VenueDataPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read venue data due: %v", err)
+		}
+
+		// initialize venue storage
+		venueStorage, err := memvenuestrg.New(memvenuestrg.Config{VenueData: venueData})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize venue storage due: %v", err)
+		}
+
+		// initialize meetup storage
+		meetupStorage := memmeetupstrg.New()
+
+		// restore the storages from the snapshot when it is enabled, the
+		// snapshot takes precedence over the seed data
+		if len(cfg.Storage.Memory.SnapshotPath) > 0 {
+			snapshotter, err := memsnapshot.New(memsnapshot.Config{
+				Path:     cfg.Storage.Memory.SnapshotPath,
+				Interval: time.Duration(cfg.Storage.Memory.SnapshotIntervalSecs) * time.Second,
+				Sources: map[string]memsnapshot.Source{
+					"games":   gameStorage,
+					"battles": battleStorage,
+					"users":   userStorage,
+					"venues":  venueStorage,
+					"meetups": meetupStorage,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("unable to initialize memory snapshot due: %v", err)
+			}
+			err = snapshotter.Load()
+			if err != nil {
+				return nil, fmt.Errorf("unable to load memory snapshot due: %v", err)
+			}
+			deps.MemorySnapshotter = snapshotter
+		}
+
 		// set storages
 		deps.BattleGameStorage = gameStorage
 		deps.BattleBattleStorage = battleStorage
@@ -97,8 +187,14 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 		deps.PlayPartnerStorage = monsterStorage
 
 		deps.EventEventStorage = eventStorage
-		deps.SessionSessionStorage = sessionStorage
+		deps.SessionRefreshTokenStorage = refreshTokenStorage
+		deps.SessionRevocationStorage = refreshTokenStorage
 		deps.SessionUserStorage = userStorage
+		deps.UserUserStorage = userStorage
+		deps.VenueVenueStorage = venueStorage
+		deps.VenueMeetupStorage = meetupStorage
+		deps.MeetupMeetupStorage = meetupStorage
+		deps.MeetupVenueStorage = venueStorage
 
 	case storageTypeDynamoDB:
 		// initialize aws awsSession
@@ -133,6 +229,46 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize battle storage due: %v", err)
 		}
+		// initialize refresh token & revocation storage
+		refreshTokenStorage, err := ddbsessionstrg.New(ddbsessionstrg.Config{
+			DynamoClient: dynamoClient,
+			TableName:    cfg.Storage.DynamoDB.SessionTableName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize refresh token storage due: %v", err)
+		}
+		// initialize user storage
+		userStorage, err := ddbuserstrg.New(ddbuserstrg.Config{
+			DynamoClient: dynamoClient,
+			TableName:    cfg.Storage.DynamoDB.UserTableName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize user storage due: %v", err)
+		}
+		// initialize event storage
+		eventStorage, err := ddbeventstrg.New(ddbeventstrg.Config{
+			DynamoClient: dynamoClient,
+			TableName:    cfg.Storage.DynamoDB.EventTableName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize event storage due: %v", err)
+		}
+		// initialize venue storage
+		venueStorage, err := ddbvenuestrg.New(ddbvenuestrg.Config{
+			DynamoClient: dynamoClient,
+			TableName:    cfg.Storage.DynamoDB.VenueTableName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize venue storage due: %v", err)
+		}
+		// initialize meetup storage
+		meetupStorage, err := ddbmeetupstrg.New(ddbmeetupstrg.Config{
+			DynamoClient: dynamoClient,
+			TableName:    cfg.Storage.DynamoDB.MeetupTableName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize meetup storage due: %v", err)
+		}
 
 		// set storages
 		deps.BattleGameStorage = gameStorage
@@ -140,6 +276,15 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 		deps.BattleMonsterStorage = monsterStorage
 		deps.PlayGameStorage = gameStorage
 		deps.PlayPartnerStorage = monsterStorage
+		deps.SessionRefreshTokenStorage = refreshTokenStorage
+		deps.SessionRevocationStorage = refreshTokenStorage
+		deps.SessionUserStorage = userStorage
+		deps.UserUserStorage = userStorage
+		deps.EventEventStorage = eventStorage
+		deps.VenueVenueStorage = venueStorage
+		deps.VenueMeetupStorage = meetupStorage
+		deps.MeetupMeetupStorage = meetupStorage
+		deps.MeetupVenueStorage = venueStorage
 
 	case storageTypeMySQL:
 		// initialize sql client
@@ -162,6 +307,31 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize battle storage due: %v", err)
 		}
+		// initialize refresh token & revocation storage
+		refreshTokenStorage, err := sqlsessionstrg.New(sqlsessionstrg.Config{SQLClient: sqlClient})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize refresh token storage due: %v", err)
+		}
+		// initialize user storage
+		userStorage, err := sqluserstrg.New(sqluserstrg.Config{SQLClient: sqlClient})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize user storage due: %v", err)
+		}
+		// initialize event storage
+		eventStorage, err := sqleventstrg.New(sqleventstrg.Config{SQLClient: sqlClient})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize event storage due: %v", err)
+		}
+		// initialize venue storage
+		venueStorage, err := sqlvenuestrg.New(sqlvenuestrg.Config{SQLClient: sqlClient})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize venue storage due: %v", err)
+		}
+		// initialize meetup storage
+		meetupStorage, err := sqlmeetupstrg.New(sqlmeetupstrg.Config{SQLClient: sqlClient})
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize meetup storage due: %v", err)
+		}
 
 		// set storages
 		deps.BattleGameStorage = gameStorage
@@ -169,6 +339,15 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 		deps.BattleMonsterStorage = monsterStorage
 		deps.PlayGameStorage = gameStorage
 		deps.PlayPartnerStorage = monsterStorage
+		deps.SessionRefreshTokenStorage = refreshTokenStorage
+		deps.SessionRevocationStorage = refreshTokenStorage
+		deps.SessionUserStorage = userStorage
+		deps.UserUserStorage = userStorage
+		deps.EventEventStorage = eventStorage
+		deps.VenueVenueStorage = venueStorage
+		deps.VenueMeetupStorage = meetupStorage
+		deps.MeetupMeetupStorage = meetupStorage
+		deps.MeetupVenueStorage = venueStorage
 
 	default:
 		return nil, fmt.Errorf("unknown storage type: %v", cfg.Storage.Type)
@@ -176,3 +355,42 @@ func initStorageDeps(cfg config) (*storageDeps, error) {
 
 	return &deps, nil
 }
+
+func initSessionStorage(cfg sessionConfig, clk *clock.Clock) (*token.Storage, error) {
+	// read signing key
+	signingKey := []byte(cfg.SigningKey)
+	if len(cfg.SigningKeyPath) > 0 {
+		data, err := os.ReadFile(cfg.SigningKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read signing key due: %v", err)
+		}
+		signingKey = data
+	}
+	// parse verification keys
+	var keyCfgs []sessionKeyConfig
+	if len(cfg.VerificationKeys) > 0 {
+		err := json.Unmarshal([]byte(cfg.VerificationKeys), &keyCfgs)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse verification keys due: %v", err)
+		}
+	}
+	verificationKeys := make([]token.KeyConfig, 0, len(keyCfgs))
+	for _, keyCfg := range keyCfgs {
+		verificationKeys = append(verificationKeys, token.KeyConfig{
+			ID:        keyCfg.ID,
+			Algorithm: keyCfg.Algorithm,
+			Key:       []byte(keyCfg.Key),
+		})
+	}
+	return token.New(token.Config{
+		SigningKey: token.KeyConfig{
+			ID:        cfg.SigningKeyID,
+			Algorithm: cfg.SigningAlgorithm,
+			Key:       signingKey,
+		},
+		VerificationKeys: verificationKeys,
+		Issuer:           cfg.Issuer,
+		Audience:         cfg.Audience,
+		Clock:            clk,
+	})
+}
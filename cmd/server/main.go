@@ -1,18 +1,28 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"log"
+	"math/rand"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/clock"
 	"github.com/Haraj-backend/hex-monscape/internal/driver/rest"
 	"github.com/gosidekick/goconfig"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/battle"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/event"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/play"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/session"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/venue"
 
 	_ "github.com/go-sql-driver/mysql"
 )
@@ -24,9 +34,15 @@ func main() {
 	if err != nil {
 		log.Fatalf("unable to parse configs due: %v", err)
 	}
+	if len(cfg.Session.SigningKey) == 0 && len(cfg.Session.SigningKeyPath) == 0 {
+		log.Fatalf("either session signing key or signing key path must be set")
+	}
+
+	// initialize clock shared by services & storages depending on current time
+	clk := clock.New()
 
 	// initialize storages depending on storage type: memory, dynamodb, mysql
-	deps, err := initStorageDeps(cfg)
+	deps, err := initStorageDeps(cfg, clk)
 	if err != nil {
 		log.Fatalf("unable to initialize storages due: %v", err)
 	}
@@ -35,6 +51,7 @@ func main() {
 	playService, err := play.NewService(play.ServiceConfig{
 		GameStorage:    deps.PlayGameStorage,
 		PartnerStorage: deps.PlayPartnerStorage,
+		Clock:          clk,
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize play service due: %v", err)
@@ -45,6 +62,7 @@ func main() {
 		GameStorage:    deps.BattleGameStorage,
 		BattleStorage:  deps.BattleBattleStorage,
 		MonsterStorage: deps.BattleMonsterStorage,
+		Rand:           rand.New(rand.NewSource(time.Now().UnixNano())),
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize battle service due: %v", err)
@@ -60,19 +78,73 @@ func main() {
 
 	// initialize session service
 	sessionService, err := session.NewService(session.ServiceConfig{
-		SessionStorage: deps.SessionSessionStorage,
-		UserStorage:    deps.SessionUserStorage,
+		SessionStorage:       deps.SessionSessionStorage,
+		RefreshTokenStorage:  deps.SessionRefreshTokenStorage,
+		RevocationStorage:    deps.SessionRevocationStorage,
+		UserStorage:          deps.SessionUserStorage,
+		LoginAttemptStorage:  deps.SessionLoginAttemptStorage,
+		Clock:                clk,
+		TokenLifetime:        time.Duration(cfg.Session.TokenLifetimeSecs) * time.Second,
+		RefreshTokenLifetime: time.Duration(cfg.Session.RefreshTokenLifetimeSecs) * time.Second,
+		LoginPolicy: entity.LoginPolicy{
+			MaxFailures: cfg.Session.LoginMaxFailures,
+			Lockout:     time.Duration(cfg.Session.LoginLockoutSecs) * time.Second,
+			MaxLockout:  time.Duration(cfg.Session.LoginMaxLockoutSecs) * time.Second,
+		},
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize session service due: %v", err)
 	}
 
+	// initialize user service
+	userService, err := user.NewService(user.ServiceConfig{
+		UserStorage: deps.UserUserStorage,
+	})
+	if err != nil {
+		log.Fatalf("unable to initialize user service due: %v", err)
+	}
+
+	// initialize venue service
+	adminUserIDs, err := cfg.Venue.GetAdminUserIDs()
+	if err != nil {
+		log.Fatalf("unable to parse venue admin user ids due: %v", err)
+	}
+	venueService, err := venue.NewService(venue.ServiceConfig{
+		VenueStorage:  deps.VenueVenueStorage,
+		MeetupStorage: deps.VenueMeetupStorage,
+		Clock:         clk,
+		AdminUserIDs:  adminUserIDs,
+	})
+	if err != nil {
+		log.Fatalf("unable to initialize venue service due: %v", err)
+	}
+
+	// initialize meetup service
+	meetupService, err := meetup.NewService(meetup.ServiceConfig{
+		MeetupStorage: deps.MeetupMeetupStorage,
+		VenueStorage:  deps.MeetupVenueStorage,
+		Clock:         clk,
+	})
+	if err != nil {
+		log.Fatalf("unable to initialize meetup service due: %v", err)
+	}
+
 	// initialize rest api
+	trustedProxies, err := cfg.RateLimit.GetTrustedProxies()
+	if err != nil {
+		log.Fatalf("unable to parse trusted proxies due: %v", err)
+	}
 	api, err := rest.NewAPI(rest.APIConfig{
-		PlayingService: playService,
-		BattleService:  battleService,
-		EventService:   eventService,
-		SessionService: sessionService,
+		PlayingService:   playService,
+		BattleService:    battleService,
+		EventService:     eventService,
+		SessionService:   sessionService,
+		UserService:      userService,
+		VenueService:     venueService,
+		MeetupService:    meetupService,
+		RateLimitStorage: deps.RestRateLimitStorage,
+		Clock:            clk,
+		TrustedProxies:   trustedProxies,
 	})
 	if err != nil {
 		log.Fatalf("unable to initialize rest api due: %v", err)
@@ -85,11 +157,38 @@ func main() {
 		ReadTimeout: 3 * time.Second,
 	}
 
+	// save memory snapshot periodically until the server is shut down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if deps.MemorySnapshotter != nil {
+		go deps.MemorySnapshotter.Run(ctx)
+	}
+
 	// run server
 	log.Printf("[INFO] server is listening on :%v...", cfg.Port)
 	log.Printf("[INFO] please wait a moment until the game client ready in http://localhost:8161 to play the game...")
-	err = server.ListenAndServe()
-	if err != nil && !errors.Is(err, http.ErrServerClosed) {
-		log.Fatalf("unable to start server due: %v", err)
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("unable to start server due: %v", err)
+		}
+	}()
+
+	// wait for termination signal then shut down the server gracefully
+	<-ctx.Done()
+	log.Printf("[INFO] shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err = server.Shutdown(shutdownCtx)
+	if err != nil {
+		log.Printf("[ERROR] unable to shut down server gracefully due: %v", err)
+	}
+
+	// save the last snapshot once no more requests are served
+	if deps.MemorySnapshotter != nil {
+		err = deps.MemorySnapshotter.Save()
+		if err != nil {
+			log.Fatalf("unable to save memory snapshot due: %v", err)
+		}
 	}
 }
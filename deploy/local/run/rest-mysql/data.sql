@@ -4,4 +4,55 @@ INSERT INTO monster (id, name, max_health, health, attack, defense, speed, avata
   ("85db0102-212d-4ac8-932c-a0e876a29a85", 'Grumpy', 100, 100, 25, 5, 20, "https://haraj-sol-dev.s3.eu-west-1.amazonaws.com/hex-monscape/monsters/grumpy.png", 1),
   ("5e1ab413-415a-4326-8e39-0f56f8a66054", 'Vegiewee', 150, 150, 25, 20, 12, "https://haraj-sol-dev.s3.eu-west-1.amazonaws.com/hex-monscape/monsters/vegiewee.png", 0),
   ("c2ca1953-2376-489e-8e34-8bb48957f140", 'Snekworm', 150, 150, 30, 5, 21, "https://haraj-sol-dev.s3.eu-west-1.amazonaws.com/hex-monscape/monsters/snekworm.png", 0),
-  ("88a98dee-ce84-4afb-b5a8-7cc07535f73f", 'Waneye', 100, 100, 20, 10, 15, "https://haraj-sol-dev.s3.eu-west-1.amazonaws.com/hex-monscape/monsters/waneye.png", 0);
\ No newline at end of file
+  ("88a98dee-ce84-4afb-b5a8-7cc07535f73f", 'Waneye', 100, 100, 20, 10, 15, "https://haraj-sol-dev.s3.eu-west-1.amazonaws.com/hex-monscape/monsters/waneye.png", 0);
+
+-- the password of all users is `123456`
+INSERT INTO user (id, username, email, password_hash) VALUES
+  (1, 'marion', 'marion@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (2, 'todd', 'todd@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (3, 'anthony', 'anthony@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (4, 'kevin', 'kevin@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (5, 'eric', 'eric@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (6, 'elnora', 'elnora@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (7, 'etta', 'etta@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (8, 'caleb', 'caleb@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (9, 'larry', 'larry@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (10, 'stanley', 'stanley@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (11, 'nelle', 'nelle@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (12, 'luke', 'luke@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (13, 'ian', 'ian@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (14, 'harry', 'harry@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (15, 'paul', 'paul@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (16, 'tobias', 'tobias@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (17, 'lula', 'lula@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (18, 'warren', 'warren@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (19, 'marguerite', 'marguerite@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO'),
+  (20, 'mitchell', 'mitchell@eveners.com', '$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO');
+
+INSERT INTO event (id, name) VALUES
+  (1, 'Wedding'),
+  (2, 'Exhibition'),
+  (3, 'Bazaar'),
+  (4, 'Workshop'),
+  (5, 'Conference');
+
+INSERT INTO venue (id, name, open_at, closed_at, timezone) VALUES
+  (1, 'Si Jalak Harupat', '08:00', '23:59', 'Asia/Jakarta'),
+  (2, 'Parahyangan Convention', '00:00', '23:59', 'Asia/Jakarta'),
+  (3, 'Ice BSD', '05:00', '23:59', 'Asia/Jakarta');
+
+INSERT INTO venue_open_day (venue_id, day) VALUES
+  (1, 0), (1, 1), (1, 2), (1, 3), (1, 4), (1, 5), (1, 6),
+  (2, 0), (2, 1), (2, 3), (2, 4), (2, 5), (2, 6),
+  (3, 0), (3, 1), (3, 2), (3, 3), (3, 4), (3, 5), (3, 6);
+
+INSERT INTO venue_supported_event (venue_id, event_id, event_capacity) VALUES
+  (1, 2, 1),
+  (1, 3, 1),
+  (1, 4, 1),
+  (2, 1, 1),
+  (2, 2, 1),
+  (3, 1, 1),
+  (3, 2, 2),
+  (3, 3, 2),
+  (3, 4, 3);
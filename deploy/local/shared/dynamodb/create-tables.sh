@@ -46,3 +46,141 @@ awslocal dynamodb create-table \
         AttributeName=game_id,KeyType=HASH \
     --provisioned-throughput \
         ReadCapacityUnits=1,WriteCapacityUnits=1
+
+# Create session table, it stores refresh tokens & revocation list
+awslocal dynamodb create-table \
+    --table-name session \
+    --attribute-definitions \
+        AttributeName=id,AttributeType=S \
+    --key-schema \
+        AttributeName=id,KeyType=HASH \
+    --provisioned-throughput \
+        ReadCapacityUnits=1,WriteCapacityUnits=1
+
+awslocal dynamodb update-time-to-live \
+    --table-name session \
+    --time-to-live-specification \
+        Enabled=true,AttributeName=expires_at
+
+# Create user table, it stores users along with the claims of their username
+# & email for uniqueness check
+awslocal dynamodb create-table \
+    --table-name user \
+    --attribute-definitions \
+        AttributeName=id,AttributeType=S \
+        AttributeName=username,AttributeType=S \
+        AttributeName=email,AttributeType=S \
+    --key-schema \
+        AttributeName=id,KeyType=HASH \
+    --provisioned-throughput \
+        ReadCapacityUnits=1,WriteCapacityUnits=1 \
+    --global-secondary-indexes \
+        '
+            [
+                {
+                    "IndexName": "username",
+                    "KeySchema": [{"AttributeName":"username","KeyType":"HASH"}],
+                    "Projection": {
+                        "ProjectionType": "ALL"
+                    },
+                    "ProvisionedThroughput": {
+                        "ReadCapacityUnits": 1,
+                        "WriteCapacityUnits": 1
+                    }
+                },
+                {
+                    "IndexName": "email",
+                    "KeySchema": [{"AttributeName":"email","KeyType":"HASH"}],
+                    "Projection": {
+                        "ProjectionType": "ALL"
+                    },
+                    "ProvisionedThroughput": {
+                        "ReadCapacityUnits": 1,
+                        "WriteCapacityUnits": 1
+                    }
+                }
+            ]
+        '
+
+# Create event table
+awslocal dynamodb create-table \
+    --table-name event \
+    --attribute-definitions \
+        AttributeName=id,AttributeType=N \
+    --key-schema \
+        AttributeName=id,KeyType=HASH \
+    --provisioned-throughput \
+        ReadCapacityUnits=1,WriteCapacityUnits=1
+
+# Create venue table
+awslocal dynamodb create-table \
+    --table-name venue \
+    --attribute-definitions \
+        AttributeName=id,AttributeType=N \
+    --key-schema \
+        AttributeName=id,KeyType=HASH \
+    --provisioned-throughput \
+        ReadCapacityUnits=1,WriteCapacityUnits=1
+
+# Create meetup table, it stores meetups along with their participants
+awslocal dynamodb create-table \
+    --table-name meetup \
+    --attribute-definitions \
+        AttributeName=meetup_id,AttributeType=N \
+        AttributeName=sk,AttributeType=S \
+        AttributeName=bucket,AttributeType=S \
+        AttributeName=venue_id,AttributeType=N \
+        AttributeName=start_ts,AttributeType=N \
+        AttributeName=member_id,AttributeType=N \
+    --key-schema \
+        AttributeName=meetup_id,KeyType=HASH \
+        AttributeName=sk,KeyType=RANGE \
+    --provisioned-throughput \
+        ReadCapacityUnits=1,WriteCapacityUnits=1 \
+    --global-secondary-indexes \
+        '
+            [
+                {
+                    "IndexName": "bucket",
+                    "KeySchema": [
+                        {"AttributeName":"bucket","KeyType":"HASH"},
+                        {"AttributeName":"start_ts","KeyType":"RANGE"}
+                    ],
+                    "Projection": {
+                        "ProjectionType": "ALL"
+                    },
+                    "ProvisionedThroughput": {
+                        "ReadCapacityUnits": 1,
+                        "WriteCapacityUnits": 1
+                    }
+                },
+                {
+                    "IndexName": "venue_id",
+                    "KeySchema": [
+                        {"AttributeName":"venue_id","KeyType":"HASH"},
+                        {"AttributeName":"start_ts","KeyType":"RANGE"}
+                    ],
+                    "Projection": {
+                        "ProjectionType": "ALL"
+                    },
+                    "ProvisionedThroughput": {
+                        "ReadCapacityUnits": 1,
+                        "WriteCapacityUnits": 1
+                    }
+                },
+                {
+                    "IndexName": "member_id",
+                    "KeySchema": [
+                        {"AttributeName":"member_id","KeyType":"HASH"},
+                        {"AttributeName":"start_ts","KeyType":"RANGE"}
+                    ],
+                    "Projection": {
+                        "ProjectionType": "KEYS_ONLY"
+                    },
+                    "ProvisionedThroughput": {
+                        "ReadCapacityUnits": 1,
+                        "WriteCapacityUnits": 1
+                    }
+                }
+            ]
+        '
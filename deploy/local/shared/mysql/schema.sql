@@ -42,3 +42,112 @@ CREATE TABLE IF NOT EXISTS battle (
   enemy_avatar_url TEXT NOT NULL,
   enemy_last_damage INT(11) NOT NULL
 );
+
+CREATE TABLE IF NOT EXISTS refresh_token (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  family_id VARCHAR(36) NOT NULL,
+  user_id INT(11) NOT NULL,
+  issued_at BIGINT(20) NOT NULL,
+  expires_at BIGINT(20) NOT NULL,
+  rotated_at BIGINT(20) NOT NULL DEFAULT 0,
+  KEY `family_id` (`family_id`)
+);
+
+CREATE TABLE IF NOT EXISTS revoked_refresh_token_family (
+  family_id VARCHAR(36) NOT NULL PRIMARY KEY,
+  revoked_at BIGINT(20) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS revoked_access_token (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  expires_at BIGINT(20) NOT NULL,
+  KEY `expires_at` (`expires_at`)
+);
+
+CREATE TABLE IF NOT EXISTS user (
+  id INT(11) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  username VARCHAR(30) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  password_hash VARCHAR(255) NOT NULL,
+  UNIQUE KEY `username` (`username`),
+  UNIQUE KEY `email` (`email`)
+);
+
+CREATE TABLE IF NOT EXISTS event (
+  id INT(11) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS venue (
+  id INT(11) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  open_at VARCHAR(5) NOT NULL,
+  closed_at VARCHAR(5) NOT NULL,
+  timezone VARCHAR(64) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS venue_open_day (
+  venue_id INT(11) NOT NULL,
+  day TINYINT(1) NOT NULL,
+  PRIMARY KEY (`venue_id`, `day`)
+);
+
+CREATE TABLE IF NOT EXISTS venue_weekday_hours (
+  venue_id INT(11) NOT NULL,
+  day TINYINT(1) NOT NULL,
+  open_at VARCHAR(5) NOT NULL,
+  closed_at VARCHAR(5) NOT NULL,
+  PRIMARY KEY (`venue_id`, `day`)
+);
+
+CREATE TABLE IF NOT EXISTS venue_closure (
+  id INT(11) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  venue_id INT(11) NOT NULL,
+  start_ts INT(11) NOT NULL,
+  end_ts INT(11) NOT NULL,
+  reason TEXT NOT NULL,
+  KEY `venue_id` (`venue_id`, `start_ts`)
+);
+
+CREATE TABLE IF NOT EXISTS venue_supported_event (
+  venue_id INT(11) NOT NULL,
+  event_id INT(11) NOT NULL,
+  event_capacity INT(11) NOT NULL,
+  PRIMARY KEY (`venue_id`, `event_id`)
+);
+
+CREATE TABLE IF NOT EXISTS meetup (
+  id INT(11) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  venue_id INT(11) NOT NULL,
+  venue_name VARCHAR(255) NOT NULL,
+  event_id INT(11) NOT NULL,
+  event_name VARCHAR(255) NOT NULL,
+  start_ts INT(11) NOT NULL,
+  end_ts INT(11) NOT NULL,
+  max_persons INT(11) NOT NULL,
+  organizer_id INT(11) NOT NULL,
+  organizer_username VARCHAR(30) NOT NULL,
+  organizer_email VARCHAR(255) NOT NULL,
+  joined_persons_count INT(11) NOT NULL DEFAULT 0,
+  KEY `start_ts` (`start_ts`, `id`),
+  KEY `venue_id` (`venue_id`, `start_ts`),
+  KEY `organizer_id` (`organizer_id`, `start_ts`)
+);
+
+CREATE TABLE IF NOT EXISTS meetup_participant (
+  meetup_id INT(11) NOT NULL,
+  user_id INT(11) NOT NULL,
+  username VARCHAR(30) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  joined_at INT(11) NOT NULL,
+  PRIMARY KEY (`meetup_id`, `user_id`),
+  KEY `user_id` (`user_id`)
+);
+
+CREATE TABLE IF NOT EXISTS meetup_cancellation (
+  meetup_id INT(11) NOT NULL PRIMARY KEY,
+  reason TEXT NOT NULL,
+  cancelled_at BIGINT(20) NOT NULL,
+  cancelled_by INT(11) NOT NULL
+);
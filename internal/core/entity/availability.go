@@ -0,0 +1,71 @@
+package entity
+
+import "errors"
+
+const (
+	// DefaultAvailabilityGranularity is the slot length in seconds used when
+	// the granularity is not specified
+	DefaultAvailabilityGranularity = 3600
+	// MinAvailabilityGranularity is the shortest slot length in seconds
+	MinAvailabilityGranularity = 60
+	// MaxAvailabilitySlots is the maximum number of slots returned at once
+	MaxAvailabilitySlots = 1000
+)
+
+var ErrInvalidGranularity = errors.New("granularity must be at least 60 seconds and yield at most 1000 slots")
+
+// AvailabilityQuery holds the criteria for checking availability of a venue.
+type AvailabilityQuery struct {
+	VenueID int
+	EventID int
+	FromTs  int
+	ToTs    int
+	// Granularity is the length of each slot in seconds, when it is zero
+	// `DefaultAvailabilityGranularity` is used
+	Granularity int
+}
+
+// Validate returns `ErrInvalidTimeRange` when the time range is invalid and
+// `ErrInvalidGranularity` when the granularity is too short or yields too
+// many slots for the time range.
+func (q AvailabilityQuery) Validate() error {
+	if q.ToTs <= q.FromTs {
+		return ErrInvalidTimeRange
+	}
+	granularity := q.GetGranularity()
+	if granularity < MinAvailabilityGranularity {
+		return ErrInvalidGranularity
+	}
+	slotsCount := (q.ToTs - q.FromTs + granularity - 1) / granularity
+	if slotsCount > MaxAvailabilitySlots {
+		return ErrInvalidGranularity
+	}
+	return nil
+}
+
+// GetGranularity returns the slot length in seconds.
+func (q AvailabilityQuery) GetGranularity() int {
+	if q.Granularity == 0 {
+		return DefaultAvailabilityGranularity
+	}
+	return q.Granularity
+}
+
+// VenueAvailability holds the availability of a venue for an event.
+type VenueAvailability struct {
+	VenueID       int
+	EventID       int
+	TimeZone      string
+	EventCapacity int
+	Slots         []AvailabilitySlot
+}
+
+// AvailabilitySlot tells whether a meetup could be held for the whole slot.
+type AvailabilitySlot struct {
+	StartTs int
+	EndTs   int
+	IsOpen  bool
+	// RemainingCapacity is the number of meetups of the event which still
+	// could be held in the slot, it is always zero when the venue is closed
+	RemainingCapacity int
+}
@@ -3,7 +3,6 @@ package entity
 import (
 	"errors"
 	"math/rand"
-	"time"
 
 	"gopkg.in/validator.v2"
 )
@@ -78,9 +77,10 @@ func (b Battle) IsEnded() bool {
 }
 
 // DecideTurn is used for deciding turn in the battle. It calculates turn based
-// on speed of both partner & enemy. The battle state must be DECIDE_TURN, otherwise
-// the action will be rejected.
-func (b *Battle) DecideTurn() (State, error) {
+// on speed of both partner & enemy, the turn is drawn using given random number
+// generator. The battle state must be DECIDE_TURN, otherwise the action will be
+// rejected.
+func (b *Battle) DecideTurn(rnd *rand.Rand) (State, error) {
 	if b.State != StateDecideTurn {
 		return "", ErrInvalidState
 	}
@@ -100,7 +100,6 @@ func (b *Battle) DecideTurn() (State, error) {
 		slots = append(slots, enemySlot)
 	}
 	// decide turn
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
 	idx := rnd.Intn(lenSlots)
 	state := StateEnemyTurn
 	if slots[idx] == partnerSlot {
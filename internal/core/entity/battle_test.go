@@ -3,6 +3,7 @@ package entity_test
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -409,7 +410,7 @@ func TestDecideTurn(t *testing.T) {
 				Enemy:   &testCase.Enemy,
 			})
 			battle.State = testCase.State
-			state, err := battle.DecideTurn()
+			state, err := battle.DecideTurn(rand.New(rand.NewSource(time.Now().UnixNano())))
 			require.Equal(t, testCase.IsError, (err != nil), "unexpected error")
 			if !testCase.IsError {
 				require.Equal(t, testCase.ExpectedState, state, "expected state is not valid")
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrMissingCaller = errors.New("caller is not found in context")
+
+// Caller represents the user who is calling the system.
+type Caller struct {
+	ID       int
+	Username string
+	Email    string
+}
+
+type callerCtxKey struct{}
+
+// NewCallerContext returns copy of given context that carries given caller.
+func NewCallerContext(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerCtxKey{}, caller)
+}
+
+// GetCaller returns caller carried by given context. Returns `ErrMissingCaller`
+// when there is no caller in the context.
+func GetCaller(ctx context.Context) (*Caller, error) {
+	caller, ok := ctx.Value(callerCtxKey{}).(Caller)
+	if !ok {
+		return nil, ErrMissingCaller
+	}
+	return &caller, nil
+}
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// LoginAttempt holds the record of consecutive failed logins of a key, the key
+// is either a username or a client ip address.
+type LoginAttempt struct {
+	Key          string
+	Failures     int
+	LastFailedAt int64
+	// ExpiresAt is unix timestamp when the record is forgotten, the failures
+	// counter starts from zero again after this time
+	ExpiresAt int64
+}
+
+// IsExpired returns true when the record is already expired at given time.
+func (a LoginAttempt) IsExpired(now int64) bool {
+	return now >= a.ExpiresAt
+}
+
+// LoginPolicy defines when login of a key is locked after repeated failures.
+type LoginPolicy struct {
+	// MaxFailures is the number of consecutive failures allowed before the
+	// login is locked
+	MaxFailures int `validate:"min=1"`
+	// Lockout is the lock duration on the first lock, it is doubled on every
+	// subsequent failure up to MaxLockout
+	Lockout    time.Duration `validate:"min=1"`
+	MaxLockout time.Duration `validate:"min=1"`
+}
+
+// GetLockout returns the lock duration after given number of consecutive
+// failures. Returns zero when the login is not locked yet.
+func (p LoginPolicy) GetLockout(failures int) time.Duration {
+	if failures < p.MaxFailures {
+		return 0
+	}
+	lockout := p.Lockout
+	for i := p.MaxFailures; i < failures && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > p.MaxLockout {
+		lockout = p.MaxLockout
+	}
+	return lockout
+}
+
+// GetLockedUntil returns unix timestamp until when login of given attempt is
+// locked. The login is not locked when the returned value is not greater
+// than current time.
+func (p LoginPolicy) GetLockedUntil(attempt LoginAttempt) int64 {
+	return attempt.LastFailedAt + int64(p.GetLockout(attempt.Failures)/time.Second)
+}
+
+// GetExpiresAt returns unix timestamp when the attempt record which just
+// failed at failedAt should be forgotten. The record outlives the longest
+// lock, so the failures keep adding up when the attacker retries right after
+// the lock is over.
+func (p LoginPolicy) GetExpiresAt(failedAt int64) int64 {
+	return failedAt + int64(2*p.MaxLockout/time.Second)
+}
@@ -0,0 +1,43 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginPolicyGetLockout(t *testing.T) {
+	policy := entity.LoginPolicy{
+		MaxFailures: 3,
+		Lockout:     30 * time.Second,
+		MaxLockout:  5 * time.Minute,
+	}
+	// define test cases
+	testCases := []struct {
+		Failures   int
+		ExpLockout time.Duration
+	}{
+		{Failures: 0, ExpLockout: 0},
+		{Failures: 2, ExpLockout: 0},
+		{Failures: 3, ExpLockout: 30 * time.Second},
+		{Failures: 4, ExpLockout: time.Minute},
+		{Failures: 5, ExpLockout: 2 * time.Minute},
+		{Failures: 6, ExpLockout: 4 * time.Minute},
+		{Failures: 7, ExpLockout: 5 * time.Minute},
+		{Failures: 100, ExpLockout: 5 * time.Minute},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		lockout := policy.GetLockout(testCase.Failures)
+		require.Equal(t, testCase.ExpLockout, lockout, "unexpected lockout for %v failures", testCase.Failures)
+	}
+
+	// locked until is counted from the last failure
+	attempt := entity.LoginAttempt{Failures: 4, LastFailedAt: 1000}
+	require.Equal(t, int64(1060), policy.GetLockedUntil(attempt))
+
+	// the record should outlive the longest lock
+	require.Greater(t, policy.GetExpiresAt(1000), int64(1000)+int64(policy.MaxLockout/time.Second))
+}
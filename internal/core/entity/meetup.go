@@ -1,9 +1,31 @@
 package entity
 
 import (
+	"errors"
+	"sort"
+
 	"gopkg.in/validator.v2"
 )
 
+var ErrInvalidTimeRange = errors.New("end time must be after start time")
+
+// MaxMeetupDurationSecs is the upper bound of meetup duration. Meetup must be
+// held within single day of its venue, which lasts 25 hours at most on the
+// day of DST transition.
+const MaxMeetupDurationSecs = 25 * 3600
+
+// MeetupStatus is the lifecycle status of a meetup. Only `cancelled` is set
+// by the organizer, the others are derived from the number of joined persons
+// and the current time, see `Meetup.RefreshStatus()`.
+type MeetupStatus string
+
+const (
+	MeetupStatusOpen      MeetupStatus = "open"
+	MeetupStatusClosed    MeetupStatus = "closed"
+	MeetupStatusCancelled MeetupStatus = "cancelled"
+	MeetupStatusFinished  MeetupStatus = "finished"
+)
+
 type MeetupConfig struct {
 	Name       string `validate:"nonzero"`
 	VenueID    int    `validate:"nonzero"`
@@ -14,7 +36,14 @@ type MeetupConfig struct {
 }
 
 func (c MeetupConfig) Validate() error {
-	return validator.Validate(c)
+	err := validator.Validate(c)
+	if err != nil {
+		return err
+	}
+	if c.EndTs <= c.StartTs {
+		return ErrInvalidTimeRange
+	}
+	return nil
 }
 
 type CreateMeetupRequest struct {
@@ -37,8 +66,177 @@ type Meetup struct {
 	Organizer          MeetupOrganizer
 	JoinedPersons      []JoinedPerson
 	JoinedPersonsCount int
-	IsJoined           bool
-	Status             string
+	// IsJoined tells whether the viewer joined the meetup, it is only set on
+	// the meetup returned by `ViewFor()`
+	IsJoined bool
+	Status   MeetupStatus
+	// CancelledReason, CancelledAt & CancelledBy are only set when the meetup
+	// is cancelled, CancelledBy is the id of the user who cancelled it
+	CancelledReason string
+	CancelledAt     int64
+	CancelledBy     int
+}
+
+// RefreshStatus derives the meetup status at given unix timestamp. Cancelled
+// meetup stays cancelled, otherwise the meetup is finished once its end time
+// is passed, closed when the number of joined persons reaches max persons,
+// and open for the rest.
+func (m *Meetup) RefreshStatus(now int64) {
+	switch {
+	case m.Status == MeetupStatusCancelled:
+		// cancellation is final
+	case int64(m.EndTs) <= now:
+		m.Status = MeetupStatusFinished
+	case m.JoinedPersonsCount >= m.MaxPersons:
+		m.Status = MeetupStatusClosed
+	default:
+		m.Status = MeetupStatusOpen
+	}
+}
+
+// IsOngoing returns true when the meetup is neither cancelled nor finished,
+// the status must be refreshed beforehand.
+func (m Meetup) IsOngoing() bool {
+	return m.Status != MeetupStatusCancelled && m.Status != MeetupStatusFinished
+}
+
+// IsStarted returns true when the meetup is already started at given time.
+func (m Meetup) IsStarted(now int64) bool {
+	return int64(m.StartTs) <= now
+}
+
+// Cancel is used for cancelling the meetup by user with given id for given
+// reason. The meetup must not be cancelled nor started yet at given time
+// otherwise the action will be rejected.
+func (m *Meetup) Cancel(userID int, reason string, now int64) error {
+	m.RefreshStatus(now)
+	if m.Status == MeetupStatusCancelled || m.IsStarted(now) {
+		return ErrInvalidState
+	}
+	m.Status = MeetupStatusCancelled
+	m.CancelledReason = reason
+	m.CancelledAt = now
+	m.CancelledBy = userID
+	return nil
+}
+
+// ViewFor returns the meetup as seen by user with given id. The joined persons
+// and the cancellation details are private to the organizer & participants of
+// the meetup, so they are removed for the other users.
+func (m Meetup) ViewFor(userID int) Meetup {
+	m.IsJoined = m.IsParticipant(userID)
+	if m.IsMember(userID) {
+		return m
+	}
+	m.JoinedPersons = nil
+	m.CancelledReason = ""
+	m.CancelledAt = 0
+	m.CancelledBy = 0
+	return m
+}
+
+// IsMember returns true when user with given id is the organizer or the
+// participant of the meetup.
+func (m Meetup) IsMember(userID int) bool {
+	return m.Organizer.ID == userID || m.IsParticipant(userID)
+}
+
+// Join is used for adding given person to the participants of the meetup.
+// The meetup must be open at given time otherwise the action will be
+// rejected.
+func (m *Meetup) Join(person JoinedPerson, now int64) error {
+	m.RefreshStatus(now)
+	if m.Status != MeetupStatusOpen || m.IsParticipant(person.ID) {
+		return ErrInvalidState
+	}
+	m.JoinedPersons = append(m.JoinedPersons, person)
+	m.JoinedPersonsCount++
+	m.RefreshStatus(now)
+	return nil
+}
+
+// Leave is used for removing user with given id from the participants of the
+// meetup. The meetup must be ongoing at given time and the user must be the
+// participant, otherwise the action will be rejected.
+func (m *Meetup) Leave(userID int, now int64) error {
+	m.RefreshStatus(now)
+	if !m.IsOngoing() || !m.IsParticipant(userID) {
+		return ErrInvalidState
+	}
+	persons := make([]JoinedPerson, 0, len(m.JoinedPersons))
+	for _, person := range m.JoinedPersons {
+		if person.ID != userID {
+			persons = append(persons, person)
+		}
+	}
+	m.JoinedPersons = persons
+	m.JoinedPersonsCount = len(persons)
+	m.RefreshStatus(now)
+	return nil
+}
+
+// SetMaxPersons is used for changing the max persons of the meetup. The
+// meetup must be ongoing at given time and the new value must not be less
+// than the number of joined persons, otherwise the action will be rejected.
+// Setting it exactly to the number of joined persons closes the meetup.
+func (m *Meetup) SetMaxPersons(maxPersons int, now int64) error {
+	m.RefreshStatus(now)
+	if !m.IsOngoing() || maxPersons < m.JoinedPersonsCount {
+		return ErrInvalidState
+	}
+	m.MaxPersons = maxPersons
+	m.RefreshStatus(now)
+	return nil
+}
+
+// IsParticipant returns true when user with given id already joined the meetup.
+func (m Meetup) IsParticipant(userID int) bool {
+	for _, person := range m.JoinedPersons {
+		if person.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOverlapping returns true when the meetup time overlaps with given time range.
+func (m Meetup) IsOverlapping(startTs, endTs int) bool {
+	return m.StartTs < endTs && m.EndTs > startTs
+}
+
+// CountMaxConcurrentMeetups returns the highest number of given meetups that
+// are running at the same time within given time range.
+func CountMaxConcurrentMeetups(meetups []Meetup, startTs, endTs int) int {
+	type boundary struct {
+		ts    int
+		delta int
+	}
+	var boundaries []boundary
+	for _, meetup := range meetups {
+		if !meetup.IsOverlapping(startTs, endTs) {
+			continue
+		}
+		boundaries = append(boundaries,
+			boundary{ts: meetup.StartTs, delta: 1},
+			boundary{ts: meetup.EndTs, delta: -1},
+		)
+	}
+	// meetup that ends at the same time when the other starts is not
+	// considered overlapping, so the end boundary must come first
+	sort.Slice(boundaries, func(i, j int) bool {
+		if boundaries[i].ts == boundaries[j].ts {
+			return boundaries[i].delta < boundaries[j].delta
+		}
+		return boundaries[i].ts < boundaries[j].ts
+	})
+	count, maxCount := 0, 0
+	for _, b := range boundaries {
+		count += b.delta
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	return maxCount
 }
 
 type MeetupVenue struct {
@@ -58,7 +256,7 @@ type MeetupOrganizer struct {
 }
 
 type JoinedPerson struct {
-	ID       string
+	ID       int
 	Username string
 	Email    string
 	JoinedAt int
@@ -74,11 +272,12 @@ type GetMeetupsResponse struct {
 	MaxPersons         int
 	Organizer          MeetupOrganizer
 	JoinedPersonsCount int
-	Status             string
+	Status             MeetupStatus
 }
 
+// UpdateMeetupRequest holds the changes for a meetup, zero value field means
+// the field is left unchanged.
 type UpdateMeetupRequest struct {
-	ID         string
 	Name       string
 	StartTs    int
 	EndTs      int
@@ -94,9 +293,10 @@ type CancelMeetupResponse struct {
 	EndTs           int
 	MaxPersons      int
 	Organizer       MeetupOrganizer
-	Status          string
+	Status          MeetupStatus
 	CancelledReason string
 	CancelledAt     int64
+	CancelledBy     int
 }
 
 func NewMeetup(cfg MeetupConfig) (*Meetup, error) {
@@ -112,7 +312,7 @@ func NewMeetup(cfg MeetupConfig) (*Meetup, error) {
 		EndTs:      cfg.EndTs,
 		MaxPersons: cfg.MaxPersons,
 		IsJoined:   false,
-		Status:     "open",
+		Status:     MeetupStatusOpen,
 	}
 	return m, nil
 }
@@ -0,0 +1,201 @@
+package entity
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	// DefaultMeetupsLimit is the number of meetups returned at once when the
+	// limit is not specified
+	DefaultMeetupsLimit = 100
+	// MaxMeetupsLimit is the maximum number of meetups returned at once
+	MaxMeetupsLimit = 1000
+)
+
+var (
+	ErrInvalidLimit  = errors.New("limit must be between 1 and 1000")
+	ErrInvalidCursor = errors.New("cursor is invalid")
+	ErrInvalidStatus = errors.New("status is invalid")
+)
+
+// MeetupQuery holds the criteria for listing meetups, zero value field means
+// the criterion is not used. The meetups are sorted by their start time, the
+// meetups with the same start time are sorted by their id.
+type MeetupQuery struct {
+	// Limit is the maximum number of meetups returned, when it is zero
+	// `DefaultMeetupsLimit` is used
+	Limit int
+	// Cursor is the opaque position after which the meetups are returned,
+	// it is taken from the next cursor of the previous page
+	Cursor      string
+	EventID     int
+	VenueID     int
+	OrganizerID int
+	Status      MeetupStatus
+	// FromTs & ToTs filter meetups whose start time is within the range,
+	// either of them could be set alone
+	FromTs int
+	ToTs   int
+}
+
+// Validate returns `ErrInvalidLimit`, `ErrInvalidStatus`, `ErrInvalidTimeRange`
+// or `ErrInvalidCursor` when the respective criterion is invalid.
+func (q MeetupQuery) Validate() error {
+	if q.Limit < 0 || q.Limit > MaxMeetupsLimit {
+		return ErrInvalidLimit
+	}
+	switch q.Status {
+	case "", MeetupStatusOpen, MeetupStatusClosed, MeetupStatusCancelled, MeetupStatusFinished:
+	default:
+		return ErrInvalidStatus
+	}
+	if q.FromTs != 0 && q.ToTs != 0 && q.ToTs <= q.FromTs {
+		return ErrInvalidTimeRange
+	}
+	_, err := q.GetCursor()
+	return err
+}
+
+// GetLimit returns the maximum number of meetups returned.
+func (q MeetupQuery) GetLimit() int {
+	if q.Limit == 0 {
+		return DefaultMeetupsLimit
+	}
+	return q.Limit
+}
+
+// GetCursor returns the decoded cursor of the query. Returns nil when the
+// cursor is not set.
+func (q MeetupQuery) GetCursor() (*MeetupCursor, error) {
+	if len(q.Cursor) == 0 {
+		return nil, nil
+	}
+	return DecodeMeetupCursor(q.Cursor)
+}
+
+// IsMatch returns true when given meetup matches all of the query filters at
+// given unix timestamp. The cursor & limit are not taken into account.
+func (q MeetupQuery) IsMatch(m Meetup, now int64) bool {
+	if q.EventID != 0 && m.Event.ID != q.EventID {
+		return false
+	}
+	if q.VenueID != 0 && m.Venue.ID != q.VenueID {
+		return false
+	}
+	if q.OrganizerID != 0 && m.Organizer.ID != q.OrganizerID {
+		return false
+	}
+	if q.FromTs != 0 && m.StartTs < q.FromTs {
+		return false
+	}
+	if q.ToTs != 0 && m.StartTs >= q.ToTs {
+		return false
+	}
+	if q.Status != "" {
+		m.RefreshStatus(now)
+		if m.Status != q.Status {
+			return false
+		}
+	}
+	return true
+}
+
+// MeetupCursor is the position of a meetup in the sorted meetups list.
+type MeetupCursor struct {
+	StartTs int
+	ID      int
+}
+
+// IsAfter returns true when given meetup is positioned after the cursor.
+func (c MeetupCursor) IsAfter(m Meetup) bool {
+	if m.StartTs != c.StartTs {
+		return m.StartTs > c.StartTs
+	}
+	return m.ID > c.ID
+}
+
+// Encode returns the opaque representation of the cursor.
+func (c MeetupCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", c.StartTs, c.ID)))
+}
+
+// DecodeMeetupCursor parses cursor returned by `MeetupCursor.Encode()`.
+// Returns `ErrInvalidCursor` when the cursor is malformed.
+func DecodeMeetupCursor(cursor string) (*MeetupCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c MeetupCursor
+	n, err := fmt.Sscanf(string(b), "%d:%d", &c.StartTs, &c.ID)
+	if err != nil || n != 2 || c.Encode() != cursor {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// NewMeetupCursor returns the cursor positioned at given meetup.
+func NewMeetupCursor(m Meetup) MeetupCursor {
+	return MeetupCursor{StartTs: m.StartTs, ID: m.ID}
+}
+
+// SortMeetups sorts given meetups by their start time then by their id.
+func SortMeetups(meetups []Meetup) {
+	sort.Slice(meetups, func(i, j int) bool {
+		return NewMeetupCursor(meetups[i]).IsAfter(meetups[j])
+	})
+}
+
+// MeetupPage is a page of meetups listing, NextCursor is empty when there is
+// no more meetups to be returned.
+type MeetupPage struct {
+	Meetups    []GetMeetupsResponse
+	NextCursor string
+}
+
+// IncomingMeetupFilter holds the criteria for listing incoming meetups of a
+// user, zero value field means the criterion is not used.
+type IncomingMeetupFilter struct {
+	// Status is either open, closed or cancelled since incoming meetups are
+	// never finished
+	Status MeetupStatus
+	// EventIDs & VenueIDs match meetups of any of the listed events & venues
+	EventIDs []int
+	VenueIDs []int
+}
+
+// Validate returns `ErrInvalidStatus` when the status is invalid.
+func (f IncomingMeetupFilter) Validate() error {
+	switch f.Status {
+	case "", MeetupStatusOpen, MeetupStatusClosed, MeetupStatusCancelled:
+		return nil
+	}
+	return ErrInvalidStatus
+}
+
+// IsMatch returns true when given meetup matches all of the filter criteria,
+// the meetup status must be refreshed beforehand.
+func (f IncomingMeetupFilter) IsMatch(m Meetup) bool {
+	if f.Status != "" && m.Status != f.Status {
+		return false
+	}
+	if len(f.EventIDs) > 0 && !containsInt(f.EventIDs, m.Event.ID) {
+		return false
+	}
+	if len(f.VenueIDs) > 0 && !containsInt(f.VenueIDs, m.Venue.ID) {
+		return false
+	}
+	return true
+}
+
+func containsInt(values []int, v int) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
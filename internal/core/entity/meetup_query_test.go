@@ -0,0 +1,96 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeetupQueryValidate(t *testing.T) {
+	validCursor := entity.MeetupCursor{StartTs: 2000, ID: 1}.Encode()
+
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Query  entity.MeetupQuery
+		ExpErr error
+	}{
+		{
+			Name:   "Empty Query",
+			Query:  entity.MeetupQuery{},
+			ExpErr: nil,
+		},
+		{
+			Name: "Full Query",
+			Query: entity.MeetupQuery{
+				Limit:       entity.MaxMeetupsLimit,
+				Cursor:      validCursor,
+				EventID:     1,
+				VenueID:     1,
+				OrganizerID: 1,
+				Status:      entity.MeetupStatusClosed,
+				FromTs:      1000,
+				ToTs:        2000,
+			},
+			ExpErr: nil,
+		},
+		{
+			Name:   "Only From Time",
+			Query:  entity.MeetupQuery{FromTs: 1000},
+			ExpErr: nil,
+		},
+		{
+			Name:   "Negative Limit",
+			Query:  entity.MeetupQuery{Limit: -1},
+			ExpErr: entity.ErrInvalidLimit,
+		},
+		{
+			Name:   "Limit Too Large",
+			Query:  entity.MeetupQuery{Limit: entity.MaxMeetupsLimit + 1},
+			ExpErr: entity.ErrInvalidLimit,
+		},
+		{
+			Name:   "Unknown Status",
+			Query:  entity.MeetupQuery{Status: "all"},
+			ExpErr: entity.ErrInvalidStatus,
+		},
+		{
+			Name:   "Empty Time Range",
+			Query:  entity.MeetupQuery{FromTs: 1000, ToTs: 1000},
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+		{
+			Name:   "Malformed Cursor",
+			Query:  entity.MeetupQuery{Cursor: "!"},
+			ExpErr: entity.ErrInvalidCursor,
+		},
+		{
+			Name:   "Tampered Cursor",
+			Query:  entity.MeetupQuery{Cursor: validCursor + "A"},
+			ExpErr: entity.ErrInvalidCursor,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := testCase.Query.Validate()
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+		})
+	}
+}
+
+func TestMeetupCursor(t *testing.T) {
+	cursor := entity.MeetupCursor{StartTs: 2000, ID: 7}
+
+	// the cursor should survive the round trip
+	decoded, err := entity.DecodeMeetupCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.Equal(t, cursor, *decoded, "mismatch cursor")
+
+	// meetups are positioned by start time then by id
+	require.False(t, cursor.IsAfter(entity.Meetup{ID: 7, StartTs: 2000}), "same meetup is not after the cursor")
+	require.False(t, cursor.IsAfter(entity.Meetup{ID: 8, StartTs: 1999}), "earlier meetup is not after the cursor")
+	require.True(t, cursor.IsAfter(entity.Meetup{ID: 8, StartTs: 2000}), "meetup with greater id is after the cursor")
+	require.True(t, cursor.IsAfter(entity.Meetup{ID: 1, StartTs: 2001}), "later meetup is after the cursor")
+}
@@ -0,0 +1,223 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeetupRefreshStatus(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name      string
+		Meetup    entity.Meetup
+		Now       int64
+		ExpStatus entity.MeetupStatus
+	}{
+		{
+			Name:      "Open",
+			Meetup:    newTestMeetup(2, 1, entity.MeetupStatusOpen),
+			Now:       1000,
+			ExpStatus: entity.MeetupStatusOpen,
+		},
+		{
+			Name:      "Full",
+			Meetup:    newTestMeetup(2, 2, entity.MeetupStatusOpen),
+			Now:       1000,
+			ExpStatus: entity.MeetupStatusClosed,
+		},
+		{
+			Name:      "Reopened",
+			Meetup:    newTestMeetup(2, 1, entity.MeetupStatusClosed),
+			Now:       1000,
+			ExpStatus: entity.MeetupStatusOpen,
+		},
+		{
+			Name:      "Ongoing At Start Time",
+			Meetup:    newTestMeetup(2, 1, entity.MeetupStatusOpen),
+			Now:       2000,
+			ExpStatus: entity.MeetupStatusOpen,
+		},
+		{
+			Name:      "Finished At End Time",
+			Meetup:    newTestMeetup(2, 2, entity.MeetupStatusClosed),
+			Now:       3000,
+			ExpStatus: entity.MeetupStatusFinished,
+		},
+		{
+			Name:      "Cancelled Stays Cancelled",
+			Meetup:    newTestMeetup(2, 1, entity.MeetupStatusCancelled),
+			Now:       3000,
+			ExpStatus: entity.MeetupStatusCancelled,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m := testCase.Meetup
+			m.RefreshStatus(testCase.Now)
+			require.Equal(t, testCase.ExpStatus, m.Status, "mismatch status")
+		})
+	}
+}
+
+func TestMeetupCancel(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Meetup entity.Meetup
+		Now    int64
+		ExpErr error
+	}{
+		{
+			Name:   "Cancel Open Meetup",
+			Meetup: newTestMeetup(2, 1, entity.MeetupStatusOpen),
+			Now:    1000,
+			ExpErr: nil,
+		},
+		{
+			Name:   "Cancel Closed Meetup",
+			Meetup: newTestMeetup(2, 2, entity.MeetupStatusClosed),
+			Now:    1000,
+			ExpErr: nil,
+		},
+		{
+			Name:   "Cancel Cancelled Meetup",
+			Meetup: newTestMeetup(2, 1, entity.MeetupStatusCancelled),
+			Now:    1000,
+			ExpErr: entity.ErrInvalidState,
+		},
+		{
+			Name:   "Cancel One Second Before Start",
+			Meetup: newTestMeetup(2, 1, entity.MeetupStatusOpen),
+			Now:    1999,
+			ExpErr: nil,
+		},
+		{
+			Name:   "Cancel Started Meetup",
+			Meetup: newTestMeetup(2, 1, entity.MeetupStatusOpen),
+			Now:    2000,
+			ExpErr: entity.ErrInvalidState,
+		},
+		{
+			Name:   "Cancel Finished Meetup",
+			Meetup: newTestMeetup(2, 1, entity.MeetupStatusOpen),
+			Now:    3000,
+			ExpErr: entity.ErrInvalidState,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m := testCase.Meetup
+			err := m.Cancel(1, "Venue is under renovation", testCase.Now)
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+			if err != nil {
+				return
+			}
+			require.Equal(t, entity.MeetupStatusCancelled, m.Status, "mismatch status")
+			require.Equal(t, "Venue is under renovation", m.CancelledReason, "mismatch cancelled reason")
+			require.Equal(t, testCase.Now, m.CancelledAt, "mismatch cancelled at")
+			require.Equal(t, 1, m.CancelledBy, "mismatch cancelled by")
+		})
+	}
+}
+
+func TestMeetupJoinLeave(t *testing.T) {
+	m := newTestMeetup(2, 0, entity.MeetupStatusOpen)
+
+	// join until the meetup is full
+	err := m.Join(entity.JoinedPerson{ID: 1}, 1000)
+	require.NoError(t, err)
+	require.Equal(t, entity.MeetupStatusOpen, m.Status, "mismatch status")
+	err = m.Join(entity.JoinedPerson{ID: 1}, 1000)
+	require.Equal(t, entity.ErrInvalidState, err, "join twice should be rejected")
+	err = m.Join(entity.JoinedPerson{ID: 2}, 1000)
+	require.NoError(t, err)
+	require.Equal(t, entity.MeetupStatusClosed, m.Status, "mismatch status")
+	err = m.Join(entity.JoinedPerson{ID: 3}, 1000)
+	require.Equal(t, entity.ErrInvalidState, err, "join full meetup should be rejected")
+
+	// leave the meetup, it should be open again
+	err = m.Leave(2, 1000)
+	require.NoError(t, err)
+	require.Equal(t, entity.MeetupStatusOpen, m.Status, "mismatch status")
+	require.Equal(t, 1, m.JoinedPersonsCount, "mismatch joined persons count")
+	err = m.Leave(2, 1000)
+	require.Equal(t, entity.ErrInvalidState, err, "leave twice should be rejected")
+
+	// the meetup is finished, nobody could join or leave
+	err = m.Join(entity.JoinedPerson{ID: 3}, 3000)
+	require.Equal(t, entity.ErrInvalidState, err, "join finished meetup should be rejected")
+	err = m.Leave(1, 3000)
+	require.Equal(t, entity.ErrInvalidState, err, "leave finished meetup should be rejected")
+}
+
+func TestMeetupSetMaxPersons(t *testing.T) {
+	m := newTestMeetup(3, 2, entity.MeetupStatusOpen)
+
+	// less than joined persons
+	err := m.SetMaxPersons(1, 1000)
+	require.Equal(t, entity.ErrInvalidState, err, "unexpected error")
+	require.Equal(t, 3, m.MaxPersons, "max persons should not be changed")
+
+	// equal to joined persons closes the meetup
+	err = m.SetMaxPersons(2, 1000)
+	require.NoError(t, err)
+	require.Equal(t, entity.MeetupStatusClosed, m.Status, "mismatch status")
+
+	// more than joined persons reopens the meetup
+	err = m.SetMaxPersons(4, 1000)
+	require.NoError(t, err)
+	require.Equal(t, entity.MeetupStatusOpen, m.Status, "mismatch status")
+}
+
+// newTestMeetup returns meetup held from 2000 to 3000 with given number of
+// joined persons.
+func TestMeetupViewFor(t *testing.T) {
+	m := newTestMeetup(2, 1, entity.MeetupStatusOpen)
+	m.Organizer.ID = 1
+	err := m.Cancel(1, "Venue is under renovation", 1000)
+	require.NoError(t, err)
+
+	// organizer sees everything but doesn't join the meetup
+	view := m.ViewFor(1)
+	require.False(t, view.IsJoined, "organizer is not joined")
+	require.Equal(t, m.JoinedPersons, view.JoinedPersons, "mismatch joined persons")
+	require.Equal(t, m.CancelledReason, view.CancelledReason, "mismatch cancelled reason")
+
+	// participant sees everything
+	view = m.ViewFor(101)
+	require.True(t, view.IsJoined, "participant is joined")
+	require.Equal(t, m.JoinedPersons, view.JoinedPersons, "mismatch joined persons")
+	require.Equal(t, m.CancelledAt, view.CancelledAt, "mismatch cancelled at")
+
+	// outsider doesn't see private fields
+	view = m.ViewFor(999)
+	require.False(t, view.IsJoined, "outsider is not joined")
+	require.Nil(t, view.JoinedPersons, "joined persons is visible")
+	require.Empty(t, view.CancelledReason, "cancelled reason is visible")
+	require.Zero(t, view.CancelledAt, "cancelled at is visible")
+	require.Zero(t, view.CancelledBy, "cancelled by is visible")
+	require.Equal(t, m.JoinedPersonsCount, view.JoinedPersonsCount, "joined persons count should be visible")
+
+	// the meetup itself is left untouched
+	require.Len(t, m.JoinedPersons, 1, "meetup is changed")
+}
+
+func newTestMeetup(maxPersons, joinedPersons int, status entity.MeetupStatus) entity.Meetup {
+	m := entity.Meetup{
+		ID:         1,
+		Name:       "Test Meetup",
+		StartTs:    2000,
+		EndTs:      3000,
+		MaxPersons: maxPersons,
+		Status:     status,
+	}
+	for i := 1; i <= joinedPersons; i++ {
+		m.JoinedPersons = append(m.JoinedPersons, entity.JoinedPerson{ID: 100 + i})
+	}
+	m.JoinedPersonsCount = joinedPersons
+	return m
+}
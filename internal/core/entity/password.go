@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+// HashPassword returns bcrypt hash of given plaintext password.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("unable to hash password due: %w", err)
+	}
+	return string(hash), nil
+}
+
+// IsPasswordHash returns true when given value is password hash supported by
+// VerifyPassword, either bcrypt or argon2id in PHC string format.
+func IsPasswordHash(value string) bool {
+	if strings.HasPrefix(value, argon2idPrefix) {
+		return true
+	}
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyPassword returns true when given plaintext password matches given
+// password hash. The comparison is done in constant time.
+func VerifyPassword(hash, password string) bool {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return verifyArgon2idPassword(hash, password)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// verifyArgon2idPassword verifies password against argon2id hash in PHC string
+// format: `$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>`.
+func verifyArgon2idPassword(hash, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false
+	}
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return false
+	}
+	var memory, iterations uint32
+	var parallelism uint8
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism)
+	if err != nil {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false
+	}
+	otherKey := argon2.IDKey([]byte(password), salt, iterations, memory, parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, otherKey) == 1
+}
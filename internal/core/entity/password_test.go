@@ -0,0 +1,60 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashPassword(t *testing.T) {
+	hash, err := entity.HashPassword("123456")
+	require.NoError(t, err)
+	require.NotEqual(t, "123456", hash, "password is not hashed")
+	require.True(t, entity.IsPasswordHash(hash), "hash is not recognized")
+	require.True(t, entity.VerifyPassword(hash, "123456"), "password is not match")
+	require.False(t, entity.VerifyPassword(hash, "654321"), "wrong password is match")
+}
+
+func TestVerifyPassword(t *testing.T) {
+	// define test cases, the hashes are generated for password `123456`
+	testCases := []struct {
+		Name    string
+		Hash    string
+		IsMatch bool
+	}{
+		{
+			Name:    "Bcrypt Hash",
+			Hash:    "$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO",
+			IsMatch: true,
+		},
+		{
+			Name:    "Argon2id Hash",
+			Hash:    "$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHRzb21lc2FsdA$K5VnXtUCgBS4Pu7ENAGrSphWGCRscNpkj2nj/fUlYZA",
+			IsMatch: true,
+		},
+		{
+			Name:    "Malformed Argon2id Hash",
+			Hash:    "$argon2id$v=19$m=65536,t=3$c29tZXNhbHRzb21lc2FsdA$K5VnXtUCgBS4Pu7ENAGrSphWGCRscNpkj2nj/fUlYZA",
+			IsMatch: false,
+		},
+		{
+			Name:    "Plaintext",
+			Hash:    "123456",
+			IsMatch: false,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			isMatch := entity.VerifyPassword(testCase.Hash, "123456")
+			require.Equal(t, testCase.IsMatch, isMatch, "unexpected match result")
+		})
+	}
+}
+
+func TestIsPasswordHash(t *testing.T) {
+	require.True(t, entity.IsPasswordHash("$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO"))
+	require.True(t, entity.IsPasswordHash("$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHRzb21lc2FsdA$K5VnXtUCgBS4Pu7ENAGrSphWGCRscNpkj2nj/fUlYZA"))
+	require.False(t, entity.IsPasswordHash("123456"))
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"gopkg.in/validator.v2"
+)
+
+// RefreshToken is long-lived token used for obtaining new access token. Every
+// refresh token could only be used once, after used it will be rotated by
+// new token in the same family. The family represents single login session.
+type RefreshToken struct {
+	ID        string
+	FamilyID  string
+	UserID    int
+	IssuedAt  int64
+	ExpiresAt int64
+	// RotatedAt is the time when the token is exchanged for new token, zero
+	// means the token is still the latest token in its family
+	RotatedAt int64
+}
+
+// IsRotated returns true when the token is already exchanged for new token.
+func (t RefreshToken) IsRotated() bool {
+	return t.RotatedAt != 0
+}
+
+// IsExpired returns true when the token is already expired at given time.
+func (t RefreshToken) IsExpired(now int64) bool {
+	return now >= t.ExpiresAt
+}
+
+type RefreshTokenConfig struct {
+	FamilyID  string `validate:"nonzero"`
+	UserID    int    `validate:"nonzero"`
+	IssuedAt  int64  `validate:"nonzero"`
+	ExpiresAt int64  `validate:"nonzero"`
+}
+
+func (c RefreshTokenConfig) Validate() error {
+	return validator.Validate(c)
+}
+
+func NewRefreshToken(cfg RefreshTokenConfig) (*RefreshToken, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	t := &RefreshToken{
+		ID:        uuid.NewString(),
+		FamilyID:  cfg.FamilyID,
+		UserID:    cfg.UserID,
+		IssuedAt:  cfg.IssuedAt,
+		ExpiresAt: cfg.ExpiresAt,
+	}
+	return t, nil
+}
@@ -1,11 +1,16 @@
 package entity
 
-import "gopkg.in/validator.v2"
+import (
+	"github.com/google/uuid"
+	"gopkg.in/validator.v2"
+)
 
 type SessionConfig struct {
 	UserID   int    `validate:"nonzero"`
 	Username string `validate:"nonzero"`
 	Email    string `validate:"nonzero"`
+	FamilyID string `validate:"nonzero"`
+	IssuedAt int64  `validate:"nonzero"`
 	Exp      int64  `validate:"nonzero"`
 }
 
@@ -18,6 +23,20 @@ type Session struct {
 	Username    string
 	Email       string
 	AccessToken string
+	TokenID     string
+	// FamilyID identifies the login session, it is shared by all tokens
+	// obtained by refreshing the session
+	FamilyID  string
+	IssuedAt  int64
+	ExpiresAt int64
+	// Issuer & Audience are assigned by the token issuer, so they are only
+	// available when the session is parsed from access token
+	Issuer   string
+	Audience []string
+	// RefreshToken & RefreshTokenExpiresAt are only available when the
+	// session is just created or refreshed
+	RefreshToken          string
+	RefreshTokenExpiresAt int64
 }
 
 func NewSession(cfg SessionConfig) (*Session, error) {
@@ -26,9 +45,13 @@ func NewSession(cfg SessionConfig) (*Session, error) {
 		return nil, err
 	}
 	s := &Session{
-		ID:       cfg.UserID,
-		Username: cfg.Username,
-		Email:    cfg.Email,
+		ID:        cfg.UserID,
+		Username:  cfg.Username,
+		Email:     cfg.Email,
+		TokenID:   uuid.NewString(),
+		FamilyID:  cfg.FamilyID,
+		IssuedAt:  cfg.IssuedAt,
+		ExpiresAt: cfg.Exp,
 	}
 	return s, nil
 }
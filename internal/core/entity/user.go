@@ -1,8 +1,127 @@
 package entity
 
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidUsername = errors.New("username must be 3-30 characters of lowercase letters, digits, or underscore")
+	ErrInvalidEmail    = errors.New("email is invalid")
+	ErrWeakPassword    = errors.New("password must be 8-72 characters and contain at least one letter and one digit")
+)
+
+var usernameRegex = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+
 type User struct {
 	ID       int
+	Username string
+	Email    string
+	// PasswordHash is bcrypt or argon2id hash of user password, use
+	// VerifyPassword to check the password. It must never be exposed to
+	// the client.
+	PasswordHash string
+}
+
+// IsPasswordMatch returns true when given plaintext password matches the
+// user password.
+func (u User) IsPasswordMatch(password string) bool {
+	return VerifyPassword(u.PasswordHash, password)
+}
+
+// SetUsername validates then sets the user username. The username is
+// case-insensitive so it is always stored in lowercase.
+func (u *User) SetUsername(username string) error {
+	username = strings.ToLower(strings.TrimSpace(username))
+	if !usernameRegex.MatchString(username) {
+		return ErrInvalidUsername
+	}
+	u.Username = username
+	return nil
+}
+
+// SetEmail validates then sets the user email. The email is case-insensitive
+// so it is always stored in lowercase.
+func (u *User) SetEmail(email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return ErrInvalidEmail
+	}
+	u.Email = email
+	return nil
+}
+
+// SetPassword validates given plaintext password against password policy,
+// then sets the user password hash.
+func (u *User) SetPassword(password string) error {
+	err := validatePassword(password)
+	if err != nil {
+		return err
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = hash
+	return nil
+}
+
+// validatePassword checks given password against password policy. The maximum
+// length follows bcrypt limit.
+func validatePassword(password string) error {
+	if len(password) < 8 || len(password) > 72 {
+		return ErrWeakPassword
+	}
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return ErrWeakPassword
+	}
+	return nil
+}
+
+type UserConfig struct {
 	Username string
 	Email    string
 	Password string
 }
+
+// NewUser returns new user after validating given config. Returns
+// `ErrInvalidUsername`, `ErrInvalidEmail`, or `ErrWeakPassword` when the
+// config is invalid.
+func NewUser(cfg UserConfig) (*User, error) {
+	u := &User{}
+	err := u.SetUsername(cfg.Username)
+	if err != nil {
+		return nil, err
+	}
+	err = u.SetEmail(cfg.Email)
+	if err != nil {
+		return nil, err
+	}
+	err = u.SetPassword(cfg.Password)
+	if err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// UpdateProfileRequest holds the changes of user profile, zero value means
+// the field is unchanged. CurrentPassword is required when changing password.
+type UpdateProfileRequest struct {
+	Username        string
+	Email           string
+	CurrentPassword string
+	NewPassword     string
+}
@@ -0,0 +1,66 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUser(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Config entity.UserConfig
+		ExpErr error
+	}{
+		{
+			Name:   "Valid Config",
+			Config: entity.UserConfig{Username: "Marion_99", Email: " Marion@Eveners.com ", Password: "secret123"},
+			ExpErr: nil,
+		},
+		{
+			Name:   "Username Too Short",
+			Config: entity.UserConfig{Username: "ab", Email: "marion@eveners.com", Password: "secret123"},
+			ExpErr: entity.ErrInvalidUsername,
+		},
+		{
+			Name:   "Username With Invalid Character",
+			Config: entity.UserConfig{Username: "marion.99", Email: "marion@eveners.com", Password: "secret123"},
+			ExpErr: entity.ErrInvalidUsername,
+		},
+		{
+			Name:   "Invalid Email",
+			Config: entity.UserConfig{Username: "marion", Email: "Marion <marion@eveners.com>", Password: "secret123"},
+			ExpErr: entity.ErrInvalidEmail,
+		},
+		{
+			Name:   "Password Too Short",
+			Config: entity.UserConfig{Username: "marion", Email: "marion@eveners.com", Password: "sec123"},
+			ExpErr: entity.ErrWeakPassword,
+		},
+		{
+			Name:   "Password Without Digit",
+			Config: entity.UserConfig{Username: "marion", Email: "marion@eveners.com", Password: "secretsecret"},
+			ExpErr: entity.ErrWeakPassword,
+		},
+		{
+			Name:   "Password Without Letter",
+			Config: entity.UserConfig{Username: "marion", Email: "marion@eveners.com", Password: "12345678"},
+			ExpErr: entity.ErrWeakPassword,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			user, err := entity.NewUser(testCase.Config)
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+			if testCase.ExpErr != nil {
+				return
+			}
+			require.Equal(t, "marion_99", user.Username, "username is not normalized")
+			require.Equal(t, "marion@eveners.com", user.Email, "email is not normalized")
+			require.True(t, user.IsPasswordMatch(testCase.Config.Password), "password is not match")
+		})
+	}
+}
@@ -1,17 +1,268 @@
 package entity
 
+import (
+	"fmt"
+	"time"
+)
+
 type Venue struct {
-	ID              string
-	Name            string
-	OpenDays        []int
-	OpenAt          string
-	ClosedAt        string
-	TimeZone        string
+	ID       int
+	Name     string
+	OpenDays []int
+	OpenAt   string
+	ClosedAt string
+	TimeZone string
+	// WeekdayHours overrides OpenAt & ClosedAt on specific days of the week,
+	// the day must still be listed in OpenDays for the venue to be open
+	WeekdayHours []WeekdayHours
+	// Closures are the periods when the venue is closed regardless of its
+	// operating hours, e.g public holidays or maintenance
+	Closures        []VenueClosure
 	SupportedEvents []SupportedEvent
 }
 
+// GetSupportedEvent returns supported event for given event id. Returns nil
+// when the event is not supported by the venue.
+func (v Venue) GetSupportedEvent(eventID int) *SupportedEvent {
+	for _, supportedEvent := range v.SupportedEvents {
+		if supportedEvent.ID == eventID {
+			return &supportedEvent
+		}
+	}
+	return nil
+}
+
+// IsOpen returns true when the venue is open for the whole given time range.
+// Since venue never opens past midnight, both start & end time must be on the
+// same day in the venue timezone. The time range must also not overlap any of
+// the venue closures.
+func (v Venue) IsOpen(startTs, endTs int) (bool, error) {
+	schedule, err := v.getSchedule()
+	if err != nil {
+		return false, err
+	}
+	return schedule.isOpen(startTs, endTs), nil
+}
+
+// GetAvailabilitySlots splits given time range into consecutive slots of
+// given length in seconds and marks whether the venue is open for each of
+// them. The slots are laid out on the venue wall clock, so on the day of DST
+// transition a slot may be shorter or longer than the granularity, and a slot
+// which falls entirely into the skipped hour is omitted. The remaining
+// capacity of the slots is left for the caller to fill.
+func (v Venue) GetAvailabilitySlots(fromTs, toTs, granularity int) ([]AvailabilitySlot, error) {
+	schedule, err := v.getSchedule()
+	if err != nil {
+		return nil, err
+	}
+	from := time.Unix(int64(fromTs), 0).In(schedule.loc)
+	year, month, day := from.Date()
+	hour, min, sec := from.Clock()
+	var slots []AvailabilitySlot
+	for i := 0; ; i++ {
+		startTs := int(time.Date(year, month, day, hour, min, sec+i*granularity, 0, schedule.loc).Unix())
+		if startTs >= toTs {
+			break
+		}
+		endTs := int(time.Date(year, month, day, hour, min, sec+(i+1)*granularity, 0, schedule.loc).Unix())
+		if startTs < fromTs {
+			startTs = fromTs
+		}
+		if endTs > toTs {
+			endTs = toTs
+		}
+		if endTs <= startTs {
+			continue
+		}
+		slots = append(slots, AvailabilitySlot{
+			StartTs: startTs,
+			EndTs:   endTs,
+			IsOpen:  schedule.isOpen(startTs, endTs),
+		})
+	}
+	return slots, nil
+}
+
+// getSchedule returns the parsed operating hours of the venue.
+func (v Venue) getSchedule() (*venueSchedule, error) {
+	loc, err := time.LoadLocation(v.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load venue timezone due: %w", err)
+	}
+	defaultHours, err := parseHours(v.OpenAt, v.ClosedAt)
+	if err != nil {
+		return nil, err
+	}
+	// the default hours are used for the open days without override
+	schedule := &venueSchedule{loc: loc, closures: v.Closures}
+	for _, day := range v.OpenDays {
+		if day < 0 || day >= len(schedule.hours) {
+			return nil, fmt.Errorf("invalid venue open day: %v", day)
+		}
+		schedule.hours[day] = defaultHours
+	}
+	for _, weekdayHours := range v.WeekdayHours {
+		if weekdayHours.Day < 0 || weekdayHours.Day >= len(schedule.hours) {
+			return nil, fmt.Errorf("invalid venue weekday hours day: %v", weekdayHours.Day)
+		}
+		if schedule.hours[weekdayHours.Day] == nil {
+			continue
+		}
+		hours, err := parseHours(weekdayHours.OpenAt, weekdayHours.ClosedAt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse venue hours on day %v due: %w", weekdayHours.Day, err)
+		}
+		schedule.hours[weekdayHours.Day] = hours
+	}
+	return schedule, nil
+}
+
+// venueSchedule holds the operating hours of a venue, it is parsed once so
+// checking many time ranges does not load the timezone repeatedly.
+type venueSchedule struct {
+	loc *time.Location
+	// hours is indexed by day of the week, nil means the venue is closed
+	hours    [7]*openingHours
+	closures []VenueClosure
+}
+
+func (s venueSchedule) isOpen(startTs, endTs int) bool {
+	start := time.Unix(int64(startTs), 0).In(s.loc)
+	end := time.Unix(int64(endTs), 0).In(s.loc)
+	// make sure both start & end are on the same day
+	startYear, startMonth, startDay := start.Date()
+	endYear, endMonth, endDay := end.Date()
+	if startYear != endYear || startMonth != endMonth || startDay != endDay {
+		return false
+	}
+	// make sure the venue is open on that day
+	hours := s.hours[start.Weekday()]
+	if hours == nil {
+		return false
+	}
+	// make sure the time range is within venue operating hours
+	if secondsOfDay(start) < hours.openAt || secondsOfDay(end) > hours.closedAt {
+		return false
+	}
+	// make sure the venue is not closed in the middle of the time range
+	for _, closure := range s.closures {
+		if closure.IsOverlapping(startTs, endTs) {
+			return false
+		}
+	}
+	return true
+}
+
+// openingHours holds the operating hours of a venue in a day, the values are
+// the number of seconds since midnight.
+type openingHours struct {
+	openAt   int
+	closedAt int
+}
+
+func parseHours(openAt, closedAt string) (*openingHours, error) {
+	openAtSecs, err := parseTimeOfDay(openAt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse venue open time due: %w", err)
+	}
+	closedAtSecs, err := parseTimeOfDay(closedAt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse venue closed time due: %w", err)
+	}
+	return &openingHours{openAt: openAtSecs, closedAt: closedAtSecs}, nil
+}
+
+// VenueFilter holds the criteria for searching venues, zero value field means
+// the criterion is not used.
+type VenueFilter struct {
+	// EventID filters venues that support the event
+	EventID int
+	// MeetupStartTs & MeetupEndTs filter venues that can host a meetup in
+	// the time range, both must be set together
+	MeetupStartTs int
+	MeetupEndTs   int
+}
+
+// Validate returns `ErrInvalidTimeRange` when only one side of the meetup
+// time range is set or the end time is not after the start time.
+func (f VenueFilter) Validate() error {
+	if f.MeetupStartTs == 0 && f.MeetupEndTs == 0 {
+		return nil
+	}
+	if f.MeetupStartTs == 0 || f.MeetupEndTs <= f.MeetupStartTs {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+// HasTimeRange returns true when the filter has meetup time range.
+func (f VenueFilter) HasTimeRange() bool {
+	return f.MeetupStartTs != 0 && f.MeetupEndTs != 0
+}
+
+// WeekdayHours holds the operating hours of a venue on a day of the week.
+type WeekdayHours struct {
+	Day      int
+	OpenAt   string
+	ClosedAt string
+}
+
+// VenueClosure is a period when the venue is closed.
+type VenueClosure struct {
+	StartTs int
+	EndTs   int
+	Reason  string
+}
+
+// Validate returns `ErrInvalidTimeRange` when the end time is not after the
+// start time.
+func (c VenueClosure) Validate() error {
+	if c.StartTs == 0 || c.EndTs <= c.StartTs {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
+
+// IsOverlapping returns true when the closure overlaps with given time range.
+func (c VenueClosure) IsOverlapping(startTs, endTs int) bool {
+	return c.StartTs < endTs && c.EndTs > startTs
+}
+
+// AddVenueClosureResponse holds the added closure along with the existing
+// meetups held during the closure.
+type AddVenueClosureResponse struct {
+	Closure            VenueClosure
+	ConflictingMeetups []ConflictingMeetup
+}
+
+// ConflictingMeetup is a meetup which can no longer be held as scheduled, it
+// carries the organizer so they could be informed.
+type ConflictingMeetup struct {
+	ID        int
+	Name      string
+	Event     MeetupEvent
+	StartTs   int
+	EndTs     int
+	Organizer MeetupOrganizer
+	Status    MeetupStatus
+}
+
 type SupportedEvent struct {
-	ID            string
+	ID            int
 	Name          string
 	EventCapacity int
 }
+
+// parseTimeOfDay parses time of day in `15:04` format into the number of
+// seconds since midnight.
+func parseTimeOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return secondsOfDay(t), nil
+}
+
+func secondsOfDay(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+}
@@ -0,0 +1,278 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVenueGetSupportedEvent(t *testing.T) {
+	venue := newTestVenue()
+
+	// get supported event
+	supportedEvent := venue.GetSupportedEvent(1)
+	require.NotNil(t, supportedEvent, "supported event is nil")
+	require.Equal(t, venue.SupportedEvents[0], *supportedEvent, "mismatch supported event")
+
+	// get unsupported event
+	supportedEvent = venue.GetSupportedEvent(99)
+	require.Nil(t, supportedEvent, "unexpected supported event")
+}
+
+func TestVenueIsOpen(t *testing.T) {
+	// define test cases, 2024-01-08 is a monday
+	venue := newTestVenue()
+	venue.WeekdayHours = []entity.WeekdayHours{
+		{Day: 5, OpenAt: "13:00", ClosedAt: "22:00"},
+		{Day: 0, OpenAt: "08:00", ClosedAt: "22:00"},
+	}
+	venue.Closures = []entity.VenueClosure{
+		{
+			StartTs: newTestTs(t, "2024-01-10 00:00"),
+			EndTs:   newTestTs(t, "2024-01-11 00:00"),
+			Reason:  "Public Holiday",
+		},
+		{
+			StartTs: newTestTs(t, "2024-01-11 10:00"),
+			EndTs:   newTestTs(t, "2024-01-11 12:00"),
+			Reason:  "Maintenance",
+		},
+	}
+	testCases := []struct {
+		Name    string
+		StartTs int
+		EndTs   int
+		IsOpen  bool
+	}{
+		{
+			Name:    "Within Operating Hours",
+			StartTs: newTestTs(t, "2024-01-08 10:00"),
+			EndTs:   newTestTs(t, "2024-01-08 12:00"),
+			IsOpen:  true,
+		},
+		{
+			Name:    "Exactly On Operating Hours",
+			StartTs: newTestTs(t, "2024-01-08 08:00"),
+			EndTs:   newTestTs(t, "2024-01-08 22:00"),
+			IsOpen:  true,
+		},
+		{
+			Name:    "Start Before Open",
+			StartTs: newTestTs(t, "2024-01-08 07:59"),
+			EndTs:   newTestTs(t, "2024-01-08 12:00"),
+			IsOpen:  false,
+		},
+		{
+			Name:    "End After Closed",
+			StartTs: newTestTs(t, "2024-01-08 20:00"),
+			EndTs:   newTestTs(t, "2024-01-08 22:01"),
+			IsOpen:  false,
+		},
+		{
+			Name:    "Closed Day",
+			StartTs: newTestTs(t, "2024-01-07 10:00"),
+			EndTs:   newTestTs(t, "2024-01-07 12:00"),
+			IsOpen:  false,
+		},
+		{
+			Name:    "Before Weekday Open Override",
+			StartTs: newTestTs(t, "2024-01-12 10:00"),
+			EndTs:   newTestTs(t, "2024-01-12 12:00"),
+			IsOpen:  false,
+		},
+		{
+			Name:    "Within Weekday Hours Override",
+			StartTs: newTestTs(t, "2024-01-12 14:00"),
+			EndTs:   newTestTs(t, "2024-01-12 16:00"),
+			IsOpen:  true,
+		},
+		{
+			Name:    "Weekday Hours Override On Closed Day",
+			StartTs: newTestTs(t, "2024-01-14 10:00"),
+			EndTs:   newTestTs(t, "2024-01-14 12:00"),
+			IsOpen:  false,
+		},
+		{
+			Name:    "Public Holiday",
+			StartTs: newTestTs(t, "2024-01-10 10:00"),
+			EndTs:   newTestTs(t, "2024-01-10 12:00"),
+			IsOpen:  false,
+		},
+		{
+			Name:    "Overlap Maintenance",
+			StartTs: newTestTs(t, "2024-01-11 11:00"),
+			EndTs:   newTestTs(t, "2024-01-11 13:00"),
+			IsOpen:  false,
+		},
+		{
+			Name:    "Right After Maintenance",
+			StartTs: newTestTs(t, "2024-01-11 12:00"),
+			EndTs:   newTestTs(t, "2024-01-11 14:00"),
+			IsOpen:  true,
+		},
+		{
+			Name:    "Span Multiple Days",
+			StartTs: newTestTs(t, "2024-01-08 10:00"),
+			EndTs:   newTestTs(t, "2024-01-09 12:00"),
+			IsOpen:  false,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			isOpen, err := venue.IsOpen(testCase.StartTs, testCase.EndTs)
+			require.NoError(t, err)
+			require.Equal(t, testCase.IsOpen, isOpen, "unexpected is open")
+		})
+	}
+
+	// invalid weekday hours, should return error
+	invalidHoursVenue := newTestVenue()
+	invalidHoursVenue.WeekdayHours = []entity.WeekdayHours{{Day: 1, OpenAt: "8 AM", ClosedAt: "22:00"}}
+	_, err := invalidHoursVenue.IsOpen(newTestTs(t, "2024-01-08 10:00"), newTestTs(t, "2024-01-08 12:00"))
+	require.Error(t, err, "expected error")
+
+	// invalid timezone, should return error
+	venue.TimeZone = "Invalid/Timezone"
+	_, err = venue.IsOpen(newTestTs(t, "2024-01-08 10:00"), newTestTs(t, "2024-01-08 12:00"))
+	require.Error(t, err, "expected error")
+}
+
+func TestVenueGetAvailabilitySlots(t *testing.T) {
+	// define test cases, DST in Europe/Berlin starts on 2024-03-31 02:00 and
+	// ends on 2024-10-27 03:00
+	testCases := []struct {
+		Name     string
+		FromTs   int
+		ToTs     int
+		ExpSlots []entity.AvailabilitySlot
+	}{
+		{
+			Name:   "Operating Hours",
+			FromTs: newTestTsIn(t, "Europe/Berlin", "2024-01-08 07:00"),
+			ToTs:   newTestTsIn(t, "Europe/Berlin", "2024-01-08 09:30"),
+			ExpSlots: []entity.AvailabilitySlot{
+				newTestSlot(t, "2024-01-08 07:00", "2024-01-08 08:00", false),
+				newTestSlot(t, "2024-01-08 08:00", "2024-01-08 09:00", true),
+				newTestSlot(t, "2024-01-08 09:00", "2024-01-08 09:30", true),
+			},
+		},
+		{
+			Name:   "DST Starts",
+			FromTs: newTestTsIn(t, "Europe/Berlin", "2024-03-31 01:00"),
+			ToTs:   newTestTsIn(t, "Europe/Berlin", "2024-03-31 04:00"),
+			ExpSlots: []entity.AvailabilitySlot{
+				newTestSlot(t, "2024-03-31 01:00", "2024-03-31 03:00", false),
+				newTestSlot(t, "2024-03-31 03:00", "2024-03-31 04:00", false),
+			},
+		},
+		{
+			Name:   "DST Ends",
+			FromTs: newTestTsIn(t, "Europe/Berlin", "2024-10-27 01:00"),
+			ToTs:   newTestTsIn(t, "Europe/Berlin", "2024-10-27 04:00"),
+			ExpSlots: []entity.AvailabilitySlot{
+				newTestSlot(t, "2024-10-27 01:00", "2024-10-27 02:00", false),
+				newTestSlot(t, "2024-10-27 02:00", "2024-10-27 03:00", false),
+				newTestSlot(t, "2024-10-27 03:00", "2024-10-27 04:00", false),
+			},
+		},
+		{
+			Name:   "Operating Hours After DST Starts",
+			FromTs: newTestTsIn(t, "Europe/Berlin", "2024-03-31 07:00"),
+			ToTs:   newTestTsIn(t, "Europe/Berlin", "2024-03-31 09:00"),
+			ExpSlots: []entity.AvailabilitySlot{
+				newTestSlot(t, "2024-03-31 07:00", "2024-03-31 08:00", false),
+				newTestSlot(t, "2024-03-31 08:00", "2024-03-31 09:00", true),
+			},
+		},
+	}
+	// execute test cases
+	venue := newTestVenue()
+	venue.OpenDays = []int{0, 1, 2, 3, 4, 5, 6}
+	venue.TimeZone = "Europe/Berlin"
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			slots, err := venue.GetAvailabilitySlots(testCase.FromTs, testCase.ToTs, 3600)
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpSlots, slots, "mismatch slots")
+		})
+	}
+}
+
+func TestAvailabilityQueryValidate(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Query  entity.AvailabilityQuery
+		ExpErr error
+	}{
+		{
+			Name:   "Default Granularity",
+			Query:  entity.AvailabilityQuery{FromTs: 0, ToTs: 86400},
+			ExpErr: nil,
+		},
+		{
+			Name:   "Invalid Time Range",
+			Query:  entity.AvailabilityQuery{FromTs: 86400, ToTs: 86400},
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+		{
+			Name:   "Too Short Granularity",
+			Query:  entity.AvailabilityQuery{FromTs: 0, ToTs: 86400, Granularity: 59},
+			ExpErr: entity.ErrInvalidGranularity,
+		},
+		{
+			Name:   "Too Many Slots",
+			Query:  entity.AvailabilityQuery{FromTs: 0, ToTs: 60001, Granularity: 60},
+			ExpErr: entity.ErrInvalidGranularity,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			require.Equal(t, testCase.ExpErr, testCase.Query.Validate(), "unexpected error")
+		})
+	}
+}
+
+func newTestVenue() entity.Venue {
+	return entity.Venue{
+		ID:       1,
+		Name:     "Si Jalak Harupat",
+		OpenDays: []int{1, 2, 3, 4, 5, 6},
+		OpenAt:   "8:00",
+		ClosedAt: "22:00",
+		TimeZone: "Asia/Jakarta",
+		SupportedEvents: []entity.SupportedEvent{
+			{
+				ID:            1,
+				Name:          "Wedding",
+				EventCapacity: 2,
+			},
+		},
+	}
+}
+
+func newTestSlot(t *testing.T, start, end string, isOpen bool) entity.AvailabilitySlot {
+	return entity.AvailabilitySlot{
+		StartTs: newTestTsIn(t, "Europe/Berlin", start),
+		EndTs:   newTestTsIn(t, "Europe/Berlin", end),
+		IsOpen:  isOpen,
+	}
+}
+
+// newTestTs returns unix timestamp for given time in Asia/Jakarta timezone
+func newTestTs(t *testing.T, value string) int {
+	return newTestTsIn(t, "Asia/Jakarta", value)
+}
+
+// newTestTsIn returns unix timestamp for given time in given timezone
+func newTestTsIn(t *testing.T, timeZone string, value string) int {
+	loc, err := time.LoadLocation(timeZone)
+	require.NoError(t, err)
+	ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	require.NoError(t, err)
+	return int(ts.Unix())
+}
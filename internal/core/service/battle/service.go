@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
-	"time"
+	"sync"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"gopkg.in/validator.v2"
@@ -47,6 +47,11 @@ type service struct {
 	gameStorage    GameStorage
 	battleStorage  BattleStorage
 	monsterStorage MonsterStorage
+
+	// rnd is not safe for concurrent use, so it must be accessed while
+	// holding rndMtx
+	rndMtx sync.Mutex
+	rnd    *rand.Rand
 }
 
 func (s *service) StartBattle(ctx context.Context, gameID string) (*entity.Battle, error) {
@@ -68,8 +73,9 @@ func (s *service) StartBattle(ctx context.Context, gameID string) (*entity.Battl
 	if err != nil {
 		return nil, fmt.Errorf("unable to get possible enemies due: %w", err)
 	}
-	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
-	enemy := enemies[rnd.Intn(len(enemies))]
+	s.rndMtx.Lock()
+	enemy := enemies[s.rnd.Intn(len(enemies))]
+	s.rndMtx.Unlock()
 	// create new battle instance
 	battle, err = entity.NewBattle(entity.BattleConfig{
 		GameID:  gameID,
@@ -123,7 +129,9 @@ func (s *service) DecideTurn(ctx context.Context, gameID string) (*entity.Battle
 	if err != nil {
 		return nil, err
 	}
-	newState, err := battle.DecideTurn()
+	s.rndMtx.Lock()
+	newState, err := battle.DecideTurn(s.rnd)
+	s.rndMtx.Unlock()
 	if err != nil {
 		return nil, ErrInvalidBattleState
 	}
@@ -194,6 +202,9 @@ type ServiceConfig struct {
 	GameStorage    GameStorage    `validate:"nonnil"`
 	BattleStorage  BattleStorage  `validate:"nonnil"`
 	MonsterStorage MonsterStorage `validate:"nonnil"`
+	// Rand is used for choosing the enemy & deciding the turns, seed it
+	// with fixed value to make the battle reproducible
+	Rand *rand.Rand `validate:"nonnil"`
 }
 
 func (c ServiceConfig) Validate() error {
@@ -210,6 +221,7 @@ func NewService(cfg ServiceConfig) (Service, error) {
 		gameStorage:    cfg.GameStorage,
 		battleStorage:  cfg.BattleStorage,
 		monsterStorage: cfg.MonsterStorage,
+		rnd:            cfg.Rand,
 	}
 	return svc, nil
 }
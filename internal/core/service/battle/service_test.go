@@ -19,6 +19,7 @@ package battle_test
 import (
 	"context"
 	"math"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -34,6 +35,7 @@ func TestNewService(t *testing.T) {
 	gameStorage := newMockGameStorage()
 	battleStorage := newMockBattleStorage()
 	monsterStorage := newMockMonsterStorage()
+	rnd := rand.New(rand.NewSource(1))
 
 	// define test cases
 	testCases := []struct {
@@ -47,6 +49,7 @@ func TestNewService(t *testing.T) {
 				GameStorage:    nil,
 				BattleStorage:  battleStorage,
 				MonsterStorage: monsterStorage,
+				Rand:           rnd,
 			},
 			IsError: true,
 		},
@@ -56,6 +59,17 @@ func TestNewService(t *testing.T) {
 				GameStorage:    gameStorage,
 				BattleStorage:  battleStorage,
 				MonsterStorage: nil,
+				Rand:           rnd,
+			},
+			IsError: true,
+		},
+		{
+			Name: "Test Missing Rand",
+			Config: battle.ServiceConfig{
+				GameStorage:    gameStorage,
+				BattleStorage:  battleStorage,
+				MonsterStorage: monsterStorage,
+				Rand:           nil,
 			},
 			IsError: true,
 		},
@@ -65,6 +79,7 @@ func TestNewService(t *testing.T) {
 				GameStorage:    gameStorage,
 				BattleStorage:  battleStorage,
 				MonsterStorage: monsterStorage,
+				Rand:           rnd,
 			},
 			IsError: false,
 		},
@@ -126,6 +141,7 @@ func TestServiceStartBattle(t *testing.T) {
 				GameStorage:    gameStorage,
 				BattleStorage:  battleStorage,
 				MonsterStorage: monsterStorage,
+				Rand:           rand.New(rand.NewSource(1)),
 			})
 			battle, err := svc.StartBattle(context.Background(), gameID)
 			require.Equal(t, testCase.IsError, (err != nil), "unexpected error")
@@ -201,6 +217,7 @@ func TestServiceGetBattle(t *testing.T) {
 				GameStorage:    gameStorage,
 				BattleStorage:  battleStorage,
 				MonsterStorage: monsterStorage,
+				Rand:           rand.New(rand.NewSource(1)),
 			})
 			newBattle, err := svc.GetBattle(context.Background(), gameID)
 			require.Equal(t, testCase.IsError, (err != nil), "unexpected error")
@@ -419,6 +436,7 @@ func initService(t *testing.T) *initServiceOutput {
 		GameStorage:    gameStorage,
 		BattleStorage:  battleStorage,
 		MonsterStorage: monsterStorage,
+		Rand:           rand.New(rand.NewSource(1)),
 	})
 	require.NoError(t, err)
 
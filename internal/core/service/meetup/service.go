@@ -4,100 +4,248 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"gopkg.in/validator.v2"
 )
 
 var (
-	ErrMeetupNotFound = errors.New("meetup is not found")
+	ErrMeetupNotFound      = errors.New("meetup is not found")
+	ErrVenueNotFound       = errors.New("venue is not found")
+	ErrInvalidEvent        = errors.New("event is not supported by the venue")
+	ErrVenueIsClosed       = errors.New("venue is closed on the designated meetup time")
+	ErrExceedVenueCapacity = errors.New("venue capacity is full on the designated meetup time")
+	ErrMeetupCancelled     = errors.New("meetup is cancelled")
+	ErrMeetupFinished      = errors.New("meetup is finished")
+	ErrMeetupStarted       = errors.New("meetup is already started")
+	ErrStartTimeInPast     = errors.New("meetup start time is in the past")
+	ErrMeetupClosed        = errors.New("meetup is closed")
+	ErrAlreadyJoined       = errors.New("user already joined the meetup")
+	ErrMeetupOverlaps      = errors.New("meetup overlaps with other meetup that user already joined")
+	ErrUserNotParticipant  = errors.New("user is not a participant")
+	ErrForbidden           = errors.New("user is not the organizer of the meetup")
+
+	ErrMaxPersonsLessThanJoinedPersons = errors.New("max persons is less than number of joined persons")
 )
 
 type Service interface {
-	// CreateMeetup is used to add a new meetup to the system. If the given `meetupID` not found in storage,
-	// it returns `ErrMeetupNotFound`. Upon success it returns meetup instance that being saved on storage.
 	// CreateMeetup is used to add a new meetup to the system. Meetup is a gathering event in a venue in a specific range of time.
 	// Meetup can only be created in a venue that supports the event, within the operating hours of the venue, and not exceeding the capacity of the venue in that time.
+	// Otherwise it returns `ErrInvalidEvent`, `ErrVenueIsClosed`, or `ErrExceedVenueCapacity` respectively. Meetup that doesn't
+	// start in the future returns `ErrStartTimeInPast`. If the given venue is not found in storage, it returns `ErrVenueNotFound`.
+	// Upon success it returns meetup instance that being saved on storage.
 	CreateMeetup(ctx context.Context, req entity.CreateMeetupRequest) (*entity.Meetup, error)
 
-	// GetMeetups returns all meetups available in the system.
-	GetMeetups(ctx context.Context) ([]entity.GetMeetupsResponse, error)
+	// GetMeetups returns a page of meetups matching given query sorted by their start time. The next
+	// page is fetched by passing the returned next cursor in the query. When the query is invalid it
+	// returns `entity.ErrInvalidLimit`, `entity.ErrInvalidStatus`, `entity.ErrInvalidTimeRange` or
+	// `entity.ErrInvalidCursor`.
+	GetMeetups(ctx context.Context, query entity.MeetupQuery) (*entity.MeetupPage, error)
 
 	// GetMeetup returns a single meetup from storage from given meetup id. Upon meetup is not found, it returns
-	// `ErrMeetupNotFound`.
+	// `ErrMeetupNotFound`. The meetup is viewed by the caller, so the joined persons & the cancellation details
+	// are only returned to the organizer & participants of the meetup.
 	GetMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error)
 
 	// UpdateMeetup is used to update a meetup. Only the organizer of the meetup can update the meetup. Update action is limited to:
 	// - Change the name of the meetup
 	// - Change the start and end time of the meetup, but it still need to follows the rules of Create Meetup endpoint
 	// - Update maximum number of persons that can join the meetup
+	//
+	// If the user is not the organizer, it returns `ErrForbidden`. Setting max persons lower than the number
+	// of joined persons returns `ErrMaxPersonsLessThanJoinedPersons`, while setting it exactly to the number
+	// of joined persons closes the meetup. Rescheduling started meetup returns `ErrMeetupStarted`, while
+	// rescheduling it to start in the past returns `entity.ErrInvalidTimeRange`.
 	UpdateMeetup(ctx context.Context, meetupID int, req entity.UpdateMeetupRequest) (*entity.Meetup, error)
 
-	// CancelMeetup is used to cancel a meetup. Only the organizer of the meetup can cancel the meetup.
-	// Meetup can only be cancelled if it isn't started yet.
+	// CancelMeetup is used to cancel a meetup. Only the organizer of the meetup can cancel the meetup,
+	// otherwise it returns `ErrForbidden`. Meetup can only be cancelled if it isn't started yet.
+	// Cancelling a meetup which is already cancelled or started returns `ErrMeetupCancelled` or
+	// `ErrMeetupStarted` respectively.
 	CancelMeetup(ctx context.Context, meetupID int, cancelledReason string) (*entity.CancelMeetupResponse, error)
 
 	// JoinMeetup is used to join a meetup. User can only join a meetup if the meetup is still open
 	// which means the meetup hasn't reached the maximum number of persons, not cancelled, and not finished yet.
+	// Otherwise it returns `ErrMeetupClosed`, `ErrMeetupCancelled`, or `ErrMeetupFinished` respectively. If the
+	// user already joined the meetup, it returns `ErrAlreadyJoined`. User also cannot join meetup which overlaps
+	// with other non-cancelled meetup he/she already joined, in such case it returns `ErrMeetupOverlaps`.
 	JoinMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error)
 
 	// LeaveMeetup is used to leave a meetup. User can only leave a meetup if he/she already
-	// joined the meetup, also the meetup is not cancelled or finished yet.
+	// joined the meetup, also the meetup is not cancelled or finished yet. Otherwise it returns
+	// `ErrUserNotParticipant`, `ErrMeetupCancelled`, or `ErrMeetupFinished` respectively.
 	LeaveMeetup(ctx context.Context, meetupID int) error
 
-	// GetIncomingMeetups is used to list future meetups that are joined by a user. The returned meetup
-	// statuses are either open or cancelled.
-	GetIncomingMeetups(ctx context.Context) ([]entity.Meetup, error)
+	// GetIncomingMeetups is used to list future meetups that are joined or organized by the caller,
+	// sorted by their start time. The returned meetup statuses are either open, closed or cancelled.
+	// When the filter status is invalid it returns `entity.ErrInvalidStatus`.
+	GetIncomingMeetups(ctx context.Context, filter entity.IncomingMeetupFilter) ([]entity.Meetup, error)
 }
 
 type service struct {
 	meetupStorage MeetupStorage
+	venueStorage  VenueStorage
+	clock         Clock
 }
 
 func (s *service) CreateMeetup(ctx context.Context, req entity.CreateMeetupRequest) (*entity.Meetup, error) {
-	// TODO: validation
+	// the user who creates the meetup becomes its organizer
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
 	// initiate new meetup instance
 	cfg := ConvertRequestToConfig(req)
 	meetup, err := entity.NewMeetup(cfg)
 	if err != nil {
+		if errors.Is(err, entity.ErrInvalidTimeRange) {
+			return nil, entity.ErrInvalidTimeRange
+		}
 		return nil, fmt.Errorf("unable to initialize meetup instance due: %w", err)
 	}
+	// meetup could only be created for the future
+	if meetup.IsStarted(s.clock.Now().Unix()) {
+		return nil, ErrStartTimeInPast
+	}
+	meetup.Organizer = entity.MeetupOrganizer{
+		ID:       caller.ID,
+		Username: caller.Username,
+		Email:    caller.Email,
+	}
+	// get the venue where the meetup will be held
+	venue, err := s.getVenue(ctx, meetup.Venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	// make sure the meetup follows the venue rules
+	supportedEvent, err := s.validateSchedule(ctx, *venue, *meetup)
+	if err != nil {
+		return nil, err
+	}
+	meetup.Venue.Name = venue.Name
+	meetup.Event.Name = supportedEvent.Name
 	// store the meetup instance on storage
-	err = s.meetupStorage.SaveMeetup(ctx, *meetup)
+	meetup.ID, err = s.meetupStorage.SaveMeetup(ctx, *meetup)
 	if err != nil {
 		return nil, fmt.Errorf("unable to save meetup instance due: %w", err)
 	}
 	return meetup, nil
 }
 
+// getVenue returns venue for given venue id. Returns `ErrVenueNotFound` when the
+// venue is not found.
+func (s *service) getVenue(ctx context.Context, venueID int) (*entity.Venue, error) {
+	venue, err := s.venueStorage.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get venue due: %w", err)
+	}
+	if venue == nil {
+		return nil, ErrVenueNotFound
+	}
+	return venue, nil
+}
+
+// validateSchedule makes sure given meetup is held in venue that supports its event,
+// within the venue operating hours, and not exceeding the venue capacity for the
+// event on that time. Upon success it returns the venue supported event of the meetup.
+func (s *service) validateSchedule(ctx context.Context, venue entity.Venue, meetup entity.Meetup) (*entity.SupportedEvent, error) {
+	// make sure the venue supports the event
+	supportedEvent := venue.GetSupportedEvent(meetup.Event.ID)
+	if supportedEvent == nil {
+		return nil, ErrInvalidEvent
+	}
+	// make sure the venue is open on the meetup time
+	isOpen, err := venue.IsOpen(meetup.StartTs, meetup.EndTs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check venue operating hours due: %w", err)
+	}
+	if !isOpen {
+		return nil, ErrVenueIsClosed
+	}
+	// make sure the venue still has capacity on the meetup time
+	overlaps, err := s.meetupStorage.GetOverlappingMeetups(ctx, venue.ID, meetup.Event.ID, meetup.StartTs, meetup.EndTs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get overlapping meetups due: %w", err)
+	}
+	// exclude the meetup itself, this is for the case when existing meetup is rescheduled
+	var others []entity.Meetup
+	for _, overlap := range overlaps {
+		if overlap.ID != meetup.ID {
+			others = append(others, overlap)
+		}
+	}
+	if entity.CountMaxConcurrentMeetups(others, meetup.StartTs, meetup.EndTs)+1 > supportedEvent.EventCapacity {
+		return nil, ErrExceedVenueCapacity
+	}
+	return supportedEvent, nil
+}
+
 // Conversion function
 func ConvertRequestToConfig(req entity.CreateMeetupRequest) entity.MeetupConfig {
 	return entity.MeetupConfig(req)
 }
 
-func (s *service) GetMeetups(ctx context.Context) ([]entity.GetMeetupsResponse, error) {
-	meetups, err := s.meetupStorage.GetMeetups(ctx)
+func (s *service) GetMeetups(ctx context.Context, query entity.MeetupQuery) (*entity.MeetupPage, error) {
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+	// fetch one more meetup to find out whether there is next page
+	limit := query.GetLimit()
+	query.Limit = limit + 1
+	now := s.clock.Now().Unix()
+	meetups, err := s.meetupStorage.GetMeetups(ctx, query, now)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get available meetups due: %w", err)
+		return nil, fmt.Errorf("unable to get meetups due: %w", err)
 	}
-	res := make([]entity.GetMeetupsResponse, len(meetups))
-	for i := 0; i < len(meetups); i++ {
-		meetup := entity.GetMeetupsResponse{
-			ID: meetups[i].ID,
-			// TODO: fill fields
-		}
-		res = append(res, meetup)
+	page := &entity.MeetupPage{Meetups: []entity.GetMeetupsResponse{}}
+	if len(meetups) > limit {
+		meetups = meetups[:limit]
+		page.NextCursor = entity.NewMeetupCursor(meetups[limit-1]).Encode()
 	}
-	return res, nil
+	for _, meetup := range meetups {
+		meetup.RefreshStatus(now)
+		page.Meetups = append(page.Meetups, entity.GetMeetupsResponse{
+			ID:                 meetup.ID,
+			Name:               meetup.Name,
+			Venue:              meetup.Venue,
+			Event:              meetup.Event,
+			StartTs:            meetup.StartTs,
+			EndTs:              meetup.EndTs,
+			MaxPersons:         meetup.MaxPersons,
+			Organizer:          meetup.Organizer,
+			JoinedPersonsCount: meetup.JoinedPersonsCount,
+			Status:             meetup.Status,
+		})
+	}
+	return page, nil
 }
 
 func (s *service) GetMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error) {
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	meetup, err := s.getMeetup(ctx, meetupID)
+	if err != nil {
+		return nil, err
+	}
+	view := meetup.ViewFor(caller.ID)
+	return &view, nil
+}
+
+// getMeetup returns meetup for given meetup id with its status refreshed to the
+// current time. Returns `ErrMeetupNotFound` when the meetup is not found.
+func (s *service) getMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error) {
 	meetup, err := s.getMeetupInstance(ctx, meetupID)
+	if err != nil {
+		return nil, err
+	}
 	if meetup == nil {
 		return nil, ErrMeetupNotFound
 	}
-	return meetup, err
+	meetup.RefreshStatus(s.clock.Now().Unix())
+	return meetup, nil
 }
 
 // getMeetupInstance returns meetup for given meetup id, if meetup is not found
@@ -113,22 +261,118 @@ func (s *service) getMeetupInstance(ctx context.Context, meetupID int) (*entity.
 	return meetup, nil
 }
 
-// UpdateMeetup implements Service.
 func (s *service) UpdateMeetup(ctx context.Context, meetupID int, req entity.UpdateMeetupRequest) (*entity.Meetup, error) {
-	panic("unimplemented")
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// get existing meetup, make sure it is updated by its organizer
+	meetup, err := s.getMeetup(ctx, meetupID)
+	if err != nil {
+		return nil, err
+	}
+	if meetup.Organizer.ID != caller.ID {
+		return nil, ErrForbidden
+	}
+	err = validateOngoing(*meetup)
+	if err != nil {
+		return nil, err
+	}
+	// apply the changes
+	now := s.clock.Now().Unix()
+	isStarted := meetup.IsStarted(now)
+	if req.Name != "" {
+		meetup.Name = req.Name
+	}
+	isRescheduled := false
+	if req.StartTs != 0 && req.StartTs != meetup.StartTs {
+		meetup.StartTs = req.StartTs
+		isRescheduled = true
+	}
+	if req.EndTs != 0 && req.EndTs != meetup.EndTs {
+		meetup.EndTs = req.EndTs
+		isRescheduled = true
+	}
+	if meetup.EndTs <= meetup.StartTs {
+		return nil, entity.ErrInvalidTimeRange
+	}
+	// started meetup could not be rescheduled, neither into the past
+	if isRescheduled && isStarted {
+		return nil, ErrMeetupStarted
+	}
+	if isRescheduled && meetup.IsStarted(now) {
+		return nil, entity.ErrInvalidTimeRange
+	}
+	if req.MaxPersons != 0 {
+		// setting max persons to the number of joined persons closes the meetup
+		err = meetup.SetMaxPersons(req.MaxPersons, now)
+		if err != nil {
+			return nil, ErrMaxPersonsLessThanJoinedPersons
+		}
+	}
+	// rescheduled meetup must still follow the venue rules
+	if isRescheduled {
+		venue, err := s.getVenue(ctx, meetup.Venue.ID)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.validateSchedule(ctx, *venue, *meetup)
+		if err != nil {
+			return nil, err
+		}
+	}
+	meetup.RefreshStatus(s.clock.Now().Unix())
+	// store the changes, the storage makes sure max persons never goes below
+	// number of joined persons even when other users are joining at the same time
+	isUpdated, err := s.meetupStorage.UpdateMeetup(ctx, *meetup)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update meetup due: %w", err)
+	}
+	if !isUpdated {
+		// the meetup might be cancelled after it is read
+		meetup, err = s.GetMeetup(ctx, meetupID)
+		if err != nil {
+			return nil, err
+		}
+		err = validateOngoing(*meetup)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrMaxPersonsLessThanJoinedPersons
+	}
+	return s.GetMeetup(ctx, meetupID)
 }
 
 func (s *service) CancelMeetup(ctx context.Context, meetupID int, cancelledReason string) (*entity.CancelMeetupResponse, error) {
-	// get existing meetup
-	meetup, err := s.GetMeetup(ctx, meetupID)
+	caller, err := entity.GetCaller(ctx)
 	if err != nil {
 		return nil, err
 	}
+	// get existing meetup, make sure it is cancelled by its organizer
+	meetup, err := s.getMeetup(ctx, meetupID)
+	if err != nil {
+		return nil, err
+	}
+	if meetup.Organizer.ID != caller.ID {
+		return nil, ErrForbidden
+	}
+	// make sure the meetup is still cancellable
+	err = meetup.Cancel(caller.ID, cancelledReason, s.clock.Now().Unix())
+	if err != nil {
+		if meetup.Status == entity.MeetupStatusCancelled {
+			return nil, ErrMeetupCancelled
+		}
+		return nil, ErrMeetupStarted
+	}
 
-	// delete meetup
-	err = s.meetupStorage.CancelMeetup(ctx, meetup.ID, cancelledReason)
+	// cancel meetup
+	err = s.meetupStorage.CancelMeetup(ctx, *meetup)
 	if err != nil {
-		return nil, fmt.Errorf("unable to delete meetup due: %w", err)
+		// the meetup may be cancelled concurrently after it is read
+		if errors.Is(err, ErrMeetupCancelled) {
+			return nil, ErrMeetupCancelled
+		}
+		return nil, fmt.Errorf("unable to cancel meetup due: %w", err)
 	}
 	return &entity.CancelMeetupResponse{
 		ID:              meetup.ID,
@@ -140,34 +384,172 @@ func (s *service) CancelMeetup(ctx context.Context, meetupID int, cancelledReaso
 		MaxPersons:      meetup.MaxPersons,
 		Organizer:       meetup.Organizer,
 		Status:          meetup.Status,
-		CancelledReason: cancelledReason,
-		CancelledAt:     time.Now().Unix(), // TODO: make relation between meetup and meetup_cancelled_reason
+		CancelledReason: meetup.CancelledReason,
+		CancelledAt:     meetup.CancelledAt,
+		CancelledBy:     meetup.CancelledBy,
 	}, nil
 }
 
-// JoinMeetup implements Service.
 func (s *service) JoinMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error) {
-	panic("unimplemented")
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// get existing meetup, make sure it is still joinable
+	meetup, err := s.getMeetup(ctx, meetupID)
+	if err != nil {
+		return nil, err
+	}
+	err = validateOngoing(*meetup)
+	if err != nil {
+		return nil, err
+	}
+	if meetup.IsParticipant(caller.ID) {
+		return nil, ErrAlreadyJoined
+	}
+	now := s.clock.Now().Unix()
+	person := entity.JoinedPerson{
+		ID:       caller.ID,
+		Username: caller.Username,
+		Email:    caller.Email,
+		JoinedAt: int(now),
+	}
+	err = meetup.Join(person, now)
+	if err != nil {
+		return nil, getStatusError(meetup.Status)
+	}
+	err = s.validateNoOverlap(ctx, caller.ID, *meetup)
+	if err != nil {
+		return nil, err
+	}
+	// join the meetup, the storage guarantees the meetup seats never exceeded
+	// even when there are many users joining at the same time
+	isJoined, err := s.meetupStorage.JoinMeetup(ctx, meetupID, person)
+	if err != nil {
+		return nil, fmt.Errorf("unable to join meetup due: %w", err)
+	}
+	// get the latest state of the meetup, if the user is failed to join this is
+	// also used to determine the reason
+	meetup, err = s.GetMeetup(ctx, meetupID)
+	if err != nil {
+		return nil, err
+	}
+	if !isJoined {
+		if meetup.IsParticipant(caller.ID) {
+			return nil, ErrAlreadyJoined
+		}
+		err = validateOngoing(*meetup)
+		if err != nil {
+			return nil, err
+		}
+		return nil, ErrMeetupClosed
+	}
+	return meetup, nil
+}
+
+// validateNoOverlap makes sure user with given id hasn't joined other
+// non-cancelled meetup overlapping with given meetup. Returns `ErrMeetupOverlaps`
+// when there is such meetup.
+func (s *service) validateNoOverlap(ctx context.Context, userID int, meetup entity.Meetup) error {
+	// overlapping meetup must start after this point since no meetup lasts
+	// longer than the max duration
+	startAfterTs := meetup.StartTs - entity.MaxMeetupDurationSecs
+	meetups, err := s.meetupStorage.GetParticipantMeetups(ctx, userID, startAfterTs)
+	if err != nil {
+		return fmt.Errorf("unable to get participant meetups due: %w", err)
+	}
+	for _, other := range meetups {
+		if other.ID == meetup.ID || !other.IsParticipant(userID) || other.Status == entity.MeetupStatusCancelled {
+			continue
+		}
+		if other.IsOverlapping(meetup.StartTs, meetup.EndTs) {
+			return ErrMeetupOverlaps
+		}
+	}
+	return nil
 }
 
-// LeaveMeetup implements Service.
 func (s *service) LeaveMeetup(ctx context.Context, meetupID int) error {
-	panic("unimplemented")
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return err
+	}
+	// get existing meetup, make sure it is still leaveable
+	meetup, err := s.getMeetup(ctx, meetupID)
+	if err != nil {
+		return err
+	}
+	err = validateOngoing(*meetup)
+	if err != nil {
+		return err
+	}
+	err = meetup.Leave(caller.ID, s.clock.Now().Unix())
+	if err != nil {
+		return ErrUserNotParticipant
+	}
+	// leave the meetup
+	isLeft, err := s.meetupStorage.LeaveMeetup(ctx, meetupID, caller.ID)
+	if err != nil {
+		return fmt.Errorf("unable to leave meetup due: %w", err)
+	}
+	if !isLeft {
+		return ErrUserNotParticipant
+	}
+	return nil
 }
 
-func (s *service) GetIncomingMeetups(ctx context.Context) ([]entity.Meetup, error) {
-	// TODO: validation
-	//
+// validateOngoing makes sure given meetup is not cancelled or finished yet, the
+// meetup status must be refreshed beforehand.
+func validateOngoing(meetup entity.Meetup) error {
+	if !meetup.IsOngoing() {
+		return getStatusError(meetup.Status)
+	}
+	return nil
+}
 
-	meetups, err := s.meetupStorage.GetMeetups(ctx)
+// getStatusError returns the error describing why an action is rejected on
+// meetup with given status.
+func getStatusError(status entity.MeetupStatus) error {
+	switch status {
+	case entity.MeetupStatusCancelled:
+		return ErrMeetupCancelled
+	case entity.MeetupStatusFinished:
+		return ErrMeetupFinished
+	case entity.MeetupStatusClosed:
+		return ErrMeetupClosed
+	}
+	return fmt.Errorf("unexpected action on meetup with status %v", status)
+}
+
+func (s *service) GetIncomingMeetups(ctx context.Context, filter entity.IncomingMeetupFilter) ([]entity.Meetup, error) {
+	caller, err := entity.GetCaller(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get available meetups due: %w", err)
+		return nil, err
 	}
-	return meetups, nil
+	err = filter.Validate()
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now().Unix()
+	meetups, err := s.meetupStorage.GetParticipantMeetups(ctx, caller.ID, int(now))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get participant meetups due: %w", err)
+	}
+	res := []entity.Meetup{}
+	for _, meetup := range meetups {
+		meetup.RefreshStatus(now)
+		if !filter.IsMatch(meetup) {
+			continue
+		}
+		res = append(res, meetup.ViewFor(caller.ID))
+	}
+	return res, nil
 }
 
 type ServiceConfig struct {
 	MeetupStorage MeetupStorage `validate:"nonnil"`
+	VenueStorage  VenueStorage  `validate:"nonnil"`
+	Clock         Clock         `validate:"nonnil"`
 }
 
 func (c ServiceConfig) Validate() error {
@@ -182,6 +564,8 @@ func NewService(cfg ServiceConfig) (Service, error) {
 	}
 	s := &service{
 		meetupStorage: cfg.MeetupStorage,
+		venueStorage:  cfg.VenueStorage,
+		clock:         cfg.Clock,
 	}
 	return s, nil
 }
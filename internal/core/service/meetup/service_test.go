@@ -0,0 +1,1482 @@
+package meetup_test
+
+/*
+	The purpose of testing the Service component is to ensure it has correct
+	implementation of business logic.
+
+	The common pitfall when creating test for Service component is we tend to use
+	concrete implementation for the dependency components (e.g actual MeetupStorage
+	for MySQL). Not only this will increase the test complexity but also it will
+	increase the possibility of getting false test result. The reason is simply
+	because service such as MySQL has its own constraints & has much higher chance
+	of failing rather than its mock counterpart (e.g network failure).
+
+	So to avoid this pitfall, our first go to choice is to use mock implementation
+	for the dependency when testing the Service component. This way we can control
+	more the behavior of the dependency components to fit our test scenarios.
+*/
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
+	"github.com/Haraj-backend/hex-monscape/internal/core/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewService(t *testing.T) {
+	// define mock dependencies
+	meetupStorage := newMockMeetupStorage()
+	venueStorage := newMockVenueStorage(nil)
+	clock := testutil.NewFakeClock(testNow)
+
+	// define test cases
+	testCases := []struct {
+		Name    string
+		Config  meetup.ServiceConfig
+		IsError bool
+	}{
+		{
+			Name: "Test Missing Meetup Storage",
+			Config: meetup.ServiceConfig{
+				MeetupStorage: nil,
+				VenueStorage:  venueStorage,
+				Clock:         clock,
+			},
+			IsError: true,
+		},
+		{
+			Name: "Test Missing Venue Storage",
+			Config: meetup.ServiceConfig{
+				MeetupStorage: meetupStorage,
+				VenueStorage:  nil,
+				Clock:         clock,
+			},
+			IsError: true,
+		},
+		{
+			Name: "Test Missing Clock",
+			Config: meetup.ServiceConfig{
+				MeetupStorage: meetupStorage,
+				VenueStorage:  venueStorage,
+				Clock:         nil,
+			},
+			IsError: true,
+		},
+		{
+			Name: "Test Valid Config",
+			Config: meetup.ServiceConfig{
+				MeetupStorage: meetupStorage,
+				VenueStorage:  venueStorage,
+				Clock:         clock,
+			},
+			IsError: false,
+		},
+	}
+	// execute test cases
+	for _, testcase := range testCases {
+		t.Run(testcase.Name, func(t *testing.T) {
+			_, err := meetup.NewService(testcase.Config)
+			require.Equal(t, testcase.IsError, (err != nil), "unexpected error")
+		})
+	}
+}
+
+func TestServiceCreateMeetup(t *testing.T) {
+	// initialize new service, the clock is set before the meetups so they
+	// could still be created
+	output := newService()
+	output.Clock.Set(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// fill venue with existing meetups, 2024-01-08 is a monday
+	output.MeetupStorage.AddMeetup(newTestMeetup(t, "2024-01-08 10:00", "2024-01-08 12:00"))
+	output.MeetupStorage.AddMeetup(newTestMeetup(t, "2024-01-08 11:00", "2024-01-08 13:00"))
+	output.MeetupStorage.AddMeetup(newTestMeetup(t, "2024-01-08 14:00", "2024-01-08 16:00"))
+	cancelledMeetup := newTestMeetup(t, "2024-01-08 14:00", "2024-01-08 16:00")
+	cancelledMeetup.Status = entity.MeetupStatusCancelled
+	output.MeetupStorage.AddMeetup(cancelledMeetup)
+
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Req    entity.CreateMeetupRequest
+		ExpErr error
+	}{
+		{
+			Name:   "Test Venue Not Found",
+			Req:    newTestCreateMeetupRequest(t, 99, 1, "2024-01-08 16:00", "2024-01-08 18:00"),
+			ExpErr: meetup.ErrVenueNotFound,
+		},
+		{
+			Name:   "Test Event Not Supported",
+			Req:    newTestCreateMeetupRequest(t, 1, 99, "2024-01-08 16:00", "2024-01-08 18:00"),
+			ExpErr: meetup.ErrInvalidEvent,
+		},
+		{
+			Name:   "Test Venue Closed Day",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-07 16:00", "2024-01-07 18:00"),
+			ExpErr: meetup.ErrVenueIsClosed,
+		},
+		{
+			Name:   "Test Outside Operating Hours",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 21:00", "2024-01-08 23:00"),
+			ExpErr: meetup.ErrVenueIsClosed,
+		},
+		{
+			Name:   "Test Outside Weekday Hours",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-12 10:00", "2024-01-12 12:00"),
+			ExpErr: meetup.ErrVenueIsClosed,
+		},
+		{
+			Name:   "Test Venue Closure",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-10 10:00", "2024-01-10 12:00"),
+			ExpErr: meetup.ErrVenueIsClosed,
+		},
+		{
+			Name:   "Test Exceed Venue Capacity",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 11:30", "2024-01-08 12:30"),
+			ExpErr: meetup.ErrExceedVenueCapacity,
+		},
+		{
+			Name:   "Test Right After Full Time Range",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 13:00", "2024-01-08 14:00"),
+			ExpErr: nil,
+		},
+		{
+			Name:   "Test Overlap With Cancelled Meetup",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 15:00", "2024-01-08 17:00"),
+			ExpErr: nil,
+		},
+		{
+			Name:   "Test Overlap With Non Concurrent Meetups",
+			Req:    newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 12:30", "2024-01-08 14:30"),
+			ExpErr: nil,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m, err := output.Service.CreateMeetup(newCallerContext(1), testCase.Req)
+			require.Equal(t, testCase.ExpErr, err, "mismatch error")
+			if err != nil {
+				require.Nil(t, m, "unexpected meetup")
+				return
+			}
+			// validate returned meetup with stored meetup, this is to make sure
+			// the meetup is also stored on storage
+			storedMeetup, err := output.MeetupStorage.GetMeetup(context.Background(), m.ID)
+			require.NoError(t, err, "unexpected error")
+			require.Equal(t, *m, *storedMeetup, "mismatch meetup")
+			require.Equal(t, "Si Jalak Harupat", m.Venue.Name, "mismatch venue name")
+			require.Equal(t, "Wedding", m.Event.Name, "mismatch event name")
+			require.Equal(t, 1, m.Organizer.ID, "mismatch organizer")
+		})
+	}
+
+	// create meetup without caller, should return error
+	req := newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 18:00", "2024-01-08 19:00")
+	m, err := output.Service.CreateMeetup(context.Background(), req)
+	require.Equal(t, entity.ErrMissingCaller, err, "mismatch error")
+	require.Nil(t, m, "unexpected meetup")
+
+	// create meetup with end time before start time, should return error
+	req = newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 20:00", "2024-01-08 19:00")
+	m, err = output.Service.CreateMeetup(newCallerContext(1), req)
+	require.Equal(t, entity.ErrInvalidTimeRange, err, "mismatch error")
+	require.Nil(t, m, "unexpected meetup")
+
+	// create meetup which starts in the past, should return error
+	req = newTestCreateMeetupRequest(t, 1, 1, "2023-12-31 18:00", "2023-12-31 19:00")
+	m, err = output.Service.CreateMeetup(newCallerContext(1), req)
+	require.Equal(t, meetup.ErrStartTimeInPast, err, "mismatch error")
+	require.Nil(t, m, "unexpected meetup")
+
+	// create meetup which starts right now, should return error
+	req = newTestCreateMeetupRequest(t, 1, 1, "2024-01-01 00:00", "2024-01-01 01:00")
+	m, err = output.Service.CreateMeetup(newCallerContext(1), req)
+	require.Equal(t, meetup.ErrStartTimeInPast, err, "mismatch error")
+	require.Nil(t, m, "unexpected meetup")
+
+	// set error on get venue, should return error
+	req = newTestCreateMeetupRequest(t, 1, 1, "2024-01-08 18:00", "2024-01-08 19:00")
+	output.VenueStorage.SetRetErrOnGetVenue(true)
+	m, err = output.Service.CreateMeetup(newCallerContext(1), req)
+	output.VenueStorage.SetRetErrOnGetVenue(false)
+	require.Error(t, err, "expected error")
+	require.Nil(t, m, "unexpected meetup")
+
+	// set error on save meetup, should return error
+	output.MeetupStorage.SetRetErrOnSaveMeetup(true)
+	m, err = output.Service.CreateMeetup(newCallerContext(1), req)
+	output.MeetupStorage.SetRetErrOnSaveMeetup(false)
+	require.Error(t, err, "expected error")
+	require.Nil(t, m, "unexpected meetup")
+}
+
+func TestServiceGetMeetups(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// no meetups, should return empty list
+	page, err := output.Service.GetMeetups(context.Background(), entity.MeetupQuery{})
+	require.NoError(t, err)
+	require.Empty(t, page.Meetups, "unexpected meetups")
+	require.Empty(t, page.NextCursor, "unexpected next cursor")
+
+	// add meetup
+	m := newFutureTestMeetup(12)
+	m.JoinedPersonsCount = 3
+	m.ID = output.MeetupStorage.AddMeetup(m)
+
+	// the meetup should be returned with all of its fields
+	page, err = output.Service.GetMeetups(context.Background(), entity.MeetupQuery{})
+	require.NoError(t, err)
+	require.Equal(t, []entity.GetMeetupsResponse{
+		{
+			ID:                 m.ID,
+			Name:               m.Name,
+			Venue:              m.Venue,
+			Event:              m.Event,
+			StartTs:            m.StartTs,
+			EndTs:              m.EndTs,
+			MaxPersons:         m.MaxPersons,
+			Organizer:          m.Organizer,
+			JoinedPersonsCount: m.JoinedPersonsCount,
+			Status:             m.Status,
+		},
+	}, page.Meetups, "mismatch meetups")
+}
+
+func TestServiceGetMeetupsQuery(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// add meetups, they are added in reverse order of their start time to make
+	// sure they are sorted
+	startTs := int(testNow.Unix())
+	addMeetup := func(offset int, fn func(m *entity.Meetup)) int {
+		m := newFutureTestMeetup(2)
+		m.StartTs = startTs + offset
+		m.EndTs = m.StartTs + 3600
+		if fn != nil {
+			fn(&m)
+		}
+		return output.MeetupStorage.AddMeetup(m)
+	}
+	otherVenueID := addMeetup(5000, func(m *entity.Meetup) { m.Venue.ID = 2 })
+	otherEventID := addMeetup(4000, func(m *entity.Meetup) { m.Event.ID = 2 })
+	otherOrganizerID := addMeetup(3000, func(m *entity.Meetup) { m.Organizer.ID = 2 })
+	cancelledID := addMeetup(2000, func(m *entity.Meetup) { m.Status = entity.MeetupStatusCancelled })
+	closedID := addMeetup(1000, func(m *entity.Meetup) { m.JoinedPersonsCount = 2 })
+	finishedID := addMeetup(-7200, nil)
+	// same start time as the closed meetup, it should be sorted by id
+	openID := addMeetup(1000, nil)
+
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Query  entity.MeetupQuery
+		ExpIDs []int
+		ExpErr error
+	}{
+		{
+			Name:   "Test No Filter",
+			Query:  entity.MeetupQuery{},
+			ExpIDs: []int{finishedID, closedID, openID, cancelledID, otherOrganizerID, otherEventID, otherVenueID},
+		},
+		{
+			Name:   "Test Filter Event",
+			Query:  entity.MeetupQuery{EventID: 2},
+			ExpIDs: []int{otherEventID},
+		},
+		{
+			Name:   "Test Filter Venue",
+			Query:  entity.MeetupQuery{VenueID: 2},
+			ExpIDs: []int{otherVenueID},
+		},
+		{
+			Name:   "Test Filter Organizer",
+			Query:  entity.MeetupQuery{OrganizerID: 2},
+			ExpIDs: []int{otherOrganizerID},
+		},
+		{
+			Name:   "Test Filter Open",
+			Query:  entity.MeetupQuery{Status: entity.MeetupStatusOpen},
+			ExpIDs: []int{openID, otherOrganizerID, otherEventID, otherVenueID},
+		},
+		{
+			Name:   "Test Filter Closed",
+			Query:  entity.MeetupQuery{Status: entity.MeetupStatusClosed},
+			ExpIDs: []int{closedID},
+		},
+		{
+			Name:   "Test Filter Cancelled",
+			Query:  entity.MeetupQuery{Status: entity.MeetupStatusCancelled},
+			ExpIDs: []int{cancelledID},
+		},
+		{
+			Name:   "Test Filter Finished",
+			Query:  entity.MeetupQuery{Status: entity.MeetupStatusFinished},
+			ExpIDs: []int{finishedID},
+		},
+		{
+			Name:   "Test Filter Time Range",
+			Query:  entity.MeetupQuery{FromTs: startTs + 1000, ToTs: startTs + 3000},
+			ExpIDs: []int{closedID, openID, cancelledID},
+		},
+		{
+			Name:   "Test Filter Combined",
+			Query:  entity.MeetupQuery{FromTs: startTs, EventID: 1, VenueID: 1, Status: entity.MeetupStatusOpen},
+			ExpIDs: []int{openID, otherOrganizerID},
+		},
+		{
+			Name:   "Test Invalid Limit",
+			Query:  entity.MeetupQuery{Limit: entity.MaxMeetupsLimit + 1},
+			ExpErr: entity.ErrInvalidLimit,
+		},
+		{
+			Name:   "Test Invalid Status",
+			Query:  entity.MeetupQuery{Status: "unknown"},
+			ExpErr: entity.ErrInvalidStatus,
+		},
+		{
+			Name:   "Test Invalid Time Range",
+			Query:  entity.MeetupQuery{FromTs: startTs, ToTs: startTs},
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+		{
+			Name:   "Test Invalid Cursor",
+			Query:  entity.MeetupQuery{Cursor: "invalid"},
+			ExpErr: entity.ErrInvalidCursor,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			page, err := output.Service.GetMeetups(context.Background(), testCase.Query)
+			require.Equal(t, testCase.ExpErr, err, "mismatch error")
+			if err != nil {
+				return
+			}
+			ids := []int{}
+			for _, m := range page.Meetups {
+				ids = append(ids, m.ID)
+			}
+			require.Equal(t, testCase.ExpIDs, ids, "mismatch meetups")
+		})
+	}
+
+	// iterate all meetups page by page
+	var ids []int
+	query := entity.MeetupQuery{Limit: 3}
+	for i := 0; ; i++ {
+		require.Less(t, i, 3, "too many pages")
+		page, err := output.Service.GetMeetups(context.Background(), query)
+		require.NoError(t, err)
+		for _, m := range page.Meetups {
+			ids = append(ids, m.ID)
+		}
+		if len(page.NextCursor) == 0 {
+			break
+		}
+		require.Len(t, page.Meetups, query.Limit, "non last page should be full")
+		query.Cursor = page.NextCursor
+	}
+	require.Equal(t, []int{finishedID, closedID, openID, cancelledID, otherOrganizerID, otherEventID, otherVenueID}, ids, "mismatch meetups")
+}
+
+func TestServiceUpdateMeetup(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// add meetup organized by user 1 with 2 joined persons, 2099-01-05 is a monday
+	organizedMeetup := newTestMeetup(t, "2099-01-05 10:00", "2099-01-05 12:00")
+	organizedMeetup.MaxPersons = 3
+	organizedMeetup.Organizer = entity.MeetupOrganizer{ID: 1}
+	organizedMeetup.JoinedPersons = []entity.JoinedPerson{{ID: 2}, {ID: 3}}
+	organizedMeetup.JoinedPersonsCount = 2
+	meetupID := output.MeetupStorage.AddMeetup(organizedMeetup)
+
+	// add other meetups to fill the venue capacity
+	output.MeetupStorage.AddMeetup(newTestMeetup(t, "2099-01-05 10:00", "2099-01-05 12:00"))
+	output.MeetupStorage.AddMeetup(newTestMeetup(t, "2099-01-05 14:00", "2099-01-05 16:00"))
+	output.MeetupStorage.AddMeetup(newTestMeetup(t, "2099-01-05 14:00", "2099-01-05 16:00"))
+
+	// add meetup organized by user 1 which is already started but not finished yet
+	startedMeetup := newTestMeetup(t, "2024-02-01 06:00", "2024-02-01 08:00")
+	startedMeetup.Organizer = entity.MeetupOrganizer{ID: 1}
+	startedMeetupID := output.MeetupStorage.AddMeetup(startedMeetup)
+
+	// define test cases, the order matters since updating is stateful
+	testCases := []struct {
+		Name      string
+		Ctx       context.Context
+		MeetupID  int
+		Req       entity.UpdateMeetupRequest
+		ExpErr    error
+		ExpStatus entity.MeetupStatus
+	}{
+		{
+			Name:     "Test Missing Caller",
+			Ctx:      context.Background(),
+			MeetupID: meetupID,
+			Req:      entity.UpdateMeetupRequest{Name: "Updated Meetup"},
+			ExpErr:   entity.ErrMissingCaller,
+		},
+		{
+			Name:     "Test Meetup Not Found",
+			Ctx:      newCallerContext(1),
+			MeetupID: 99,
+			Req:      entity.UpdateMeetupRequest{Name: "Updated Meetup"},
+			ExpErr:   meetup.ErrMeetupNotFound,
+		},
+		{
+			Name:     "Test Not Organizer",
+			Ctx:      newCallerContext(2),
+			MeetupID: meetupID,
+			Req:      entity.UpdateMeetupRequest{Name: "Updated Meetup"},
+			ExpErr:   meetup.ErrForbidden,
+		},
+		{
+			Name:     "Test Max Persons Less Than Joined Persons",
+			Ctx:      newCallerContext(1),
+			MeetupID: meetupID,
+			Req:      entity.UpdateMeetupRequest{MaxPersons: 1},
+			ExpErr:   meetup.ErrMaxPersonsLessThanJoinedPersons,
+		},
+		{
+			Name:     "Test Invalid Time Range",
+			Ctx:      newCallerContext(1),
+			MeetupID: meetupID,
+			Req: entity.UpdateMeetupRequest{
+				StartTs: newTestTs(t, "2099-01-05 12:00"),
+				EndTs:   newTestTs(t, "2099-01-05 11:00"),
+			},
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+		{
+			Name:     "Test Reschedule Started Meetup",
+			Ctx:      newCallerContext(1),
+			MeetupID: startedMeetupID,
+			Req: entity.UpdateMeetupRequest{
+				StartTs: newTestTs(t, "2099-01-05 10:00"),
+				EndTs:   newTestTs(t, "2099-01-05 12:00"),
+			},
+			ExpErr: meetup.ErrMeetupStarted,
+		},
+		{
+			Name:     "Test Reschedule Into The Past",
+			Ctx:      newCallerContext(1),
+			MeetupID: meetupID,
+			Req: entity.UpdateMeetupRequest{
+				StartTs: newTestTs(t, "2024-01-31 10:00"),
+				EndTs:   newTestTs(t, "2024-01-31 12:00"),
+			},
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+		{
+			Name:      "Test Rename Started Meetup",
+			Ctx:       newCallerContext(1),
+			MeetupID:  startedMeetupID,
+			Req:       entity.UpdateMeetupRequest{Name: "Renamed Meetup"},
+			ExpErr:    nil,
+			ExpStatus: entity.MeetupStatusOpen,
+		},
+		{
+			Name:     "Test Reschedule Outside Operating Hours",
+			Ctx:      newCallerContext(1),
+			MeetupID: meetupID,
+			Req: entity.UpdateMeetupRequest{
+				StartTs: newTestTs(t, "2099-01-05 21:00"),
+				EndTs:   newTestTs(t, "2099-01-05 23:00"),
+			},
+			ExpErr: meetup.ErrVenueIsClosed,
+		},
+		{
+			Name:     "Test Reschedule Exceed Venue Capacity",
+			Ctx:      newCallerContext(1),
+			MeetupID: meetupID,
+			Req: entity.UpdateMeetupRequest{
+				StartTs: newTestTs(t, "2099-01-05 15:00"),
+				EndTs:   newTestTs(t, "2099-01-05 17:00"),
+			},
+			ExpErr: meetup.ErrExceedVenueCapacity,
+		},
+		{
+			Name:     "Test Reschedule Overlapping Itself",
+			Ctx:      newCallerContext(1),
+			MeetupID: meetupID,
+			Req: entity.UpdateMeetupRequest{
+				StartTs: newTestTs(t, "2099-01-05 11:00"),
+				EndTs:   newTestTs(t, "2099-01-05 13:00"),
+			},
+			ExpErr:    nil,
+			ExpStatus: entity.MeetupStatusOpen,
+		},
+		{
+			Name:      "Test Force Close Meetup",
+			Ctx:       newCallerContext(1),
+			MeetupID:  meetupID,
+			Req:       entity.UpdateMeetupRequest{MaxPersons: 2},
+			ExpErr:    nil,
+			ExpStatus: entity.MeetupStatusClosed,
+		},
+		{
+			Name:      "Test Reopen Meetup",
+			Ctx:       newCallerContext(1),
+			MeetupID:  meetupID,
+			Req:       entity.UpdateMeetupRequest{Name: "Updated Meetup", MaxPersons: 5},
+			ExpErr:    nil,
+			ExpStatus: entity.MeetupStatusOpen,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m, err := output.Service.UpdateMeetup(testCase.Ctx, testCase.MeetupID, testCase.Req)
+			require.Equal(t, testCase.ExpErr, err, "mismatch error")
+			if err != nil {
+				require.Nil(t, m, "unexpected meetup")
+				return
+			}
+			// validate the changes are applied & stored on storage
+			if testCase.Req.Name != "" {
+				require.Equal(t, testCase.Req.Name, m.Name, "mismatch name")
+			}
+			if testCase.Req.StartTs != 0 {
+				require.Equal(t, testCase.Req.StartTs, m.StartTs, "mismatch start ts")
+				require.Equal(t, testCase.Req.EndTs, m.EndTs, "mismatch end ts")
+			}
+			if testCase.Req.MaxPersons != 0 {
+				require.Equal(t, testCase.Req.MaxPersons, m.MaxPersons, "mismatch max persons")
+			}
+			require.Equal(t, testCase.ExpStatus, m.Status, "mismatch status")
+
+			storedMeetup, err := output.MeetupStorage.GetMeetup(context.Background(), m.ID)
+			require.NoError(t, err, "unexpected error")
+			require.Equal(t, *m, *storedMeetup, "mismatch meetup")
+		})
+	}
+}
+
+func TestServiceJoinMeetup(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// add meetups in various states
+	openID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(2))
+	closedID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(0))
+	cancelledMeetup := newFutureTestMeetup(2)
+	cancelledMeetup.Status = entity.MeetupStatusCancelled
+	cancelledID := output.MeetupStorage.AddMeetup(cancelledMeetup)
+	finishedID := output.MeetupStorage.AddMeetup(newTestMeetup(t, "2024-01-08 10:00", "2024-01-08 12:00"))
+	overlappingID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(2))
+	adjacentMeetup := newFutureTestMeetup(2)
+	adjacentMeetup.StartTs += 3600
+	adjacentMeetup.EndTs += 3600
+	adjacentID := output.MeetupStorage.AddMeetup(adjacentMeetup)
+
+	// define test cases, the order matters since joining is stateful
+	testCases := []struct {
+		Name     string
+		Ctx      context.Context
+		MeetupID int
+		ExpErr   error
+	}{
+		{
+			Name:     "Test Missing Caller",
+			Ctx:      context.Background(),
+			MeetupID: openID,
+			ExpErr:   entity.ErrMissingCaller,
+		},
+		{
+			Name:     "Test Meetup Not Found",
+			Ctx:      newCallerContext(1),
+			MeetupID: 99,
+			ExpErr:   meetup.ErrMeetupNotFound,
+		},
+		{
+			Name:     "Test Meetup Cancelled",
+			Ctx:      newCallerContext(1),
+			MeetupID: cancelledID,
+			ExpErr:   meetup.ErrMeetupCancelled,
+		},
+		{
+			Name:     "Test Meetup Finished",
+			Ctx:      newCallerContext(1),
+			MeetupID: finishedID,
+			ExpErr:   meetup.ErrMeetupFinished,
+		},
+		{
+			Name:     "Test Meetup Closed",
+			Ctx:      newCallerContext(1),
+			MeetupID: closedID,
+			ExpErr:   meetup.ErrMeetupClosed,
+		},
+		{
+			Name:     "Test Join Meetup",
+			Ctx:      newCallerContext(1),
+			MeetupID: openID,
+			ExpErr:   nil,
+		},
+		{
+			Name:     "Test Already Joined",
+			Ctx:      newCallerContext(1),
+			MeetupID: openID,
+			ExpErr:   meetup.ErrAlreadyJoined,
+		},
+		{
+			Name:     "Test Meetup Overlaps",
+			Ctx:      newCallerContext(1),
+			MeetupID: overlappingID,
+			ExpErr:   meetup.ErrMeetupOverlaps,
+		},
+		{
+			Name:     "Test Join Adjacent Meetup",
+			Ctx:      newCallerContext(1),
+			MeetupID: adjacentID,
+			ExpErr:   nil,
+		},
+		{
+			Name:     "Test Join Last Seat",
+			Ctx:      newCallerContext(2),
+			MeetupID: openID,
+			ExpErr:   nil,
+		},
+		{
+			Name:     "Test Meetup Is Full",
+			Ctx:      newCallerContext(3),
+			MeetupID: openID,
+			ExpErr:   meetup.ErrMeetupClosed,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m, err := output.Service.JoinMeetup(testCase.Ctx, testCase.MeetupID)
+			require.Equal(t, testCase.ExpErr, err, "mismatch error")
+			if err != nil {
+				require.Nil(t, m, "unexpected meetup")
+				return
+			}
+			caller, _ := entity.GetCaller(testCase.Ctx)
+			require.True(t, m.IsJoined, "meetup is not joined")
+			require.True(t, m.IsParticipant(caller.ID), "caller is not participant")
+			require.Equal(t, len(m.JoinedPersons), m.JoinedPersonsCount, "mismatch joined persons count")
+		})
+	}
+
+	// the meetup should be closed once all seats are taken
+	m, err := output.Service.GetMeetup(newCallerContext(1), openID)
+	require.NoError(t, err)
+	require.Equal(t, entity.MeetupStatusClosed, m.Status, "mismatch status")
+}
+
+func TestServiceJoinMeetupConcurrently(t *testing.T) {
+	// initialize new service
+	output := newService()
+	maxPersons := 5
+	meetupID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(maxPersons))
+
+	// let many users join the meetup at the same time
+	numUsers := 50
+	errs := make(chan error, numUsers)
+	var wg sync.WaitGroup
+	for i := 1; i <= numUsers; i++ {
+		wg.Add(1)
+		go func(userID int) {
+			defer wg.Done()
+			_, err := output.Service.JoinMeetup(newCallerContext(userID), meetupID)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	// only users up to max persons should be able to join
+	numJoined := 0
+	for err := range errs {
+		if err == nil {
+			numJoined++
+			continue
+		}
+		require.Equal(t, meetup.ErrMeetupClosed, err, "mismatch error")
+	}
+	require.Equal(t, maxPersons, numJoined, "mismatch number of joined users")
+
+	m, err := output.MeetupStorage.GetMeetup(context.Background(), meetupID)
+	require.NoError(t, err)
+	require.Equal(t, maxPersons, m.JoinedPersonsCount, "mismatch joined persons count")
+}
+
+func TestServiceLeaveMeetup(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// add meetups in various states
+	openID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(2))
+	cancelledMeetup := newFutureTestMeetup(2)
+	cancelledMeetup.Status = entity.MeetupStatusCancelled
+	cancelledID := output.MeetupStorage.AddMeetup(cancelledMeetup)
+
+	// join the open meetup
+	_, err := output.Service.JoinMeetup(newCallerContext(1), openID)
+	require.NoError(t, err)
+
+	// define test cases, the order matters since leaving is stateful
+	testCases := []struct {
+		Name     string
+		Ctx      context.Context
+		MeetupID int
+		ExpErr   error
+	}{
+		{
+			Name:     "Test Missing Caller",
+			Ctx:      context.Background(),
+			MeetupID: openID,
+			ExpErr:   entity.ErrMissingCaller,
+		},
+		{
+			Name:     "Test Meetup Not Found",
+			Ctx:      newCallerContext(1),
+			MeetupID: 99,
+			ExpErr:   meetup.ErrMeetupNotFound,
+		},
+		{
+			Name:     "Test Meetup Cancelled",
+			Ctx:      newCallerContext(1),
+			MeetupID: cancelledID,
+			ExpErr:   meetup.ErrMeetupCancelled,
+		},
+		{
+			Name:     "Test User Not Participant",
+			Ctx:      newCallerContext(2),
+			MeetupID: openID,
+			ExpErr:   meetup.ErrUserNotParticipant,
+		},
+		{
+			Name:     "Test Leave Meetup",
+			Ctx:      newCallerContext(1),
+			MeetupID: openID,
+			ExpErr:   nil,
+		},
+		{
+			Name:     "Test Leave Meetup Twice",
+			Ctx:      newCallerContext(1),
+			MeetupID: openID,
+			ExpErr:   meetup.ErrUserNotParticipant,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := output.Service.LeaveMeetup(testCase.Ctx, testCase.MeetupID)
+			require.Equal(t, testCase.ExpErr, err, "mismatch error")
+		})
+	}
+
+	// the seat should be available again
+	m, err := output.MeetupStorage.GetMeetup(context.Background(), openID)
+	require.NoError(t, err)
+	require.Equal(t, 0, m.JoinedPersonsCount, "mismatch joined persons count")
+}
+
+func TestServiceGetIncomingMeetups(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// add meetups with various relations to user 2, they are added in reverse
+	// order of their start time to make sure they are sorted
+	participant := entity.JoinedPerson{ID: 2, Username: "user_2", Email: "user_2@eveners.com"}
+	addMeetup := func(offset int, fn func(m *entity.Meetup)) int {
+		m := newFutureTestMeetup(2)
+		m.StartTs += offset
+		m.EndTs += offset
+		fn(&m)
+		return output.MeetupStorage.AddMeetup(m)
+	}
+	otherVenueID := addMeetup(5000, func(m *entity.Meetup) {
+		m.Venue.ID = 2
+		m.JoinedPersons = []entity.JoinedPerson{participant}
+		m.JoinedPersonsCount = 1
+	})
+	cancelledID := addMeetup(4000, func(m *entity.Meetup) {
+		m.Status = entity.MeetupStatusCancelled
+		m.Event.ID = 2
+		m.JoinedPersons = []entity.JoinedPerson{participant}
+		m.JoinedPersonsCount = 1
+	})
+	closedID := addMeetup(3000, func(m *entity.Meetup) {
+		m.JoinedPersons = []entity.JoinedPerson{participant, {ID: 3}}
+		m.JoinedPersonsCount = 2
+	})
+	organizedID := addMeetup(2000, func(m *entity.Meetup) {
+		m.Organizer = entity.MeetupOrganizer{ID: 2, Username: "user_2", Email: "user_2@eveners.com"}
+	})
+	// meetup which user 2 is not related to
+	addMeetup(1000, func(m *entity.Meetup) {
+		m.JoinedPersons = []entity.JoinedPerson{{ID: 3}}
+		m.JoinedPersonsCount = 1
+	})
+	// meetup which is already started
+	addMeetup(-3600, func(m *entity.Meetup) {
+		m.JoinedPersons = []entity.JoinedPerson{participant}
+		m.JoinedPersonsCount = 1
+	})
+
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Ctx    context.Context
+		Filter entity.IncomingMeetupFilter
+		ExpIDs []int
+		ExpErr error
+	}{
+		{
+			Name:   "Test Missing Caller",
+			Ctx:    context.Background(),
+			ExpErr: entity.ErrMissingCaller,
+		},
+		{
+			Name:   "Test No Filter",
+			Ctx:    newCallerContext(2),
+			ExpIDs: []int{organizedID, closedID, cancelledID, otherVenueID},
+		},
+		{
+			Name:   "Test Filter Open",
+			Ctx:    newCallerContext(2),
+			Filter: entity.IncomingMeetupFilter{Status: entity.MeetupStatusOpen},
+			ExpIDs: []int{organizedID, otherVenueID},
+		},
+		{
+			Name:   "Test Filter Closed",
+			Ctx:    newCallerContext(2),
+			Filter: entity.IncomingMeetupFilter{Status: entity.MeetupStatusClosed},
+			ExpIDs: []int{closedID},
+		},
+		{
+			Name:   "Test Filter Cancelled",
+			Ctx:    newCallerContext(2),
+			Filter: entity.IncomingMeetupFilter{Status: entity.MeetupStatusCancelled},
+			ExpIDs: []int{cancelledID},
+		},
+		{
+			Name:   "Test Filter Events",
+			Ctx:    newCallerContext(2),
+			Filter: entity.IncomingMeetupFilter{EventIDs: []int{2, 3}},
+			ExpIDs: []int{cancelledID},
+		},
+		{
+			Name:   "Test Filter Venues",
+			Ctx:    newCallerContext(2),
+			Filter: entity.IncomingMeetupFilter{VenueIDs: []int{2}},
+			ExpIDs: []int{otherVenueID},
+		},
+		{
+			Name:   "Test No Incoming Meetups",
+			Ctx:    newCallerContext(4),
+			ExpIDs: []int{},
+		},
+		{
+			Name:   "Test Invalid Status",
+			Ctx:    newCallerContext(2),
+			Filter: entity.IncomingMeetupFilter{Status: entity.MeetupStatusFinished},
+			ExpErr: entity.ErrInvalidStatus,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			meetups, err := output.Service.GetIncomingMeetups(testCase.Ctx, testCase.Filter)
+			require.ErrorIs(t, err, testCase.ExpErr, "mismatch error")
+			if err != nil {
+				return
+			}
+			ids := []int{}
+			for _, m := range meetups {
+				ids = append(ids, m.ID)
+				require.Equal(t, m.ID != organizedID, m.IsJoined, "mismatch is joined")
+			}
+			require.Equal(t, testCase.ExpIDs, ids, "mismatch meetups")
+		})
+	}
+}
+
+func TestServiceCancelMeetup(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// add meetups in various states, all of them are organized by user 1
+	openID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(2))
+	closedID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(0))
+	cancelledMeetup := newFutureTestMeetup(2)
+	cancelledMeetup.Status = entity.MeetupStatusCancelled
+	cancelledID := output.MeetupStorage.AddMeetup(cancelledMeetup)
+	finishedMeetup := newTestMeetup(t, "2024-01-08 10:00", "2024-01-08 12:00")
+	finishedMeetup.Organizer = cancelledMeetup.Organizer
+	finishedID := output.MeetupStorage.AddMeetup(finishedMeetup)
+
+	// define test cases
+	testCases := []struct {
+		Name     string
+		Ctx      context.Context
+		MeetupID int
+		ExpErr   error
+	}{
+		{
+			Name:     "Test Missing Caller",
+			Ctx:      context.Background(),
+			MeetupID: openID,
+			ExpErr:   entity.ErrMissingCaller,
+		},
+		{
+			Name:     "Test Meetup Not Found",
+			Ctx:      newCallerContext(1),
+			MeetupID: 99,
+			ExpErr:   meetup.ErrMeetupNotFound,
+		},
+		{
+			Name:     "Test Not Organizer",
+			Ctx:      newCallerContext(2),
+			MeetupID: openID,
+			ExpErr:   meetup.ErrForbidden,
+		},
+		{
+			Name:     "Test Meetup Cancelled",
+			Ctx:      newCallerContext(1),
+			MeetupID: cancelledID,
+			ExpErr:   meetup.ErrMeetupCancelled,
+		},
+		{
+			Name:     "Test Meetup Finished",
+			Ctx:      newCallerContext(1),
+			MeetupID: finishedID,
+			ExpErr:   meetup.ErrMeetupStarted,
+		},
+		{
+			Name:     "Test Cancel Open Meetup",
+			Ctx:      newCallerContext(1),
+			MeetupID: openID,
+			ExpErr:   nil,
+		},
+		{
+			Name:     "Test Cancel Closed Meetup",
+			Ctx:      newCallerContext(1),
+			MeetupID: closedID,
+			ExpErr:   nil,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			resp, err := output.Service.CancelMeetup(testCase.Ctx, testCase.MeetupID, "Venue is under renovation")
+			require.ErrorIs(t, err, testCase.ExpErr, "mismatch error")
+			if err != nil {
+				require.Nil(t, resp, "unexpected response")
+				return
+			}
+			require.Equal(t, entity.MeetupStatusCancelled, resp.Status, "mismatch status")
+			require.Equal(t, "Venue is under renovation", resp.CancelledReason, "mismatch cancelled reason")
+			require.Equal(t, testNow.Unix(), resp.CancelledAt, "mismatch cancelled at")
+			require.Equal(t, 1, resp.CancelledBy, "mismatch cancelled by")
+
+			// the cancellation should be visible to the organizer
+			m, err := output.Service.GetMeetup(testCase.Ctx, testCase.MeetupID)
+			require.NoError(t, err)
+			require.Equal(t, entity.MeetupStatusCancelled, m.Status, "meetup is not cancelled")
+			require.Equal(t, resp.CancelledReason, m.CancelledReason, "mismatch cancelled reason")
+			require.Equal(t, resp.CancelledAt, m.CancelledAt, "mismatch cancelled at")
+			require.Equal(t, resp.CancelledBy, m.CancelledBy, "mismatch cancelled by")
+		})
+	}
+}
+
+func TestServiceCancelMeetupConcurrently(t *testing.T) {
+	// initialize new service
+	output := newService()
+	meetupID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(2))
+
+	// the meetup is cancelled by other request after it is read, the error
+	// must be returned as is so it could be mapped by the caller
+	output.MeetupStorage.SetCancelledOnCancel(true)
+	resp, err := output.Service.CancelMeetup(newCallerContext(1), meetupID, "Venue is under renovation")
+	require.Equal(t, meetup.ErrMeetupCancelled, err, "mismatch error")
+	require.Nil(t, resp, "unexpected response")
+}
+
+func TestServiceCancelMeetupOnTime(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name    string
+		Elapsed time.Duration
+		ExpErr  error
+	}{
+		{
+			Name:    "Test Cancel One Second Before Start",
+			Elapsed: time.Hour - time.Second,
+			ExpErr:  nil,
+		},
+		{
+			Name:    "Test Cancel On Start",
+			Elapsed: time.Hour,
+			ExpErr:  meetup.ErrMeetupStarted,
+		},
+		{
+			Name:    "Test Cancel On Finish",
+			Elapsed: 2 * time.Hour,
+			ExpErr:  meetup.ErrMeetupStarted,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			output := newService()
+			meetupID := output.MeetupStorage.AddMeetup(newFutureTestMeetup(2))
+
+			output.Clock.Advance(testCase.Elapsed)
+			resp, err := output.Service.CancelMeetup(newCallerContext(1), meetupID, "Venue is under renovation")
+			require.Equal(t, testCase.ExpErr, err, "mismatch error")
+			if err != nil {
+				return
+			}
+			require.Equal(t, output.Clock.Now().Unix(), resp.CancelledAt, "mismatch cancelled at")
+		})
+	}
+}
+
+func TestServiceGetMeetupView(t *testing.T) {
+	// initialize new service
+	output := newService()
+
+	// add open & cancelled meetups organized by user 1 and joined by user 2
+	participant := entity.JoinedPerson{ID: 2, Username: "user_2", Email: "user_2@eveners.com", JoinedAt: int(testNow.Unix())}
+	openMeetup := newFutureTestMeetup(2)
+	openMeetup.JoinedPersons = []entity.JoinedPerson{participant}
+	openMeetup.JoinedPersonsCount = 1
+	openMeetup.ID = output.MeetupStorage.AddMeetup(openMeetup)
+
+	cancelledMeetup := openMeetup
+	err := cancelledMeetup.Cancel(1, "Venue is under renovation", testNow.Unix())
+	require.NoError(t, err)
+	cancelledMeetup.ID = output.MeetupStorage.AddMeetup(cancelledMeetup)
+
+	// define test cases
+	testCases := []struct {
+		Name        string
+		Ctx         context.Context
+		Meetup      entity.Meetup
+		ExpIsJoined bool
+		ExpPrivate  bool
+		ExpErr      error
+	}{
+		{
+			Name:   "Test Missing Caller",
+			Ctx:    context.Background(),
+			Meetup: openMeetup,
+			ExpErr: entity.ErrMissingCaller,
+		},
+		{
+			Name:        "Test Organizer",
+			Ctx:         newCallerContext(1),
+			Meetup:      openMeetup,
+			ExpIsJoined: false,
+			ExpPrivate:  true,
+		},
+		{
+			Name:        "Test Participant",
+			Ctx:         newCallerContext(2),
+			Meetup:      openMeetup,
+			ExpIsJoined: true,
+			ExpPrivate:  true,
+		},
+		{
+			Name:        "Test Outsider",
+			Ctx:         newCallerContext(3),
+			Meetup:      openMeetup,
+			ExpIsJoined: false,
+			ExpPrivate:  false,
+		},
+		{
+			Name:        "Test Organizer Cancelled",
+			Ctx:         newCallerContext(1),
+			Meetup:      cancelledMeetup,
+			ExpIsJoined: false,
+			ExpPrivate:  true,
+		},
+		{
+			Name:        "Test Participant Cancelled",
+			Ctx:         newCallerContext(2),
+			Meetup:      cancelledMeetup,
+			ExpIsJoined: true,
+			ExpPrivate:  true,
+		},
+		{
+			Name:        "Test Outsider Cancelled",
+			Ctx:         newCallerContext(3),
+			Meetup:      cancelledMeetup,
+			ExpIsJoined: false,
+			ExpPrivate:  false,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			m, err := output.Service.GetMeetup(testCase.Ctx, testCase.Meetup.ID)
+			require.ErrorIs(t, err, testCase.ExpErr, "mismatch error")
+			if err != nil {
+				return
+			}
+			expMeetup := testCase.Meetup
+			expMeetup.IsJoined = testCase.ExpIsJoined
+			if !testCase.ExpPrivate {
+				expMeetup.JoinedPersons = nil
+				expMeetup.CancelledReason = ""
+				expMeetup.CancelledAt = 0
+				expMeetup.CancelledBy = 0
+			}
+			require.Equal(t, expMeetup, *m, "mismatch meetup")
+		})
+	}
+
+	// private fields should not leak to the storage
+	stored, err := output.MeetupStorage.GetMeetup(context.Background(), cancelledMeetup.ID)
+	require.NoError(t, err)
+	require.Equal(t, cancelledMeetup, *stored, "stored meetup is changed")
+}
+
+func newService() *newServiceOutput {
+	// initialize dependencies
+	meetupStorage := newMockMeetupStorage()
+	venueStorage := newMockVenueStorage([]entity.Venue{
+		{
+			ID:       1,
+			Name:     "Si Jalak Harupat",
+			OpenDays: []int{1, 2, 3, 4, 5, 6},
+			OpenAt:   "08:00",
+			ClosedAt: "22:00",
+			TimeZone: "Asia/Jakarta",
+			WeekdayHours: []entity.WeekdayHours{
+				{Day: 5, OpenAt: "13:00", ClosedAt: "22:00"},
+			},
+			// closed for the whole day of 2024-01-10
+			Closures: []entity.VenueClosure{
+				{StartTs: 1704819600, EndTs: 1704906000, Reason: "Public Holiday"},
+			},
+			SupportedEvents: []entity.SupportedEvent{
+				{
+					ID:            1,
+					Name:          "Wedding",
+					EventCapacity: 2,
+				},
+			},
+		},
+	})
+
+	// initialize service
+	clock := testutil.NewFakeClock(testNow)
+	cfg := meetup.ServiceConfig{
+		MeetupStorage: meetupStorage,
+		VenueStorage:  venueStorage,
+		Clock:         clock,
+	}
+	svc, _ := meetup.NewService(cfg)
+
+	return &newServiceOutput{
+		Service:       svc,
+		MeetupStorage: meetupStorage,
+		VenueStorage:  venueStorage,
+		Clock:         clock,
+	}
+}
+
+type newServiceOutput struct {
+	Service       meetup.Service
+	MeetupStorage *mockMeetupStorage
+	VenueStorage  *mockVenueStorage
+	Clock         *testutil.FakeClock
+}
+
+// testNow is the current time of the service clock in the tests, it is after
+// all of the fixed meetups in 2024-01 are finished
+var testNow = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestCreateMeetupRequest(t *testing.T, venueID, eventID int, start, end string) entity.CreateMeetupRequest {
+	return entity.CreateMeetupRequest{
+		Name:       "Wedding Fulan",
+		VenueID:    venueID,
+		EventID:    eventID,
+		StartTs:    newTestTs(t, start),
+		EndTs:      newTestTs(t, end),
+		MaxPersons: 12,
+	}
+}
+
+func newTestMeetup(t *testing.T, start, end string) entity.Meetup {
+	return entity.Meetup{
+		Name:       "Existing Meetup",
+		Venue:      entity.MeetupVenue{ID: 1, Name: "Si Jalak Harupat"},
+		Event:      entity.MeetupEvent{ID: 1, Name: "Wedding"},
+		StartTs:    newTestTs(t, start),
+		EndTs:      newTestTs(t, end),
+		MaxPersons: 12,
+		Status:     entity.MeetupStatusOpen,
+	}
+}
+
+// newFutureTestMeetup returns meetup organized by user 1 that will be started
+// in an hour since testNow and finished an hour later
+func newFutureTestMeetup(maxPersons int) entity.Meetup {
+	nowTs := int(testNow.Unix())
+	return entity.Meetup{
+		Name:       "Future Meetup",
+		Venue:      entity.MeetupVenue{ID: 1, Name: "Si Jalak Harupat"},
+		Event:      entity.MeetupEvent{ID: 1, Name: "Wedding"},
+		StartTs:    nowTs + 3600,
+		EndTs:      nowTs + 7200,
+		MaxPersons: maxPersons,
+		Status:     entity.MeetupStatusOpen,
+		Organizer:  entity.MeetupOrganizer{ID: 1, Username: "user_1", Email: "user_1@eveners.com"},
+	}
+}
+
+func newCallerContext(userID int) context.Context {
+	return entity.NewCallerContext(context.Background(), entity.Caller{
+		ID:       userID,
+		Username: fmt.Sprintf("user_%v", userID),
+		Email:    fmt.Sprintf("user_%v@eveners.com", userID),
+	})
+}
+
+// newTestTs returns unix timestamp for given time in Asia/Jakarta timezone
+func newTestTs(t *testing.T, value string) int {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	require.NoError(t, err)
+	return int(ts.Unix())
+}
+
+type mockMeetupStorage struct {
+	sync.Mutex
+	data               map[int]entity.Meetup
+	lastID             int
+	retErrOnSaveMeetup bool
+	// cancelledOnCancel simulates the meetup being cancelled concurrently
+	// right before `CancelMeetup()` is called
+	cancelledOnCancel bool
+}
+
+func (ms *mockMeetupStorage) SetRetErrOnSaveMeetup(retErr bool) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.retErrOnSaveMeetup = retErr
+}
+
+func (ms *mockMeetupStorage) SetCancelledOnCancel(cancelled bool) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	ms.cancelledOnCancel = cancelled
+}
+
+// AddMeetup is used for adding meetup directly to storage, bypassing service validation
+func (ms *mockMeetupStorage) AddMeetup(m entity.Meetup) int {
+	ms.Lock()
+	defer ms.Unlock()
+
+	return ms.addMeetup(m)
+}
+
+func (ms *mockMeetupStorage) addMeetup(m entity.Meetup) int {
+	ms.lastID++
+	m.ID = ms.lastID
+	ms.data[m.ID] = m
+	return m.ID
+}
+
+func (ms *mockMeetupStorage) GetMeetups(ctx context.Context, query entity.MeetupQuery, now int64) ([]entity.Meetup, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	cursor, err := query.GetCursor()
+	if err != nil {
+		return nil, err
+	}
+	var meetups []entity.Meetup
+	for _, m := range ms.data {
+		if !query.IsMatch(m, now) || (cursor != nil && !cursor.IsAfter(m)) {
+			continue
+		}
+		meetups = append(meetups, m)
+	}
+	entity.SortMeetups(meetups)
+	if len(meetups) > query.GetLimit() {
+		meetups = meetups[:query.GetLimit()]
+	}
+	return meetups, nil
+}
+
+func (ms *mockMeetupStorage) GetParticipantMeetups(ctx context.Context, userID int, startAfterTs int) ([]entity.Meetup, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	var meetups []entity.Meetup
+	for _, m := range ms.data {
+		if m.StartTs > startAfterTs && m.IsMember(userID) {
+			meetups = append(meetups, m)
+		}
+	}
+	entity.SortMeetups(meetups)
+	return meetups, nil
+}
+
+func (ms *mockMeetupStorage) GetOverlappingMeetups(ctx context.Context, venueID, eventID, startTs, endTs int) ([]entity.Meetup, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	var meetups []entity.Meetup
+	for _, m := range ms.data {
+		if m.Venue.ID != venueID || m.Event.ID != eventID || m.Status == entity.MeetupStatusCancelled {
+			continue
+		}
+		if m.IsOverlapping(startTs, endTs) {
+			meetups = append(meetups, m)
+		}
+	}
+	return meetups, nil
+}
+
+func (ms *mockMeetupStorage) SaveMeetup(ctx context.Context, m entity.Meetup) (int, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	if ms.retErrOnSaveMeetup {
+		return 0, ErrIntentionalError
+	}
+	return ms.addMeetup(m), nil
+}
+
+func (ms *mockMeetupStorage) GetMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	m, ok := ms.data[meetupID]
+	if !ok {
+		return nil, nil
+	}
+	m.JoinedPersons = append([]entity.JoinedPerson(nil), m.JoinedPersons...)
+	return &m, nil
+}
+
+func (ms *mockMeetupStorage) UpdateMeetup(ctx context.Context, m entity.Meetup) (bool, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	storedMeetup, ok := ms.data[m.ID]
+	if !ok || storedMeetup.Status == entity.MeetupStatusCancelled || m.MaxPersons < storedMeetup.JoinedPersonsCount {
+		return false, nil
+	}
+	storedMeetup.Name = m.Name
+	storedMeetup.StartTs = m.StartTs
+	storedMeetup.EndTs = m.EndTs
+	storedMeetup.MaxPersons = m.MaxPersons
+	storedMeetup.Status = m.Status
+	ms.data[m.ID] = storedMeetup
+	return true, nil
+}
+
+func (ms *mockMeetupStorage) CancelMeetup(ctx context.Context, m entity.Meetup) error {
+	ms.Lock()
+	defer ms.Unlock()
+
+	storedMeetup, ok := ms.data[m.ID]
+	if !ok {
+		return nil
+	}
+	if ms.cancelledOnCancel || storedMeetup.Status == entity.MeetupStatusCancelled {
+		return meetup.ErrMeetupCancelled
+	}
+	storedMeetup.Status = entity.MeetupStatusCancelled
+	storedMeetup.CancelledReason = m.CancelledReason
+	storedMeetup.CancelledAt = m.CancelledAt
+	storedMeetup.CancelledBy = m.CancelledBy
+	ms.data[m.ID] = storedMeetup
+	return nil
+}
+
+func (ms *mockMeetupStorage) JoinMeetup(ctx context.Context, meetupID int, person entity.JoinedPerson) (bool, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	m, ok := ms.data[meetupID]
+	if !ok || m.Status == entity.MeetupStatusCancelled || m.IsParticipant(person.ID) || m.JoinedPersonsCount >= m.MaxPersons {
+		return false, nil
+	}
+	m.JoinedPersons = append(m.JoinedPersons, person)
+	m.JoinedPersonsCount = len(m.JoinedPersons)
+	ms.data[meetupID] = m
+	return true, nil
+}
+
+func (ms *mockMeetupStorage) LeaveMeetup(ctx context.Context, meetupID int, userID int) (bool, error) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	m, ok := ms.data[meetupID]
+	if !ok || !m.IsParticipant(userID) {
+		return false, nil
+	}
+	var persons []entity.JoinedPerson
+	for _, person := range m.JoinedPersons {
+		if person.ID != userID {
+			persons = append(persons, person)
+		}
+	}
+	m.JoinedPersons = persons
+	m.JoinedPersonsCount = len(persons)
+	ms.data[meetupID] = m
+	return true, nil
+}
+
+func newMockMeetupStorage() *mockMeetupStorage {
+	return &mockMeetupStorage{
+		data: map[int]entity.Meetup{},
+	}
+}
+
+type mockVenueStorage struct {
+	data             map[int]entity.Venue
+	retErrOnGetVenue bool
+}
+
+func (vs *mockVenueStorage) SetRetErrOnGetVenue(retErr bool) {
+	vs.retErrOnGetVenue = retErr
+}
+
+func (vs *mockVenueStorage) GetVenue(ctx context.Context, venueID int) (*entity.Venue, error) {
+	if vs.retErrOnGetVenue {
+		return nil, ErrIntentionalError
+	}
+	venue, ok := vs.data[venueID]
+	if !ok {
+		return nil, nil
+	}
+	return &venue, nil
+}
+
+func newMockVenueStorage(venues []entity.Venue) *mockVenueStorage {
+	data := map[int]entity.Venue{}
+	for _, venue := range venues {
+		data[venue.ID] = venue
+	}
+	return &mockVenueStorage{data: data}
+}
+
+var ErrIntentionalError = errors.New("intentional error")
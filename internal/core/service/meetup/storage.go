@@ -2,22 +2,66 @@ package meetup
 
 import (
 	"context"
+	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 )
 
 type MeetupStorage interface {
-	// GetMeetups returns list of meetup available in the system.
-	// Returns nil when there is no meetups available.
-	GetMeetups(ctx context.Context) ([]entity.Meetup, error)
+	// GetMeetups returns at most query.GetLimit() meetups matching the query filters at given
+	// unix timestamp, see `entity.MeetupQuery.IsMatch()`. The meetups are sorted by their start
+	// time then by their id, and only those positioned after the query cursor are returned.
+	// Returns nil when there is no matching meetups.
+	GetMeetups(ctx context.Context, query entity.MeetupQuery, now int64) ([]entity.Meetup, error)
 
-	// SaveMeetup is used for save meetup in storage.
-	SaveMeetup(ctx context.Context, meetup entity.Meetup) error
+	// GetParticipantMeetups returns meetups organized or joined by user with given id which
+	// start after given unix timestamp. The meetups are sorted by their start time then by
+	// their id. Returns nil when there is no such meetups.
+	GetParticipantMeetups(ctx context.Context, userID int, startAfterTs int) ([]entity.Meetup, error)
+
+	// GetOverlappingMeetups returns non-cancelled meetups of given event held in given
+	// venue whose time overlaps with given time range. Returns nil when there is no
+	// overlapping meetups.
+	GetOverlappingMeetups(ctx context.Context, venueID, eventID, startTs, endTs int) ([]entity.Meetup, error)
+
+	// SaveMeetup is used for saving new meetup in storage. It returns the id
+	// assigned by storage to the meetup.
+	SaveMeetup(ctx context.Context, meetup entity.Meetup) (int, error)
 
 	// GetMeetup returns meetup instance for given meetupID from storage. Returns nil
 	// when given meetupID is not found in database.
 	GetMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error)
 
-	// CancelMeetup is used to update meetup status to cancelled in storage.
-	CancelMeetup(ctx context.Context, meetupID int, cancelledReason string) error
+	// UpdateMeetup is used to update name, start & end time, max persons, and status of
+	// the meetup in storage. The operation must be atomic: the meetup is only updated when
+	// it is not cancelled & its new max persons is not less than the current number of
+	// joined persons. Otherwise it returns false and the meetup is left untouched.
+	UpdateMeetup(ctx context.Context, meetup entity.Meetup) (bool, error)
+
+	// CancelMeetup is used to update the status of given meetup to cancelled in storage
+	// along with its cancelled reason, cancelled time & the user who cancelled it. The
+	// operation must be atomic: returns `ErrMeetupCancelled` when the meetup is already
+	// cancelled and the existing cancellation is left untouched.
+	CancelMeetup(ctx context.Context, meetup entity.Meetup) error
+
+	// JoinMeetup is used to add given person to the participants of the meetup. The operation
+	// must be atomic: the person is only added when the meetup is not cancelled, the number of
+	// joined persons is still below the meetup max persons & the person hasn't joined the meetup
+	// yet. Otherwise it returns false and the meetup is left untouched.
+	JoinMeetup(ctx context.Context, meetupID int, person entity.JoinedPerson) (bool, error)
+
+	// LeaveMeetup is used to atomically remove person with given userID from the participants
+	// of the meetup. Returns false when the person is not a participant of the meetup.
+	LeaveMeetup(ctx context.Context, meetupID int, userID int) (bool, error)
+}
+
+type VenueStorage interface {
+	// GetVenue returns venue instance for given venueID from storage. Returns nil
+	// when given venueID is not found in database.
+	GetVenue(ctx context.Context, venueID int) (*entity.Venue, error)
+}
+
+type Clock interface {
+	// Now returns the current time for deriving meetup status & stamping joins and cancellations.
+	Now() time.Time
 }
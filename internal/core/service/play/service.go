@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"gopkg.in/validator.v2"
@@ -31,6 +30,7 @@ type Service interface {
 type service struct {
 	gameStorage    GameStorage
 	partnerStorage PartnerStorage
+	clock          Clock
 }
 
 func (s *service) GetAvailablePartners(ctx context.Context) ([]entity.Monster, error) {
@@ -54,7 +54,7 @@ func (s *service) NewGame(ctx context.Context, playerName string, partnerID stri
 	cfg := entity.GameConfig{
 		PlayerName: playerName,
 		Partner:    partner,
-		CreatedAt:  time.Now().Unix(),
+		CreatedAt:  s.clock.Now().Unix(),
 	}
 	game, err := entity.NewGame(cfg)
 	if err != nil {
@@ -83,6 +83,7 @@ func (s *service) GetGame(ctx context.Context, gameID string) (*entity.Game, err
 type ServiceConfig struct {
 	GameStorage    GameStorage    `validate:"nonnil"`
 	PartnerStorage PartnerStorage `validate:"nonnil"`
+	Clock          Clock          `validate:"nonnil"`
 }
 
 func (c ServiceConfig) Validate() error {
@@ -98,6 +99,7 @@ func NewService(cfg ServiceConfig) (Service, error) {
 	s := &service{
 		gameStorage:    cfg.GameStorage,
 		partnerStorage: cfg.PartnerStorage,
+		clock:          cfg.Clock,
 	}
 	return s, nil
 }
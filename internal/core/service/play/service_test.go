@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/play"
@@ -32,6 +33,7 @@ func TestNewService(t *testing.T) {
 	// define mock dependencies
 	gameStorage := newMockGameStorage()
 	partnerStorage := newMockPartnerStorage(nil)
+	clock := testutil.NewFakeClock(time.Now())
 
 	// define test cases
 	testCases := []struct {
@@ -44,6 +46,7 @@ func TestNewService(t *testing.T) {
 			Config: play.ServiceConfig{
 				GameStorage:    nil,
 				PartnerStorage: partnerStorage,
+				Clock:          clock,
 			},
 			IsError: true,
 		},
@@ -52,6 +55,16 @@ func TestNewService(t *testing.T) {
 			Config: play.ServiceConfig{
 				GameStorage:    gameStorage,
 				PartnerStorage: nil,
+				Clock:          clock,
+			},
+			IsError: true,
+		},
+		{
+			Name: "Test Missing Clock",
+			Config: play.ServiceConfig{
+				GameStorage:    gameStorage,
+				PartnerStorage: partnerStorage,
+				Clock:          nil,
 			},
 			IsError: true,
 		},
@@ -60,6 +73,7 @@ func TestNewService(t *testing.T) {
 			Config: play.ServiceConfig{
 				GameStorage:    gameStorage,
 				PartnerStorage: partnerStorage,
+				Clock:          clock,
 			},
 			IsError: false,
 		},
@@ -106,6 +120,7 @@ func TestServiceNewGame(t *testing.T) {
 	storedGame, err := output.GameStorage.GetGame(context.Background(), game.ID)
 	require.NoError(t, err, "unexpected error")
 	require.Equal(t, *game, *storedGame, "mismatch game")
+	require.Equal(t, output.Clock.Now().Unix(), game.CreatedAt, "mismatch created at")
 
 	// create new game with invalid partner, should return error
 	game, err = output.Service.NewGame(context.Background(), "Riandy R.N", uuid.NewString())
@@ -189,11 +204,13 @@ func newService() *newServiceOutput {
 	// initialize dependencies
 	gameStorage := newMockGameStorage()
 	partnerStorage := newMockPartnerStorage(partners)
+	clock := testutil.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
 
 	// initialize service
 	cfg := play.ServiceConfig{
 		GameStorage:    gameStorage,
 		PartnerStorage: partnerStorage,
+		Clock:          clock,
 	}
 	svc, _ := play.NewService(cfg)
 
@@ -202,10 +219,12 @@ func newService() *newServiceOutput {
 		GameStorage:    gameStorage,
 		PartnerStorage: partnerStorage,
 		Partners:       partners,
+		Clock:          clock,
 	}
 }
 
 type newServiceOutput struct {
+	Clock          *testutil.FakeClock
 	Service        play.Service
 	GameStorage    *mockGameStorage
 	PartnerStorage *mockPartnerStorage
@@ -2,6 +2,7 @@ package play
 
 import (
 	"context"
+	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 )
@@ -24,3 +25,8 @@ type PartnerStorage interface {
 	// when given partnerID is not found.
 	GetPartner(ctx context.Context, partnerID string) (*entity.Monster, error)
 }
+
+type Clock interface {
+	// Now returns the current time which is recorded as creation time of new games.
+	Now() time.Time
+}
@@ -4,63 +4,334 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/google/uuid"
 	"gopkg.in/validator.v2"
 )
 
 var (
-	ErrUserNotFound = errors.New("user is not found")
-	ErrInvalidCreds = errors.New("invalid username or password")
+	ErrUserNotFound        = errors.New("user is not found")
+	ErrInvalidCreds        = errors.New("invalid username or password")
+	ErrInvalidAccessToken  = errors.New("invalid access token")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrLoginLocked         = errors.New("login is temporarily locked")
 )
 
+// LoginLockedError is returned when login is temporarily locked due to
+// repeated failures, it matches `ErrLoginLocked` in errors.Is().
+type LoginLockedError struct {
+	// RetryAfter is the remaining duration of the lock
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Error()
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
+
 type Service interface {
 	// CreateSession is used to login to the system.
 	// It returns a JWT token that can be used to access
 	// other endpoints named access_token.
-	CreateSession(ctx context.Context, username, password string) (*entity.Session, error)
+	//
+	// Repeated failed logins on the same username or from the same client ip
+	// lock the login with exponential backoff, in such case it returns
+	// `*LoginLockedError`.
+	CreateSession(ctx context.Context, username, password, clientIP string) (*entity.Session, error)
+
+	// RefreshSession exchanges given refresh token for new access token &
+	// refresh token. Every refresh token could only be used once, reusing
+	// already rotated refresh token revokes the whole session. Returns
+	// `ErrInvalidRefreshToken` when the token is unknown, expired, reused,
+	// or the session is already revoked.
+	RefreshSession(ctx context.Context, refreshToken string) (*entity.Session, error)
+
+	// DeleteSession is used to logout from the system. It revokes given
+	// access token and all refresh tokens of its session. Returns
+	// `ErrInvalidAccessToken` when the token is invalid or expired.
+	DeleteSession(ctx context.Context, accessToken string) error
+
+	// Authenticate returns identity of the user who owns given access token.
+	// Returns `ErrInvalidAccessToken` when the token is invalid, expired, or
+	// revoked.
+	Authenticate(ctx context.Context, accessToken string) (*entity.Caller, error)
+
+	// GetSession returns the verified session carried by given access token.
+	// Returns `ErrInvalidAccessToken` when the token is invalid, expired, or
+	// revoked.
+	GetSession(ctx context.Context, accessToken string) (*entity.Session, error)
 }
 
 type service struct {
-	sessionStorage SessionStorage
-	userStorage    UserStorage
+	sessionStorage       SessionStorage
+	refreshTokenStorage  RefreshTokenStorage
+	revocationStorage    RevocationStorage
+	userStorage          UserStorage
+	loginAttemptStorage  LoginAttemptStorage
+	clock                Clock
+	tokenLifetime        time.Duration
+	refreshTokenLifetime time.Duration
+	loginPolicy          entity.LoginPolicy
+	dummyPasswordHash    string
 }
 
-func (s *service) CreateSession(ctx context.Context, username, password string) (*entity.Session, error) {
+func (s *service) CreateSession(ctx context.Context, username, password, clientIP string) (*entity.Session, error) {
+	// username is case-insensitive, it is always stored in lowercase
+	username = strings.ToLower(strings.TrimSpace(username))
+	// make sure the login is not locked
+	now := s.clock.Now().Unix()
+	keys := getLoginAttemptKeys(username, clientIP)
+	err := s.checkLoginLock(ctx, keys, now)
+	if err != nil {
+		return nil, err
+	}
 	// get user
-	user, err := s.userStorage.GetUser(ctx, username, password)
+	user, err := s.userStorage.GetUserByUsername(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("unable to fetch user instance due: %w", err)
 	}
 	if user == nil {
-		return nil, ErrInvalidCreds
+		// verify against dummy hash so the response time doesn't reveal
+		// whether the username exists
+		entity.VerifyPassword(s.dummyPasswordHash, password)
+		return nil, s.addLoginFailure(ctx, keys, now)
+	}
+	if !user.IsPasswordMatch(password) {
+		return nil, s.addLoginFailure(ctx, keys, now)
+	}
+	// successful login only clears the failures of the username, the client
+	// may still be guessing passwords of other users
+	err = s.loginAttemptStorage.DeleteLoginAttempt(ctx, keys[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to delete login attempt due: %w", err)
+	}
+	// issue session in new family
+	session, refreshToken, err := s.issueSession(ctx, *user, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+	err = s.refreshTokenStorage.SaveRefreshToken(ctx, *refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("unable to save refresh token due: %w", err)
 	}
 
+	return session, nil
+}
+
+// getLoginAttemptKeys returns the keys for tracking failed logins, the first
+// key is always for the username.
+func getLoginAttemptKeys(username, clientIP string) []string {
+	keys := []string{"username#" + username}
+	if len(clientIP) > 0 {
+		keys = append(keys, "ip#"+clientIP)
+	}
+	return keys
+}
+
+// checkLoginLock returns `*LoginLockedError` when login of any of given keys
+// is still locked at given time.
+func (s *service) checkLoginLock(ctx context.Context, keys []string, now int64) error {
+	var lockedUntil int64
+	for _, key := range keys {
+		attempt, err := s.loginAttemptStorage.GetLoginAttempt(ctx, key, now)
+		if err != nil {
+			return fmt.Errorf("unable to get login attempt due: %w", err)
+		}
+		if attempt == nil {
+			continue
+		}
+		if until := s.loginPolicy.GetLockedUntil(*attempt); until > lockedUntil {
+			lockedUntil = until
+		}
+	}
+	if lockedUntil > now {
+		return &LoginLockedError{RetryAfter: time.Duration(lockedUntil-now) * time.Second}
+	}
+	return nil
+}
+
+// addLoginFailure records failed login for given keys, it returns
+// `ErrInvalidCreds` when succeed.
+func (s *service) addLoginFailure(ctx context.Context, keys []string, now int64) error {
+	for _, key := range keys {
+		_, err := s.loginAttemptStorage.AddLoginFailure(ctx, key, now, s.loginPolicy.GetExpiresAt(now))
+		if err != nil {
+			return fmt.Errorf("unable to add login failure due: %w", err)
+		}
+	}
+	return ErrInvalidCreds
+}
+
+func (s *service) RefreshSession(ctx context.Context, refreshToken string) (*entity.Session, error) {
+	// get refresh token
+	token, err := s.refreshTokenStorage.GetRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get refresh token due: %w", err)
+	}
+	if token == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	isRevoked, err := s.refreshTokenStorage.IsFamilyRevoked(ctx, token.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check refresh token family due: %w", err)
+	}
+	if isRevoked {
+		return nil, ErrInvalidRefreshToken
+	}
+	// rotated token is being reused, the token might be leaked so we need
+	// to revoke the whole family
+	if token.IsRotated() {
+		return nil, s.revokeFamily(ctx, token.FamilyID)
+	}
+	if token.IsExpired(s.clock.Now().Unix()) {
+		return nil, ErrInvalidRefreshToken
+	}
+	// get user, the user may already be updated since the last session
+	user, err := s.userStorage.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch user instance due: %w", err)
+	}
+	if user == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	// issue new session in the same family
+	session, newToken, err := s.issueSession(ctx, *user, token.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := s.refreshTokenStorage.RotateRefreshToken(ctx, token.ID, *newToken)
+	if err != nil {
+		return nil, fmt.Errorf("unable to rotate refresh token due: %w", err)
+	}
+	if !ok {
+		// the token is concurrently used by other party
+		return nil, s.revokeFamily(ctx, token.FamilyID)
+	}
+
+	return session, nil
+}
+
+// revokeFamily revokes given refresh token family, it returns
+// `ErrInvalidRefreshToken` when succeed.
+func (s *service) revokeFamily(ctx context.Context, familyID string) error {
+	err := s.refreshTokenStorage.RevokeFamily(ctx, familyID, s.clock.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("unable to revoke refresh token family due: %w", err)
+	}
+	return ErrInvalidRefreshToken
+}
+
+// issueSession returns new session with its access token for given user along
+// with the refresh token. The refresh token is not saved yet into storage.
+func (s *service) issueSession(ctx context.Context, user entity.User, familyID string) (*entity.Session, *entity.RefreshToken, error) {
 	// initiate new session instance
-	cfg := entity.SessionConfig{
+	now := s.clock.Now()
+	session, err := entity.NewSession(entity.SessionConfig{
 		UserID:   user.ID,
 		Username: user.Username,
 		Email:    user.Email,
-		Exp:      time.Now().Add(time.Hour * 24).Unix(),
+		FamilyID: familyID,
+		IssuedAt: now.Unix(),
+		Exp:      now.Add(s.tokenLifetime).Unix(),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to initialize session instance due: %w", err)
 	}
-	session, err := entity.NewSession(cfg)
+	// initiate new refresh token instance
+	refreshToken, err := entity.NewRefreshToken(entity.RefreshTokenConfig{
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.refreshTokenLifetime).Unix(),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to initialize session instance due: %w", err)
+		return nil, nil, fmt.Errorf("unable to initialize refresh token instance due: %w", err)
 	}
 	// generate token instance on storage
-	accessToken, err := s.sessionStorage.GenerateToken(ctx, session.ID)
+	accessToken, err := s.sessionStorage.GenerateToken(ctx, *session)
 	if err != nil {
-		return nil, fmt.Errorf("unable to generate token due: %w", err)
+		return nil, nil, fmt.Errorf("unable to generate token due: %w", err)
 	}
 
 	session.AccessToken = accessToken
+	session.RefreshToken = refreshToken.ID
+	session.RefreshTokenExpiresAt = refreshToken.ExpiresAt
+	return session, refreshToken, nil
+}
+
+func (s *service) DeleteSession(ctx context.Context, accessToken string) error {
+	session, err := s.GetSession(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	err = s.revocationStorage.RevokeAccessToken(ctx, session.TokenID, session.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("unable to revoke access token due: %w", err)
+	}
+	err = s.refreshTokenStorage.RevokeFamily(ctx, session.FamilyID, s.clock.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("unable to revoke refresh token family due: %w", err)
+	}
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, accessToken string) (*entity.Caller, error) {
+	session, err := s.GetSession(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Caller{
+		ID:       session.ID,
+		Username: session.Username,
+		Email:    session.Email,
+	}, nil
+}
+
+func (s *service) GetSession(ctx context.Context, accessToken string) (*entity.Session, error) {
+	session, err := s.sessionStorage.ParseToken(ctx, accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse token due: %w", err)
+	}
+	if session == nil {
+		return nil, ErrInvalidAccessToken
+	}
+	// make sure the token is not revoked, either by itself or by its family
+	isRevoked, err := s.revocationStorage.IsAccessTokenRevoked(ctx, session.TokenID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check access token revocation due: %w", err)
+	}
+	if isRevoked {
+		return nil, ErrInvalidAccessToken
+	}
+	isRevoked, err = s.refreshTokenStorage.IsFamilyRevoked(ctx, session.FamilyID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check refresh token family due: %w", err)
+	}
+	if isRevoked {
+		return nil, ErrInvalidAccessToken
+	}
 	return session, nil
 }
 
 type ServiceConfig struct {
-	SessionStorage SessionStorage `validate:"nonnil"`
-	UserStorage    UserStorage    `validate:"nonnil"`
+	SessionStorage      SessionStorage      `validate:"nonnil"`
+	RefreshTokenStorage RefreshTokenStorage `validate:"nonnil"`
+	RevocationStorage   RevocationStorage   `validate:"nonnil"`
+	UserStorage         UserStorage         `validate:"nonnil"`
+	LoginAttemptStorage LoginAttemptStorage `validate:"nonnil"`
+	Clock               Clock               `validate:"nonnil"`
+	// TokenLifetime is the duration of access token validity
+	TokenLifetime time.Duration `validate:"min=1"`
+	// RefreshTokenLifetime is the duration of refresh token validity
+	RefreshTokenLifetime time.Duration `validate:"min=1"`
+	// LoginPolicy defines when login is locked after repeated failures
+	LoginPolicy entity.LoginPolicy
 }
 
 func (c ServiceConfig) Validate() error {
@@ -73,9 +344,21 @@ func NewService(cfg ServiceConfig) (Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	dummyPasswordHash, err := entity.HashPassword(uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
 	s := &service{
-		sessionStorage: cfg.SessionStorage,
-		userStorage:    cfg.UserStorage,
+		sessionStorage:       cfg.SessionStorage,
+		refreshTokenStorage:  cfg.RefreshTokenStorage,
+		revocationStorage:    cfg.RevocationStorage,
+		userStorage:          cfg.UserStorage,
+		loginAttemptStorage:  cfg.LoginAttemptStorage,
+		clock:                cfg.Clock,
+		tokenLifetime:        cfg.TokenLifetime,
+		refreshTokenLifetime: cfg.RefreshTokenLifetime,
+		loginPolicy:          cfg.LoginPolicy,
+		dummyPasswordHash:    dummyPasswordHash,
 	}
 	return s, nil
 }
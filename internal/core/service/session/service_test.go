@@ -0,0 +1,504 @@
+package session_test
+
+/*
+	The purpose of testing the Service component is to ensure it has correct
+	implementation of business logic.
+
+	The common pitfall when creating test for Service component is we tend to use
+	concrete implementation for the dependency components (e.g actual GameStorage
+	for MySQL). Not only this will increase the test complexity but also it will
+	increase the possibility of getting false test result. The reason is simply
+	because service such as MySQL has its own constraints & has much higher chance
+	of failing rather than its mock counterpart (e.g network failure).
+
+	So to avoid this pitfall, our first go to choice is to use mock implementation
+	for the dependency when testing the Service component. This way we can control
+	more the behavior of the dependency components to fit our test scenarios.
+*/
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/session"
+	"github.com/Haraj-backend/hex-monscape/internal/core/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceCreateSession(t *testing.T) {
+	svc := newService(t)
+
+	// login with invalid password
+	_, err := svc.Service.CreateSession(context.Background(), testUser.Username, "invalid", testClientIP)
+	require.ErrorIs(t, err, session.ErrInvalidCreds)
+
+	// login with unknown username
+	_, err = svc.Service.CreateSession(context.Background(), "unknown", testPassword, testClientIP)
+	require.ErrorIs(t, err, session.ErrInvalidCreds)
+
+	// login with valid credentials
+	sess, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testClientIP)
+	require.NoError(t, err)
+	require.Equal(t, testUser.ID, sess.ID, "mismatch user id")
+	require.NotEmpty(t, sess.AccessToken, "access token is empty")
+	require.NotEmpty(t, sess.RefreshToken, "refresh token is empty")
+	require.Greater(t, sess.RefreshTokenExpiresAt, sess.ExpiresAt, "refresh token should outlive access token")
+
+	// the access token should be valid
+	caller, err := svc.Service.Authenticate(context.Background(), sess.AccessToken)
+	require.NoError(t, err)
+	require.Equal(t, entity.Caller{ID: testUser.ID, Username: testUser.Username, Email: testUser.Email}, *caller)
+}
+
+func TestServiceCreateSessionLocked(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name          string
+		FailUsername  string
+		FailClientIP  string
+		LoginClientIP string
+	}{
+		{
+			Name:          "Locked By Username",
+			FailUsername:  testUser.Username,
+			FailClientIP:  "10.0.0.1",
+			LoginClientIP: "10.0.0.2",
+		},
+		{
+			Name:          "Locked By Client IP",
+			FailUsername:  "unknown",
+			FailClientIP:  testClientIP,
+			LoginClientIP: testClientIP,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			svc := newService(t)
+
+			// fail the login until the limit
+			for i := 0; i < testLoginPolicy.MaxFailures; i++ {
+				_, err := svc.Service.CreateSession(context.Background(), testCase.FailUsername, "invalid", testCase.FailClientIP)
+				require.ErrorIs(t, err, session.ErrInvalidCreds)
+			}
+
+			// login is locked even with valid credentials
+			_, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testCase.LoginClientIP)
+			require.ErrorIs(t, err, session.ErrLoginLocked)
+			var lockedErr *session.LoginLockedError
+			require.ErrorAs(t, err, &lockedErr)
+			require.Equal(t, testLoginPolicy.Lockout, lockedErr.RetryAfter, "mismatch retry after")
+
+			// the lock is gradually released as the time passes
+			svc.Clock.Advance(testLoginPolicy.Lockout / 2)
+			_, err = svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testCase.LoginClientIP)
+			require.ErrorAs(t, err, &lockedErr)
+			require.Equal(t, testLoginPolicy.Lockout/2, lockedErr.RetryAfter, "mismatch retry after")
+
+			// login is allowed once the lock is over
+			svc.Clock.Advance(testLoginPolicy.Lockout / 2)
+			_, err = svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testCase.LoginClientIP)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServiceCreateSessionResetFailures(t *testing.T) {
+	svc := newService(t)
+
+	// fail the login right before the limit then login successfully
+	for i := 0; i < testLoginPolicy.MaxFailures-1; i++ {
+		_, err := svc.Service.CreateSession(context.Background(), testUser.Username, "invalid", testClientIP)
+		require.ErrorIs(t, err, session.ErrInvalidCreds)
+	}
+	_, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, "10.0.0.1")
+	require.NoError(t, err)
+
+	// the failures of the username should be cleared, so the next failure
+	// from other client doesn't lock the login
+	_, err = svc.Service.CreateSession(context.Background(), testUser.Username, "invalid", "10.0.0.2")
+	require.ErrorIs(t, err, session.ErrInvalidCreds)
+	_, err = svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, "10.0.0.3")
+	require.NoError(t, err)
+}
+
+func TestServiceCreateSessionFailuresExpired(t *testing.T) {
+	svc := newService(t)
+
+	// fail the login right before the limit
+	for i := 0; i < testLoginPolicy.MaxFailures-1; i++ {
+		_, err := svc.Service.CreateSession(context.Background(), testUser.Username, "invalid", testClientIP)
+		require.ErrorIs(t, err, session.ErrInvalidCreds)
+	}
+
+	// the failures are forgotten once the records expire on the service
+	// clock, so the next failure doesn't lock the login
+	failedAt := svc.Clock.Now().Unix()
+	svc.Clock.Advance(time.Duration(testLoginPolicy.GetExpiresAt(failedAt)-failedAt) * time.Second)
+	_, err := svc.Service.CreateSession(context.Background(), testUser.Username, "invalid", testClientIP)
+	require.ErrorIs(t, err, session.ErrInvalidCreds)
+	_, err = svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testClientIP)
+	require.NoError(t, err)
+}
+
+func TestServiceRefreshSession(t *testing.T) {
+	svc := newService(t)
+
+	// login
+	sess, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testClientIP)
+	require.NoError(t, err)
+
+	// refresh session, new tokens should be issued in the same family
+	newSess, err := svc.Service.RefreshSession(context.Background(), sess.RefreshToken)
+	require.NoError(t, err)
+	require.Equal(t, sess.FamilyID, newSess.FamilyID, "mismatch family id")
+	require.NotEqual(t, sess.AccessToken, newSess.AccessToken, "access token is not renewed")
+	require.NotEqual(t, sess.RefreshToken, newSess.RefreshToken, "refresh token is not rotated")
+
+	// refresh using new refresh token, should be fine
+	lastSess, err := svc.Service.RefreshSession(context.Background(), newSess.RefreshToken)
+	require.NoError(t, err)
+
+	// unknown refresh token
+	_, err = svc.Service.RefreshSession(context.Background(), uuid.NewString())
+	require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+
+	// reuse rotated refresh token, the whole family should be revoked
+	_, err = svc.Service.RefreshSession(context.Background(), sess.RefreshToken)
+	require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+
+	_, err = svc.Service.RefreshSession(context.Background(), lastSess.RefreshToken)
+	require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+
+	_, err = svc.Service.Authenticate(context.Background(), lastSess.AccessToken)
+	require.ErrorIs(t, err, session.ErrInvalidAccessToken)
+
+	// other session of the same user is not affected
+	otherSess, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testClientIP)
+	require.NoError(t, err)
+	_, err = svc.Service.RefreshSession(context.Background(), otherSess.RefreshToken)
+	require.NoError(t, err)
+}
+
+func TestServiceRefreshSessionExpired(t *testing.T) {
+	svc := newService(t)
+
+	// save expired refresh token
+	token := entity.RefreshToken{
+		ID:        uuid.NewString(),
+		FamilyID:  uuid.NewString(),
+		UserID:    testUser.ID,
+		IssuedAt:  svc.Clock.Now().Add(-2 * time.Hour).Unix(),
+		ExpiresAt: svc.Clock.Now().Add(-time.Hour).Unix(),
+	}
+	err := svc.RefreshTokenStorage.SaveRefreshToken(context.Background(), token)
+	require.NoError(t, err)
+
+	// refresh session
+	_, err = svc.Service.RefreshSession(context.Background(), token.ID)
+	require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+}
+
+func TestServiceRefreshSessionConcurrently(t *testing.T) {
+	svc := newService(t)
+
+	// login
+	sess, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testClientIP)
+	require.NoError(t, err)
+
+	// use the same refresh token concurrently, only one of them should
+	// succeed, the rest are treated as reuse so the family is revoked
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	succeed := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := svc.Service.RefreshSession(context.Background(), sess.RefreshToken)
+			if err == nil {
+				mtx.Lock()
+				succeed++
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.LessOrEqual(t, succeed, 1, "refresh token is used more than once")
+
+	isRevoked, err := svc.RefreshTokenStorage.IsFamilyRevoked(context.Background(), sess.FamilyID)
+	require.NoError(t, err)
+	require.True(t, isRevoked, "family is not revoked")
+}
+
+func TestServiceDeleteSession(t *testing.T) {
+	svc := newService(t)
+
+	// login twice
+	sess, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testClientIP)
+	require.NoError(t, err)
+	otherSess, err := svc.Service.CreateSession(context.Background(), testUser.Username, testPassword, testClientIP)
+	require.NoError(t, err)
+
+	// logout
+	err = svc.Service.DeleteSession(context.Background(), sess.AccessToken)
+	require.NoError(t, err)
+
+	// access token & refresh token should no longer be valid
+	_, err = svc.Service.Authenticate(context.Background(), sess.AccessToken)
+	require.ErrorIs(t, err, session.ErrInvalidAccessToken)
+
+	_, err = svc.Service.GetSession(context.Background(), sess.AccessToken)
+	require.ErrorIs(t, err, session.ErrInvalidAccessToken)
+
+	_, err = svc.Service.RefreshSession(context.Background(), sess.RefreshToken)
+	require.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+
+	err = svc.Service.DeleteSession(context.Background(), sess.AccessToken)
+	require.ErrorIs(t, err, session.ErrInvalidAccessToken)
+
+	// other session should not be affected
+	_, err = svc.Service.Authenticate(context.Background(), otherSess.AccessToken)
+	require.NoError(t, err)
+}
+
+const (
+	testPassword = "123456"
+	testClientIP = "127.0.0.1"
+)
+
+var testUser = newTestUser()
+
+var testLoginPolicy = entity.LoginPolicy{
+	MaxFailures: 3,
+	Lockout:     time.Minute,
+	MaxLockout:  time.Hour,
+}
+
+func newTestUser() entity.User {
+	passwordHash, _ := entity.HashPassword(testPassword)
+	return entity.User{
+		ID:           1,
+		Username:     "marion",
+		Email:        "marion@eveners.com",
+		PasswordHash: passwordHash,
+	}
+}
+
+type newServiceOutput struct {
+	Service             session.Service
+	RefreshTokenStorage *mockRefreshTokenStorage
+	Clock               *testutil.FakeClock
+}
+
+func newService(t *testing.T) *newServiceOutput {
+	refreshTokenStorage := newMockRefreshTokenStorage()
+	clock := testutil.NewFakeClock(time.Now())
+	svc, err := session.NewService(session.ServiceConfig{
+		SessionStorage:       newMockSessionStorage(),
+		RefreshTokenStorage:  refreshTokenStorage,
+		RevocationStorage:    newMockRevocationStorage(),
+		UserStorage:          newMockUserStorage(testUser),
+		LoginAttemptStorage:  newMockLoginAttemptStorage(),
+		Clock:                clock,
+		TokenLifetime:        time.Hour,
+		RefreshTokenLifetime: 24 * time.Hour,
+		LoginPolicy:          testLoginPolicy,
+	})
+	require.NoError(t, err)
+
+	return &newServiceOutput{
+		Service:             svc,
+		RefreshTokenStorage: refreshTokenStorage,
+		Clock:               clock,
+	}
+}
+
+type mockSessionStorage struct {
+	sync.Mutex
+	sessions map[string]entity.Session
+}
+
+func (s *mockSessionStorage) GenerateToken(ctx context.Context, sess entity.Session) (string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	accessToken := uuid.NewString()
+	s.sessions[accessToken] = sess
+	return accessToken, nil
+}
+
+func (s *mockSessionStorage) ParseToken(ctx context.Context, accessToken string) (*entity.Session, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	sess, ok := s.sessions[accessToken]
+	if !ok || time.Now().Unix() >= sess.ExpiresAt {
+		return nil, nil
+	}
+	sess.AccessToken = accessToken
+	return &sess, nil
+}
+
+func newMockSessionStorage() *mockSessionStorage {
+	return &mockSessionStorage{sessions: map[string]entity.Session{}}
+}
+
+type mockRefreshTokenStorage struct {
+	sync.Mutex
+	tokens          map[string]entity.RefreshToken
+	revokedFamilies map[string]bool
+}
+
+func (s *mockRefreshTokenStorage) SaveRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.tokens[token.ID] = token
+	return nil
+}
+
+func (s *mockRefreshTokenStorage) GetRefreshToken(ctx context.Context, tokenID string) (*entity.RefreshToken, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	token, ok := s.tokens[tokenID]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+func (s *mockRefreshTokenStorage) RotateRefreshToken(ctx context.Context, oldTokenID string, newToken entity.RefreshToken) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	oldToken, ok := s.tokens[oldTokenID]
+	if !ok || oldToken.IsRotated() || s.revokedFamilies[oldToken.FamilyID] {
+		return false, nil
+	}
+	oldToken.RotatedAt = newToken.IssuedAt
+	s.tokens[oldTokenID] = oldToken
+	s.tokens[newToken.ID] = newToken
+	return true, nil
+}
+
+func (s *mockRefreshTokenStorage) RevokeFamily(ctx context.Context, familyID string, revokedAt int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.revokedFamilies[familyID] = true
+	return nil
+}
+
+func (s *mockRefreshTokenStorage) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.revokedFamilies[familyID], nil
+}
+
+func newMockRefreshTokenStorage() *mockRefreshTokenStorage {
+	return &mockRefreshTokenStorage{
+		tokens:          map[string]entity.RefreshToken{},
+		revokedFamilies: map[string]bool{},
+	}
+}
+
+type mockRevocationStorage struct {
+	sync.Mutex
+	revokedTokens map[string]bool
+}
+
+func (s *mockRevocationStorage) RevokeAccessToken(ctx context.Context, tokenID string, exp int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.revokedTokens[tokenID] = true
+	return nil
+}
+
+func (s *mockRevocationStorage) IsAccessTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.revokedTokens[tokenID], nil
+}
+
+func newMockRevocationStorage() *mockRevocationStorage {
+	return &mockRevocationStorage{revokedTokens: map[string]bool{}}
+}
+
+type mockUserStorage struct {
+	users []entity.User
+}
+
+func (s *mockUserStorage) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	for _, user := range s.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *mockUserStorage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	for _, user := range s.users {
+		if user.ID == userID {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func newMockUserStorage(users ...entity.User) *mockUserStorage {
+	return &mockUserStorage{users: users}
+}
+
+type mockLoginAttemptStorage struct {
+	sync.Mutex
+	attempts map[string]entity.LoginAttempt
+}
+
+func (s *mockLoginAttemptStorage) GetLoginAttempt(ctx context.Context, key string, now int64) (*entity.LoginAttempt, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || attempt.IsExpired(now) {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+func (s *mockLoginAttemptStorage) AddLoginFailure(ctx context.Context, key string, failedAt int64, expiresAt int64) (*entity.LoginAttempt, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || attempt.IsExpired(failedAt) {
+		attempt = entity.LoginAttempt{Key: key}
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = failedAt
+	attempt.ExpiresAt = expiresAt
+	s.attempts[key] = attempt
+
+	return &attempt, nil
+}
+
+func (s *mockLoginAttemptStorage) DeleteLoginAttempt(ctx context.Context, key string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+func newMockLoginAttemptStorage() *mockLoginAttemptStorage {
+	return &mockLoginAttemptStorage{attempts: map[string]entity.LoginAttempt{}}
+}
@@ -2,17 +2,78 @@ package session
 
 import (
 	"context"
+	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 )
 
 type SessionStorage interface {
-	// GenerateToken is used for generate jwt in storage.
-	GenerateToken(ctx context.Context, userID int) (string, error)
+	// GenerateToken is used for generate jwt in storage. The token carries
+	// the claims of given session and expires at the session expiry time.
+	GenerateToken(ctx context.Context, session entity.Session) (string, error)
+
+	// ParseToken returns session carried by given access token. Returns nil when
+	// the access token is invalid or already expired.
+	ParseToken(ctx context.Context, accessToken string) (*entity.Session, error)
+}
+
+type RefreshTokenStorage interface {
+	// SaveRefreshToken is used for saving new refresh token into storage.
+	SaveRefreshToken(ctx context.Context, token entity.RefreshToken) error
+
+	// GetRefreshToken returns refresh token for given id. Returns nil when
+	// the token is not found.
+	GetRefreshToken(ctx context.Context, tokenID string) (*entity.RefreshToken, error)
+
+	// RotateRefreshToken atomically marks the old token as rotated at the time
+	// new token is issued, then saves the new token. Returns false without
+	// changing anything when the old token is already rotated or its family
+	// is already revoked.
+	RotateRefreshToken(ctx context.Context, oldTokenID string, newToken entity.RefreshToken) (bool, error)
+
+	// RevokeFamily revokes all refresh tokens in given family, so none of
+	// them could be used anymore.
+	RevokeFamily(ctx context.Context, familyID string, revokedAt int64) error
+
+	// IsFamilyRevoked returns true when given family is already revoked.
+	IsFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+}
+
+type RevocationStorage interface {
+	// RevokeAccessToken puts access token with given id into revocation list.
+	// The token only needs to be kept in the list until it expires at exp.
+	RevokeAccessToken(ctx context.Context, tokenID string, exp int64) error
+
+	// IsAccessTokenRevoked returns true when access token with given id is in
+	// revocation list.
+	IsAccessTokenRevoked(ctx context.Context, tokenID string) (bool, error)
 }
 
 type UserStorage interface {
-	// GetUser returns game instance for given username and password from storage. Returns nil
-	// when given username and password is not found in database.
-	GetUser(ctx context.Context, username, password string) (*entity.User, error)
+	// GetUserByUsername returns user for given username. Returns nil when
+	// the user is not found.
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+
+	// GetUserByID returns user for given id. Returns nil when the user is
+	// not found.
+	GetUserByID(ctx context.Context, userID int) (*entity.User, error)
+}
+
+type LoginAttemptStorage interface {
+	// GetLoginAttempt returns login attempt record for given key. Returns nil
+	// when the record is not found or already expired at given unix timestamp.
+	GetLoginAttempt(ctx context.Context, key string, now int64) (*entity.LoginAttempt, error)
+
+	// AddLoginFailure atomically increments the failures counter of given key,
+	// then returns the updated record. The counter starts from one when the
+	// record is not found or already expired at failedAt.
+	AddLoginFailure(ctx context.Context, key string, failedAt int64, expiresAt int64) (*entity.LoginAttempt, error)
+
+	// DeleteLoginAttempt deletes login attempt record for given key.
+	DeleteLoginAttempt(ctx context.Context, key string) error
+}
+
+type Clock interface {
+	// Now returns the current time for issuing, expiring & revoking the session tokens.
+	Now() time.Time
 }
@@ -0,0 +1,176 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"gopkg.in/validator.v2"
+)
+
+var (
+	ErrUserNotFound           = errors.New("user is not found")
+	ErrUsernameTaken          = errors.New("username is already taken")
+	ErrEmailTaken             = errors.New("email is already taken")
+	ErrInvalidCurrentPassword = errors.New("current password is invalid")
+)
+
+type Service interface {
+	// RegisterUser creates new user. Returns `ErrUsernameTaken` or
+	// `ErrEmailTaken` when the username or email is already used, returns
+	// entity validation error when the input is invalid.
+	RegisterUser(ctx context.Context, cfg entity.UserConfig) (*entity.User, error)
+
+	// GetMe returns the user who is calling the system.
+	GetMe(ctx context.Context) (*entity.User, error)
+
+	// UpdateProfile updates profile of the user who is calling the system.
+	// Changing password requires the current password, otherwise returns
+	// `ErrInvalidCurrentPassword`.
+	UpdateProfile(ctx context.Context, req entity.UpdateProfileRequest) (*entity.User, error)
+}
+
+type service struct {
+	userStorage UserStorage
+}
+
+func (s *service) RegisterUser(ctx context.Context, cfg entity.UserConfig) (*entity.User, error) {
+	// initialize user, this also validates the input
+	user, err := entity.NewUser(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// make sure username & email are not used yet, the storage also
+	// guarantees this but checking it here gives clearer error
+	err = s.validateUniqueness(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+	// save user
+	user.ID, err = s.userStorage.CreateUser(ctx, *user)
+	if err != nil {
+		if takenErr := getTakenError(err); takenErr != nil {
+			return nil, takenErr
+		}
+		return nil, fmt.Errorf("unable to create user due: %w", err)
+	}
+	return user, nil
+}
+
+func (s *service) GetMe(ctx context.Context) (*entity.User, error) {
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.getUser(ctx, caller.ID)
+}
+
+func (s *service) UpdateProfile(ctx context.Context, req entity.UpdateProfileRequest) (*entity.User, error) {
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.getUser(ctx, caller.ID)
+	if err != nil {
+		return nil, err
+	}
+	// apply changes
+	if len(req.Username) > 0 {
+		err = user.SetUsername(req.Username)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(req.Email) > 0 {
+		err = user.SetEmail(req.Email)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(req.NewPassword) > 0 {
+		if !user.IsPasswordMatch(req.CurrentPassword) {
+			return nil, ErrInvalidCurrentPassword
+		}
+		err = user.SetPassword(req.NewPassword)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.validateUniqueness(ctx, *user)
+	if err != nil {
+		return nil, err
+	}
+	// save changes
+	err = s.userStorage.UpdateUser(ctx, *user)
+	if err != nil {
+		if takenErr := getTakenError(err); takenErr != nil {
+			return nil, takenErr
+		}
+		return nil, fmt.Errorf("unable to update user due: %w", err)
+	}
+	return user, nil
+}
+
+func (s *service) getUser(ctx context.Context, userID int) (*entity.User, error) {
+	user, err := s.userStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get user due: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// validateUniqueness makes sure username & email of given user are not used
+// by other user.
+func (s *service) validateUniqueness(ctx context.Context, user entity.User) error {
+	other, err := s.userStorage.GetUserByUsername(ctx, user.Username)
+	if err != nil {
+		return fmt.Errorf("unable to get user by username due: %w", err)
+	}
+	if other != nil && other.ID != user.ID {
+		return ErrUsernameTaken
+	}
+	other, err = s.userStorage.GetUserByEmail(ctx, user.Email)
+	if err != nil {
+		return fmt.Errorf("unable to get user by email due: %w", err)
+	}
+	if other != nil && other.ID != user.ID {
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+// getTakenError returns `ErrUsernameTaken` or `ErrEmailTaken` when given
+// storage error is caused by either of them, otherwise returns nil.
+func getTakenError(err error) error {
+	switch {
+	case errors.Is(err, ErrUsernameTaken):
+		return ErrUsernameTaken
+	case errors.Is(err, ErrEmailTaken):
+		return ErrEmailTaken
+	}
+	return nil
+}
+
+type ServiceConfig struct {
+	UserStorage UserStorage `validate:"nonnil"`
+}
+
+func (c ServiceConfig) Validate() error {
+	return validator.Validate(c)
+}
+
+// NewService returns new instance of service.
+func NewService(cfg ServiceConfig) (Service, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	s := &service{
+		userStorage: cfg.UserStorage,
+	}
+	return s, nil
+}
@@ -0,0 +1,244 @@
+package user_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRegisterUser(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name   string
+		Config entity.UserConfig
+		ExpErr error
+	}{
+		{
+			Name:   "Valid User",
+			Config: entity.UserConfig{Username: " New_User ", Email: "New@Eveners.com", Password: "secret123"},
+			ExpErr: nil,
+		},
+		{
+			Name:   "Username Taken",
+			Config: entity.UserConfig{Username: "Marion", Email: "other@eveners.com", Password: "secret123"},
+			ExpErr: user.ErrUsernameTaken,
+		},
+		{
+			Name:   "Email Taken",
+			Config: entity.UserConfig{Username: "other", Email: "MARION@eveners.com", Password: "secret123"},
+			ExpErr: user.ErrEmailTaken,
+		},
+		{
+			Name:   "Invalid Username",
+			Config: entity.UserConfig{Username: "a!", Email: "other@eveners.com", Password: "secret123"},
+			ExpErr: entity.ErrInvalidUsername,
+		},
+		{
+			Name:   "Invalid Email",
+			Config: entity.UserConfig{Username: "other", Email: "other", Password: "secret123"},
+			ExpErr: entity.ErrInvalidEmail,
+		},
+		{
+			Name:   "Weak Password",
+			Config: entity.UserConfig{Username: "other", Email: "other@eveners.com", Password: "secret"},
+			ExpErr: entity.ErrWeakPassword,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			strg := newMockUserStorage(newTestUser())
+			svc := newService(t, strg)
+
+			u, err := svc.RegisterUser(context.Background(), testCase.Config)
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+			if testCase.ExpErr != nil {
+				return
+			}
+			require.Equal(t, "new_user", u.Username, "username is not normalized")
+			require.Equal(t, "new@eveners.com", u.Email, "email is not normalized")
+			require.True(t, u.IsPasswordMatch("secret123"), "password is not match")
+
+			// user should be stored with the assigned id
+			stored, err := strg.GetUserByID(context.Background(), u.ID)
+			require.NoError(t, err)
+			require.Equal(t, *u, *stored, "mismatch stored user")
+		})
+	}
+}
+
+func TestServiceGetMe(t *testing.T) {
+	testUser := newTestUser()
+	svc := newService(t, newMockUserStorage(testUser))
+
+	// without caller
+	_, err := svc.GetMe(context.Background())
+	require.ErrorIs(t, err, entity.ErrMissingCaller)
+
+	// unknown caller
+	_, err = svc.GetMe(newCallerContext(99))
+	require.ErrorIs(t, err, user.ErrUserNotFound)
+
+	// valid caller
+	u, err := svc.GetMe(newCallerContext(testUser.ID))
+	require.NoError(t, err)
+	require.Equal(t, testUser, *u, "mismatch user")
+}
+
+func TestServiceUpdateProfile(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name    string
+		Request entity.UpdateProfileRequest
+		ExpErr  error
+	}{
+		{
+			Name:    "Change Username & Email",
+			Request: entity.UpdateProfileRequest{Username: "marion_new", Email: "marion.new@eveners.com"},
+			ExpErr:  nil,
+		},
+		{
+			Name:    "Keep Own Username",
+			Request: entity.UpdateProfileRequest{Username: "marion"},
+			ExpErr:  nil,
+		},
+		{
+			Name:    "Change Password",
+			Request: entity.UpdateProfileRequest{CurrentPassword: testPassword, NewPassword: "newsecret123"},
+			ExpErr:  nil,
+		},
+		{
+			Name:    "Change Password Without Current Password",
+			Request: entity.UpdateProfileRequest{NewPassword: "newsecret123"},
+			ExpErr:  user.ErrInvalidCurrentPassword,
+		},
+		{
+			Name:    "Change Password With Wrong Current Password",
+			Request: entity.UpdateProfileRequest{CurrentPassword: "wrong", NewPassword: "newsecret123"},
+			ExpErr:  user.ErrInvalidCurrentPassword,
+		},
+		{
+			Name:    "Weak New Password",
+			Request: entity.UpdateProfileRequest{CurrentPassword: testPassword, NewPassword: "weak"},
+			ExpErr:  entity.ErrWeakPassword,
+		},
+		{
+			Name:    "Username Taken",
+			Request: entity.UpdateProfileRequest{Username: "todd"},
+			ExpErr:  user.ErrUsernameTaken,
+		},
+		{
+			Name:    "Email Taken",
+			Request: entity.UpdateProfileRequest{Email: "todd@eveners.com"},
+			ExpErr:  user.ErrEmailTaken,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			testUser := newTestUser()
+			otherUser := entity.User{ID: 2, Username: "todd", Email: "todd@eveners.com"}
+			strg := newMockUserStorage(testUser, otherUser)
+			svc := newService(t, strg)
+
+			u, err := svc.UpdateProfile(newCallerContext(testUser.ID), testCase.Request)
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+
+			stored, err := strg.GetUserByID(context.Background(), testUser.ID)
+			require.NoError(t, err)
+			if testCase.ExpErr != nil {
+				require.Equal(t, testUser, *stored, "user should not be changed")
+				return
+			}
+			require.Equal(t, *u, *stored, "mismatch stored user")
+			if len(testCase.Request.Username) > 0 {
+				require.Equal(t, testCase.Request.Username, u.Username, "username is not changed")
+			}
+			if len(testCase.Request.Email) > 0 {
+				require.Equal(t, testCase.Request.Email, u.Email, "email is not changed")
+			}
+			if len(testCase.Request.NewPassword) > 0 {
+				require.True(t, u.IsPasswordMatch(testCase.Request.NewPassword), "password is not changed")
+			}
+		})
+	}
+}
+
+const testPassword = "secret123"
+
+func newTestUser() entity.User {
+	hash, _ := entity.HashPassword(testPassword)
+	return entity.User{
+		ID:           1,
+		Username:     "marion",
+		Email:        "marion@eveners.com",
+		PasswordHash: hash,
+	}
+}
+
+func newCallerContext(userID int) context.Context {
+	return entity.NewCallerContext(context.Background(), entity.Caller{ID: userID})
+}
+
+func newService(t *testing.T, strg user.UserStorage) user.Service {
+	svc, err := user.NewService(user.ServiceConfig{UserStorage: strg})
+	require.NoError(t, err)
+	return svc
+}
+
+type mockUserStorage struct {
+	data   map[int]entity.User
+	lastID int
+}
+
+func (s *mockUserStorage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	u, ok := s.data[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+func (s *mockUserStorage) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	for _, u := range s.data {
+		if u.Username == username {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *mockUserStorage) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, u := range s.data {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *mockUserStorage) CreateUser(ctx context.Context, u entity.User) (int, error) {
+	s.lastID++
+	u.ID = s.lastID
+	s.data[u.ID] = u
+	return u.ID, nil
+}
+
+func (s *mockUserStorage) UpdateUser(ctx context.Context, u entity.User) error {
+	s.data[u.ID] = u
+	return nil
+}
+
+func newMockUserStorage(users ...entity.User) *mockUserStorage {
+	s := &mockUserStorage{data: map[int]entity.User{}}
+	for _, u := range users {
+		s.data[u.ID] = u
+		if u.ID > s.lastID {
+			s.lastID = u.ID
+		}
+	}
+	return s
+}
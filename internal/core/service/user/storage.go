@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+)
+
+type UserStorage interface {
+	// GetUserByID returns user for given id. Returns nil when the user is
+	// not found.
+	GetUserByID(ctx context.Context, userID int) (*entity.User, error)
+
+	// GetUserByUsername returns user for given username. Returns nil when
+	// the user is not found.
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+
+	// GetUserByEmail returns user for given email. Returns nil when the user
+	// is not found.
+	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+
+	// CreateUser saves new user into storage and returns the id assigned to
+	// the user. Returns `ErrUsernameTaken` or `ErrEmailTaken` when the
+	// username or email is already used by other user.
+	CreateUser(ctx context.Context, user entity.User) (int, error)
+
+	// UpdateUser updates username, email, and password hash of existing user.
+	// Returns `ErrUsernameTaken` or `ErrEmailTaken` when the username or email
+	// is already used by other user.
+	UpdateUser(ctx context.Context, user entity.User) error
+}
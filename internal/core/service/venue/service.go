@@ -11,27 +11,229 @@ import (
 
 var (
 	ErrVenueNotFound = errors.New("venue is not found")
+	ErrInvalidEvent  = errors.New("event is not supported by the venue")
+	ErrForbidden     = errors.New("user is not allowed to manage the venue")
 )
 
 type Service interface {
-	// GetVenues returns all venues available in the system.
-	GetVenues(ctx context.Context) ([]entity.Venue, error)
+	// GetVenues returns venues matching given filter. When the filter has
+	// meetup time range, only venues which are open for the whole range and
+	// still have capacity for one more meetup in that range are returned.
+	// Returns `entity.ErrInvalidTimeRange` when the time range is invalid.
+	GetVenues(ctx context.Context, filter entity.VenueFilter) ([]entity.Venue, error)
+
+	// GetVenue returns venue for given venue id. Returns `ErrVenueNotFound`
+	// when the venue is not found.
+	GetVenue(ctx context.Context, venueID int) (*entity.Venue, error)
+
+	// GetVenueAvailability returns whether the venue is open and how many
+	// more meetups of the event could be held in each slot of the queried
+	// time range. Returns `ErrVenueNotFound` when the venue is not found and
+	// `ErrInvalidEvent` when the event is not supported by the venue.
+	GetVenueAvailability(ctx context.Context, query entity.AvailabilityQuery) (*entity.VenueAvailability, error)
+
+	// AddVenueClosure closes the venue for given time range, e.g for public
+	// holiday or maintenance. Only venue admins could add closures, otherwise
+	// it returns `ErrForbidden`. The existing meetups held during the closure
+	// are not cancelled, instead the ones which are not finished nor cancelled
+	// yet are returned as conflicting meetups so their organizers could be
+	// informed.
+	AddVenueClosure(ctx context.Context, venueID int, closure entity.VenueClosure) (*entity.AddVenueClosureResponse, error)
 }
 
 type service struct {
-	venueStorage VenueStorage
+	venueStorage  VenueStorage
+	meetupStorage MeetupStorage
+	clock         Clock
+	adminUserIDs  []int
 }
 
-func (s *service) GetVenues(ctx context.Context) ([]entity.Venue, error) {
+func (s *service) GetVenues(ctx context.Context, filter entity.VenueFilter) ([]entity.Venue, error) {
+	err := filter.Validate()
+	if err != nil {
+		return nil, err
+	}
 	venues, err := s.venueStorage.GetVenues(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get available venues due: %w", err)
 	}
-	return venues, nil
+	var res []entity.Venue
+	for _, venue := range venues {
+		ok, err := s.isMatch(ctx, venue, filter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			res = append(res, venue)
+		}
+	}
+	return res, nil
+}
+
+// isMatch returns true when given venue matches the filter.
+func (s *service) isMatch(ctx context.Context, venue entity.Venue, filter entity.VenueFilter) (bool, error) {
+	// when event is specified, only the capacity of that event is considered
+	events := venue.SupportedEvents
+	if filter.EventID != 0 {
+		supportedEvent := venue.GetSupportedEvent(filter.EventID)
+		if supportedEvent == nil {
+			return false, nil
+		}
+		events = []entity.SupportedEvent{*supportedEvent}
+	}
+	if !filter.HasTimeRange() {
+		return true, nil
+	}
+	// make sure the venue is open for the whole time range
+	isOpen, err := venue.IsOpen(filter.MeetupStartTs, filter.MeetupEndTs)
+	if err != nil {
+		return false, fmt.Errorf("unable to check venue %v operating hours due: %w", venue.ID, err)
+	}
+	if !isOpen {
+		return false, nil
+	}
+	// make sure at least one of the events still has capacity
+	for _, event := range events {
+		hasCapacity, err := s.hasCapacity(ctx, venue.ID, event, filter.MeetupStartTs, filter.MeetupEndTs)
+		if err != nil {
+			return false, err
+		}
+		if hasCapacity {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// hasCapacity returns true when the venue could still host one more meetup of
+// given event in given time range.
+func (s *service) hasCapacity(ctx context.Context, venueID int, event entity.SupportedEvent, startTs, endTs int) (bool, error) {
+	meetups, err := s.meetupStorage.GetOverlappingMeetups(ctx, venueID, event.ID, startTs, endTs)
+	if err != nil {
+		return false, fmt.Errorf("unable to get overlapping meetups due: %w", err)
+	}
+	return entity.CountMaxConcurrentMeetups(meetups, startTs, endTs) < event.EventCapacity, nil
+}
+
+func (s *service) GetVenue(ctx context.Context, venueID int) (*entity.Venue, error) {
+	venue, err := s.venueStorage.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get venue due: %w", err)
+	}
+	if venue == nil {
+		return nil, ErrVenueNotFound
+	}
+	return venue, nil
+}
+
+func (s *service) GetVenueAvailability(ctx context.Context, query entity.AvailabilityQuery) (*entity.VenueAvailability, error) {
+	err := query.Validate()
+	if err != nil {
+		return nil, err
+	}
+	venue, err := s.GetVenue(ctx, query.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	supportedEvent := venue.GetSupportedEvent(query.EventID)
+	if supportedEvent == nil {
+		return nil, ErrInvalidEvent
+	}
+	slots, err := venue.GetAvailabilitySlots(query.FromTs, query.ToTs, query.GetGranularity())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get venue %v slots due: %w", venue.ID, err)
+	}
+	// fetch the meetups for the whole time range once, then count them for
+	// each slot
+	meetups, err := s.meetupStorage.GetOverlappingMeetups(ctx, venue.ID, supportedEvent.ID, query.FromTs, query.ToTs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get overlapping meetups due: %w", err)
+	}
+	for i, slot := range slots {
+		if !slot.IsOpen {
+			continue
+		}
+		remaining := supportedEvent.EventCapacity - entity.CountMaxConcurrentMeetups(meetups, slot.StartTs, slot.EndTs)
+		if remaining > 0 {
+			slots[i].RemainingCapacity = remaining
+		}
+	}
+	availability := &entity.VenueAvailability{
+		VenueID:       venue.ID,
+		EventID:       supportedEvent.ID,
+		TimeZone:      venue.TimeZone,
+		EventCapacity: supportedEvent.EventCapacity,
+		Slots:         slots,
+	}
+	return availability, nil
+}
+
+func (s *service) AddVenueClosure(ctx context.Context, venueID int, closure entity.VenueClosure) (*entity.AddVenueClosureResponse, error) {
+	caller, err := entity.GetCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !s.isAdmin(caller.ID) {
+		return nil, ErrForbidden
+	}
+	err = closure.Validate()
+	if err != nil {
+		return nil, err
+	}
+	venue, err := s.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.venueStorage.AddVenueClosure(ctx, venue.ID, closure)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add venue closure due: %w", err)
+	}
+	// list the meetups which can no longer be held as scheduled
+	meetups, err := s.meetupStorage.GetVenueMeetups(ctx, venue.ID, closure.StartTs, closure.EndTs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get venue meetups due: %w", err)
+	}
+	now := s.clock.Now().Unix()
+	conflicts := make([]entity.ConflictingMeetup, 0, len(meetups))
+	for _, meetup := range meetups {
+		// the meetups which are already over are not affected by the closure
+		meetup.RefreshStatus(now)
+		if !meetup.IsOngoing() {
+			continue
+		}
+		conflicts = append(conflicts, entity.ConflictingMeetup{
+			ID:        meetup.ID,
+			Name:      meetup.Name,
+			Event:     meetup.Event,
+			StartTs:   meetup.StartTs,
+			EndTs:     meetup.EndTs,
+			Organizer: meetup.Organizer,
+			Status:    meetup.Status,
+		})
+	}
+	resp := &entity.AddVenueClosureResponse{
+		Closure:            closure,
+		ConflictingMeetups: conflicts,
+	}
+	return resp, nil
+}
+
+func (s *service) isAdmin(userID int) bool {
+	for _, adminUserID := range s.adminUserIDs {
+		if adminUserID == userID {
+			return true
+		}
+	}
+	return false
 }
 
 type ServiceConfig struct {
-	VenueStorage VenueStorage `validate:"nonnil"`
+	VenueStorage  VenueStorage  `validate:"nonnil"`
+	MeetupStorage MeetupStorage `validate:"nonnil"`
+	Clock         Clock         `validate:"nonnil"`
+	// AdminUserIDs are the users who could manage the venues, when it is
+	// empty nobody could manage them
+	AdminUserIDs []int
 }
 
 func (c ServiceConfig) Validate() error {
@@ -45,7 +247,10 @@ func NewService(cfg ServiceConfig) (Service, error) {
 		return nil, err
 	}
 	s := &service{
-		venueStorage: cfg.VenueStorage,
+		venueStorage:  cfg.VenueStorage,
+		meetupStorage: cfg.MeetupStorage,
+		clock:         cfg.Clock,
+		adminUserIDs:  cfg.AdminUserIDs,
 	}
 	return s, nil
 }
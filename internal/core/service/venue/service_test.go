@@ -0,0 +1,431 @@
+package venue_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/venue"
+	"github.com/Haraj-backend/hex-monscape/internal/core/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceGetVenues(t *testing.T) {
+	// initialize service, 2024-01-13 is a saturday
+	svc := newService(t, testNow, []entity.Meetup{
+		newTestMeetup(t, 1, 1, "2024-01-13 10:00", "2024-01-13 12:00"),
+		newTestMeetup(t, 2, 2, "2024-01-13 10:00", "2024-01-13 12:00"),
+	})
+
+	// define test cases
+	testCases := []struct {
+		Name        string
+		Filter      entity.VenueFilter
+		ExpVenueIDs []int
+		ExpErr      error
+	}{
+		{
+			Name:        "No Filter",
+			Filter:      entity.VenueFilter{},
+			ExpVenueIDs: []int{1, 2},
+		},
+		{
+			Name:        "Filter By Event",
+			Filter:      entity.VenueFilter{EventID: 1},
+			ExpVenueIDs: []int{1},
+		},
+		{
+			Name:        "Filter By Unknown Event",
+			Filter:      entity.VenueFilter{EventID: 99},
+			ExpVenueIDs: nil,
+		},
+		{
+			Name:        "Filter By Event With Remaining Capacity",
+			Filter:      newTestFilter(t, 2, "2024-01-13 10:00", "2024-01-13 12:00"),
+			ExpVenueIDs: []int{1},
+		},
+		{
+			Name:        "Filter By Event Without Remaining Capacity",
+			Filter:      newTestFilter(t, 1, "2024-01-13 11:00", "2024-01-13 13:00"),
+			ExpVenueIDs: nil,
+		},
+		{
+			Name:        "Filter By Event Right After Full Time Range",
+			Filter:      newTestFilter(t, 1, "2024-01-13 12:00", "2024-01-13 14:00"),
+			ExpVenueIDs: []int{1},
+		},
+		{
+			Name:        "Filter By Time Range Without Event",
+			Filter:      newTestFilter(t, 0, "2024-01-13 10:00", "2024-01-13 12:00"),
+			ExpVenueIDs: []int{1},
+		},
+		{
+			Name:        "Filter By Open Day",
+			Filter:      newTestFilter(t, 0, "2024-01-14 10:00", "2024-01-14 12:00"),
+			ExpVenueIDs: []int{2},
+		},
+		{
+			Name:        "Filter By Open Hours",
+			Filter:      newTestFilter(t, 2, "2024-01-13 18:00", "2024-01-13 20:00"),
+			ExpVenueIDs: []int{1},
+		},
+		{
+			Name:   "Missing End Time",
+			Filter: entity.VenueFilter{MeetupStartTs: newTestTs(t, "2024-01-13 10:00")},
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+		{
+			Name:   "End Time Before Start Time",
+			Filter: newTestFilter(t, 0, "2024-01-13 12:00", "2024-01-13 10:00"),
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			venues, err := svc.GetVenues(context.Background(), testCase.Filter)
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+
+			var venueIDs []int
+			for _, v := range venues {
+				venueIDs = append(venueIDs, v.ID)
+			}
+			require.Equal(t, testCase.ExpVenueIDs, venueIDs, "mismatch venues")
+		})
+	}
+}
+
+func TestServiceGetVenue(t *testing.T) {
+	svc := newService(t, testNow, nil)
+
+	// get existing venue
+	v, err := svc.GetVenue(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, "Si Jalak Harupat", v.Name, "mismatch venue")
+
+	// get unknown venue
+	_, err = svc.GetVenue(context.Background(), 99)
+	require.Equal(t, venue.ErrVenueNotFound, err, "unexpected error")
+}
+
+func TestServiceGetVenueAvailability(t *testing.T) {
+	// initialize service, 2024-01-13 is a saturday
+	svc := newService(t, testNow, []entity.Meetup{
+		newTestMeetup(t, 1, 2, "2024-01-13 09:00", "2024-01-13 10:30"),
+		newTestMeetup(t, 1, 2, "2024-01-13 10:00", "2024-01-13 11:00"),
+		newTestMeetup(t, 1, 1, "2024-01-13 10:00", "2024-01-13 11:00"),
+	})
+
+	// define test cases
+	testCases := []struct {
+		Name     string
+		Query    entity.AvailabilityQuery
+		ExpSlots []entity.AvailabilitySlot
+		ExpErr   error
+	}{
+		{
+			Name:  "Hourly Slots",
+			Query: newTestAvailabilityQuery(t, 1, 2, "2024-01-13 07:00", "2024-01-13 12:00", 0),
+			ExpSlots: []entity.AvailabilitySlot{
+				newTestSlot(t, "2024-01-13 07:00", "2024-01-13 08:00", false, 0),
+				newTestSlot(t, "2024-01-13 08:00", "2024-01-13 09:00", true, 2),
+				newTestSlot(t, "2024-01-13 09:00", "2024-01-13 10:00", true, 1),
+				newTestSlot(t, "2024-01-13 10:00", "2024-01-13 11:00", true, 0),
+				newTestSlot(t, "2024-01-13 11:00", "2024-01-13 12:00", true, 2),
+			},
+		},
+		{
+			Name:  "Custom Granularity",
+			Query: newTestAvailabilityQuery(t, 1, 2, "2024-01-13 10:00", "2024-01-13 11:30", 1800),
+			ExpSlots: []entity.AvailabilitySlot{
+				newTestSlot(t, "2024-01-13 10:00", "2024-01-13 10:30", true, 0),
+				newTestSlot(t, "2024-01-13 10:30", "2024-01-13 11:00", true, 1),
+				newTestSlot(t, "2024-01-13 11:00", "2024-01-13 11:30", true, 2),
+			},
+		},
+		{
+			Name:  "Closed Day",
+			Query: newTestAvailabilityQuery(t, 1, 2, "2024-01-14 10:00", "2024-01-14 11:00", 0),
+			ExpSlots: []entity.AvailabilitySlot{
+				newTestSlot(t, "2024-01-14 10:00", "2024-01-14 11:00", false, 0),
+			},
+		},
+		{
+			Name:   "Venue Not Found",
+			Query:  newTestAvailabilityQuery(t, 99, 2, "2024-01-13 10:00", "2024-01-13 11:00", 0),
+			ExpErr: venue.ErrVenueNotFound,
+		},
+		{
+			Name:   "Event Not Supported",
+			Query:  newTestAvailabilityQuery(t, 2, 1, "2024-01-13 10:00", "2024-01-13 11:00", 0),
+			ExpErr: venue.ErrInvalidEvent,
+		},
+		{
+			Name:   "Invalid Time Range",
+			Query:  newTestAvailabilityQuery(t, 1, 2, "2024-01-13 11:00", "2024-01-13 10:00", 0),
+			ExpErr: entity.ErrInvalidTimeRange,
+		},
+		{
+			Name:   "Invalid Granularity",
+			Query:  newTestAvailabilityQuery(t, 1, 2, "2024-01-13 10:00", "2024-01-13 11:00", 30),
+			ExpErr: entity.ErrInvalidGranularity,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			availability, err := svc.GetVenueAvailability(context.Background(), testCase.Query)
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+			if testCase.ExpErr != nil {
+				return
+			}
+			require.Equal(t, testCase.Query.VenueID, availability.VenueID, "mismatch venue id")
+			require.Equal(t, testCase.Query.EventID, availability.EventID, "mismatch event id")
+			require.Equal(t, testCase.ExpSlots, availability.Slots, "mismatch slots")
+		})
+	}
+}
+
+func TestServiceAddVenueClosure(t *testing.T) {
+	// define test cases, 2024-01-13 is a saturday
+	testCases := []struct {
+		Name         string
+		Now          string
+		CallerID     int
+		VenueID      int
+		Closure      entity.VenueClosure
+		ExpConflicts []int
+		ExpErr       error
+	}{
+		{
+			Name:         "Closure Without Conflict",
+			CallerID:     testAdminID,
+			VenueID:      1,
+			Closure:      newTestClosure(t, "2024-01-13 13:00", "2024-01-13 15:00"),
+			ExpConflicts: []int{},
+		},
+		{
+			Name:         "Closure With Conflicts",
+			CallerID:     testAdminID,
+			VenueID:      1,
+			Closure:      newTestClosure(t, "2024-01-13 00:00", "2024-01-14 00:00"),
+			ExpConflicts: []int{1, 2},
+		},
+		{
+			Name:         "Closure Excludes Finished Meetup",
+			Now:          "2024-01-13 13:00",
+			CallerID:     testAdminID,
+			VenueID:      1,
+			Closure:      newTestClosure(t, "2024-01-13 00:00", "2024-01-14 00:00"),
+			ExpConflicts: []int{2},
+		},
+		{
+			Name:     "Not Admin",
+			CallerID: testAdminID + 1,
+			VenueID:  1,
+			Closure:  newTestClosure(t, "2024-01-13 00:00", "2024-01-14 00:00"),
+			ExpErr:   venue.ErrForbidden,
+		},
+		{
+			Name:     "Venue Not Found",
+			CallerID: testAdminID,
+			VenueID:  99,
+			Closure:  newTestClosure(t, "2024-01-13 00:00", "2024-01-14 00:00"),
+			ExpErr:   venue.ErrVenueNotFound,
+		},
+		{
+			Name:     "Invalid Time Range",
+			CallerID: testAdminID,
+			VenueID:  1,
+			Closure:  newTestClosure(t, "2024-01-14 00:00", "2024-01-13 00:00"),
+			ExpErr:   entity.ErrInvalidTimeRange,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			meetups := []entity.Meetup{
+				newTestMeetup(t, 1, 1, "2024-01-13 10:00", "2024-01-13 12:00"),
+				newTestMeetup(t, 1, 2, "2024-01-13 16:00", "2024-01-13 18:00"),
+				newTestMeetup(t, 2, 2, "2024-01-13 10:00", "2024-01-13 12:00"),
+				newTestMeetup(t, 1, 2, "2024-01-13 14:00", "2024-01-13 16:00"),
+			}
+			for i := range meetups {
+				meetups[i].ID = i + 1
+			}
+			// cancelled meetup is never conflicting
+			meetups[3].Status = entity.MeetupStatusCancelled
+			now := testNow
+			if len(testCase.Now) > 0 {
+				now = testCase.Now
+			}
+			svc := newService(t, now, meetups)
+
+			ctx := entity.NewCallerContext(context.Background(), entity.Caller{ID: testCase.CallerID})
+			resp, err := svc.AddVenueClosure(ctx, testCase.VenueID, testCase.Closure)
+			require.Equal(t, testCase.ExpErr, err, "unexpected error")
+			if testCase.ExpErr != nil {
+				return
+			}
+			require.Equal(t, testCase.Closure, resp.Closure, "mismatch closure")
+			meetupIDs := []int{}
+			for _, meetup := range resp.ConflictingMeetups {
+				meetupIDs = append(meetupIDs, meetup.ID)
+			}
+			require.Equal(t, testCase.ExpConflicts, meetupIDs, "mismatch conflicting meetups")
+
+			// the venue should be closed during the closure
+			v, err := svc.GetVenue(context.Background(), testCase.VenueID)
+			require.NoError(t, err)
+			require.Equal(t, []entity.VenueClosure{testCase.Closure}, v.Closures, "mismatch venue closures")
+			isOpen, err := v.IsOpen(testCase.Closure.StartTs, testCase.Closure.EndTs)
+			require.NoError(t, err)
+			require.False(t, isOpen, "venue should be closed")
+		})
+	}
+}
+
+const (
+	testAdminID = 1
+	testNow     = "2024-01-01 00:00"
+)
+
+func newService(t *testing.T, now string, meetups []entity.Meetup) venue.Service {
+	svc, err := venue.NewService(venue.ServiceConfig{
+		VenueStorage: &mockVenueStorage{venues: []entity.Venue{
+			{
+				ID:       1,
+				Name:     "Si Jalak Harupat",
+				OpenDays: []int{1, 2, 3, 4, 5, 6},
+				OpenAt:   "08:00",
+				ClosedAt: "22:00",
+				TimeZone: "Asia/Jakarta",
+				SupportedEvents: []entity.SupportedEvent{
+					{ID: 1, Name: "Wedding", EventCapacity: 1},
+					{ID: 2, Name: "Workshop", EventCapacity: 2},
+				},
+			},
+			{
+				ID:       2,
+				Name:     "Parahyangan Convention",
+				OpenDays: []int{0, 6},
+				OpenAt:   "09:00",
+				ClosedAt: "17:00",
+				TimeZone: "Asia/Jakarta",
+				SupportedEvents: []entity.SupportedEvent{
+					{ID: 2, Name: "Workshop", EventCapacity: 1},
+				},
+			},
+		}},
+		MeetupStorage: &mockMeetupStorage{meetups: meetups},
+		Clock:         testutil.NewFakeClock(time.Unix(int64(newTestTs(t, now)), 0)),
+		AdminUserIDs:  []int{testAdminID},
+	})
+	require.NoError(t, err)
+	return svc
+}
+
+func newTestFilter(t *testing.T, eventID int, start, end string) entity.VenueFilter {
+	return entity.VenueFilter{
+		EventID:       eventID,
+		MeetupStartTs: newTestTs(t, start),
+		MeetupEndTs:   newTestTs(t, end),
+	}
+}
+
+func newTestAvailabilityQuery(t *testing.T, venueID, eventID int, from, to string, granularity int) entity.AvailabilityQuery {
+	return entity.AvailabilityQuery{
+		VenueID:     venueID,
+		EventID:     eventID,
+		FromTs:      newTestTs(t, from),
+		ToTs:        newTestTs(t, to),
+		Granularity: granularity,
+	}
+}
+
+func newTestSlot(t *testing.T, start, end string, isOpen bool, remainingCapacity int) entity.AvailabilitySlot {
+	return entity.AvailabilitySlot{
+		StartTs:           newTestTs(t, start),
+		EndTs:             newTestTs(t, end),
+		IsOpen:            isOpen,
+		RemainingCapacity: remainingCapacity,
+	}
+}
+
+func newTestClosure(t *testing.T, start, end string) entity.VenueClosure {
+	return entity.VenueClosure{
+		StartTs: newTestTs(t, start),
+		EndTs:   newTestTs(t, end),
+		Reason:  "Maintenance",
+	}
+}
+
+func newTestMeetup(t *testing.T, venueID, eventID int, start, end string) entity.Meetup {
+	return entity.Meetup{
+		Venue:   entity.MeetupVenue{ID: venueID},
+		Event:   entity.MeetupEvent{ID: eventID},
+		StartTs: newTestTs(t, start),
+		EndTs:   newTestTs(t, end),
+		Status:  entity.MeetupStatusOpen,
+	}
+}
+
+// newTestTs returns unix timestamp for given time in Asia/Jakarta timezone
+func newTestTs(t *testing.T, value string) int {
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	require.NoError(t, err)
+	ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	require.NoError(t, err)
+	return int(ts.Unix())
+}
+
+type mockVenueStorage struct {
+	venues []entity.Venue
+}
+
+func (s *mockVenueStorage) GetVenues(ctx context.Context) ([]entity.Venue, error) {
+	return s.venues, nil
+}
+
+func (s *mockVenueStorage) GetVenue(ctx context.Context, venueID int) (*entity.Venue, error) {
+	for _, v := range s.venues {
+		if v.ID == venueID {
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *mockVenueStorage) AddVenueClosure(ctx context.Context, venueID int, closure entity.VenueClosure) error {
+	for i := range s.venues {
+		if s.venues[i].ID == venueID {
+			s.venues[i].Closures = append(s.venues[i].Closures, closure)
+		}
+	}
+	return nil
+}
+
+type mockMeetupStorage struct {
+	meetups []entity.Meetup
+}
+
+func (s *mockMeetupStorage) GetOverlappingMeetups(ctx context.Context, venueID, eventID, startTs, endTs int) ([]entity.Meetup, error) {
+	var meetups []entity.Meetup
+	for _, m := range s.meetups {
+		if m.Venue.ID == venueID && m.Event.ID == eventID && m.IsOverlapping(startTs, endTs) {
+			meetups = append(meetups, m)
+		}
+	}
+	return meetups, nil
+}
+
+func (s *mockMeetupStorage) GetVenueMeetups(ctx context.Context, venueID, startTs, endTs int) ([]entity.Meetup, error) {
+	var meetups []entity.Meetup
+	for _, m := range s.meetups {
+		if m.Venue.ID == venueID && m.IsOverlapping(startTs, endTs) {
+			meetups = append(meetups, m)
+		}
+	}
+	return meetups, nil
+}
@@ -2,6 +2,7 @@ package venue
 
 import (
 	"context"
+	"time"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 )
@@ -10,4 +11,28 @@ type VenueStorage interface {
 	// GetVenues returns list of venue available in the system.
 	// Returns nil when there is no venues available.
 	GetVenues(ctx context.Context) ([]entity.Venue, error)
+
+	// GetVenue returns venue for given venue id. Returns nil when the venue
+	// is not found.
+	GetVenue(ctx context.Context, venueID int) (*entity.Venue, error)
+
+	// AddVenueClosure appends given closure to the closures of the venue.
+	AddVenueClosure(ctx context.Context, venueID int, closure entity.VenueClosure) error
+}
+
+type MeetupStorage interface {
+	// GetOverlappingMeetups returns non-cancelled meetups of given event held in given
+	// venue whose time overlaps with given time range. Returns nil when there is no
+	// overlapping meetups.
+	GetOverlappingMeetups(ctx context.Context, venueID, eventID, startTs, endTs int) ([]entity.Meetup, error)
+
+	// GetVenueMeetups returns non-cancelled meetups of all events held in given venue
+	// whose time overlaps with given time range. Returns nil when there is no
+	// overlapping meetups.
+	GetVenueMeetups(ctx context.Context, venueID, startTs, endTs int) ([]entity.Meetup, error)
+}
+
+type Clock interface {
+	// Now returns the current time for excluding finished meetups from closure conflicts.
+	Now() time.Time
 }
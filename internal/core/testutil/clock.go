@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a controllable clock for testing time based logic without
+// sleeping, it is safe for concurrent use.
+type FakeClock struct {
+	mtx sync.Mutex
+	now time.Time
+}
+
+// Now implements Clock port of the services.
+func (c *FakeClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.now
+}
+
+// Set sets the current time of the clock.
+func (c *FakeClock) Set(now time.Time) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.now = now
+}
+
+// Advance moves the current time of the clock forward by given duration.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// NewFakeClock returns fake clock which is stopped at given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
@@ -0,0 +1,15 @@
+package clock
+
+import "time"
+
+// Clock provides the current time from the system clock.
+type Clock struct{}
+
+// Now implements Clock port of the services, the rest api & the token storage.
+func (c *Clock) Now() time.Time {
+	return time.Now()
+}
+
+func New() *Clock {
+	return &Clock{}
+}
@@ -0,0 +1,89 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gopkg.in/validator.v2"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+type KeyConfig struct {
+	// ID is the key identifier, it is written into `kid` header of the
+	// generated token and used to pick the verification key.
+	ID string `validate:"nonzero"`
+	// Algorithm is one of HS256, RS256, or EdDSA.
+	Algorithm string `validate:"nonzero"`
+	// Key is the raw secret for HS256, for RS256 & EdDSA it is the PEM-encoded
+	// private key. Verification keys for RS256 & EdDSA may also be PEM-encoded
+	// public key.
+	Key []byte `validate:"nonzero"`
+}
+
+func (c KeyConfig) Validate() error {
+	return validator.Validate(c)
+}
+
+type key struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// newKey parses given key config into key instance. When the key is meant to
+// be used for signing, the key material must contain the private key.
+func newKey(cfg KeyConfig, isSigning bool) (*key, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	k := &key{id: cfg.ID}
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		k.method = jwt.SigningMethodHS256
+		k.signKey = cfg.Key
+		k.verifyKey = cfg.Key
+	case AlgorithmRS256:
+		k.method = jwt.SigningMethodRS256
+		privKey, err := jwt.ParseRSAPrivateKeyFromPEM(cfg.Key)
+		if err == nil {
+			k.signKey = privKey
+			k.verifyKey = &privKey.PublicKey
+			break
+		}
+		if isSigning {
+			return nil, fmt.Errorf("unable to parse rsa private key due: %w", err)
+		}
+		pubKey, err := jwt.ParseRSAPublicKeyFromPEM(cfg.Key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse rsa public key due: %w", err)
+		}
+		k.verifyKey = pubKey
+	case AlgorithmEdDSA:
+		k.method = jwt.SigningMethodEdDSA
+		privKey, err := jwt.ParseEdPrivateKeyFromPEM(cfg.Key)
+		if err == nil {
+			k.signKey = privKey
+			k.verifyKey = privKey.(ed25519.PrivateKey).Public()
+			break
+		}
+		if isSigning {
+			return nil, fmt.Errorf("unable to parse ed25519 private key due: %w", err)
+		}
+		pubKey, err := jwt.ParseEdPublicKeyFromPEM(cfg.Key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ed25519 public key due: %w", err)
+		}
+		k.verifyKey = pubKey
+	default:
+		return nil, fmt.Errorf("unsupported algorithm: %v", cfg.Algorithm)
+	}
+	return k, nil
+}
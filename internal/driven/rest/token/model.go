@@ -0,0 +1,55 @@
+package token
+
+import (
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type tokenClaims struct {
+	UserID   int    `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	FamilyID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+func newTokenClaims(session entity.Session, issuer, audience string) tokenClaims {
+	claims := tokenClaims{
+		UserID:   session.ID,
+		Username: session.Username,
+		Email:    session.Email,
+		FamilyID: session.FamilyID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        session.TokenID,
+			Issuer:    issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Unix(session.IssuedAt, 0)),
+			ExpiresAt: jwt.NewNumericDate(time.Unix(session.ExpiresAt, 0)),
+		},
+	}
+	if len(audience) > 0 {
+		claims.Audience = jwt.ClaimStrings{audience}
+	}
+	return claims
+}
+
+func (c tokenClaims) toSession(accessToken string) *entity.Session {
+	session := &entity.Session{
+		ID:          c.UserID,
+		Username:    c.Username,
+		Email:       c.Email,
+		AccessToken: accessToken,
+		TokenID:     c.ID,
+		FamilyID:    c.FamilyID,
+		Issuer:      c.Issuer,
+		Audience:    c.Audience,
+	}
+	if c.IssuedAt != nil {
+		session.IssuedAt = c.IssuedAt.Unix()
+	}
+	if c.ExpiresAt != nil {
+		session.ExpiresAt = c.ExpiresAt.Unix()
+	}
+	return session
+}
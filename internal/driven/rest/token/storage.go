@@ -5,28 +5,87 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"github.com/golang-jwt/jwt/v5"
 	"gopkg.in/validator.v2"
 )
 
-var (
-	secretKey = []byte("event-maker")
-)
+type Storage struct {
+	signingKey *key
+	keys       map[string]*key
+	algorithms []string
+	issuer     string
+	audience   string
+	clock      Clock
+}
 
-type Storage struct{}
+// GenerateToken implements session.SessionStorage.
+func (s *Storage) GenerateToken(ctx context.Context, session entity.Session) (string, error) {
+	claims := newTokenClaims(session, s.issuer, s.audience)
+	token := jwt.NewWithClaims(s.signingKey.method, claims)
+	token.Header["kid"] = s.signingKey.id
+	return token.SignedString(s.signingKey.signKey)
+}
 
-// TODO: change description
-// GenerateToken implements token.SessionStorage.
-func (s *Storage) GenerateToken(ctx context.Context, userID int) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
+// ParseToken implements session.SessionStorage.
+func (s *Storage) ParseToken(ctx context.Context, accessToken string) (*entity.Session, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(s.algorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithTimeFunc(s.clock.Now),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
+	if len(s.issuer) > 0 {
+		opts = append(opts, jwt.WithIssuer(s.issuer))
+	}
+	if len(s.audience) > 0 {
+		opts = append(opts, jwt.WithAudience(s.audience))
+	}
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(accessToken, &claims, s.getVerifyKey, opts...)
+	if err != nil {
+		// token is either malformed, tampered, expired, or signed by unknown key
+		return nil, nil
+	}
+	return claims.toSession(accessToken), nil
 }
 
-type Config struct{}
+func (s *Storage) getVerifyKey(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	k, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %v", kid)
+	}
+	// make sure the token is not signed using different algorithm than the
+	// one registered for the key, otherwise attacker may use public key as
+	// hmac secret
+	if t.Method.Alg() != k.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Method.Alg())
+	}
+	return k.verifyKey, nil
+}
+
+type Config struct {
+	// SigningKey is used for signing newly generated tokens, it is also
+	// accepted for verification.
+	SigningKey KeyConfig
+	// VerificationKeys are additional keys accepted for verification, this is
+	// used to keep tokens signed by previous keys valid while rotating keys.
+	VerificationKeys []KeyConfig
+	// Issuer is written into `iss` claim, when set only tokens issued by the
+	// same issuer are accepted.
+	Issuer string
+	// Audience is written into `aud` claim, when set only tokens intended for
+	// the same audience are accepted.
+	Audience string
+	// Clock is used for checking the expiry of the tokens.
+	Clock Clock `validate:"nonnil"`
+}
+
+type Clock interface {
+	// Now returns the current time for validating the time based claims.
+	Now() time.Time
+}
 
 func (c Config) Validate() error {
 	return validator.Validate(c)
@@ -38,5 +97,39 @@ func New(cfg Config) (*Storage, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
-	return &Storage{}, nil
+	// parse signing key
+	signingKey, err := newKey(cfg.SigningKey, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+	s := &Storage{
+		signingKey: signingKey,
+		keys:       map[string]*key{},
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+		clock:      cfg.Clock,
+	}
+	s.addKey(signingKey)
+	// parse verification keys
+	for _, keyCfg := range cfg.VerificationKeys {
+		if _, ok := s.keys[keyCfg.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id: %v", keyCfg.ID)
+		}
+		k, err := newKey(keyCfg, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key %v: %w", keyCfg.ID, err)
+		}
+		s.addKey(k)
+	}
+	return s, nil
+}
+
+func (s *Storage) addKey(k *key) {
+	s.keys[k.id] = k
+	for _, alg := range s.algorithms {
+		if alg == k.method.Alg() {
+			return
+		}
+	}
+	s.algorithms = append(s.algorithms, k.method.Alg())
 }
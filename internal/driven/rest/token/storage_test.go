@@ -0,0 +1,377 @@
+package token_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/testutil"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/clock"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/rest/token"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateParseToken(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name       string
+		SigningKey token.KeyConfig
+	}{
+		{
+			Name:       "HS256",
+			SigningKey: newHMACKey("hs"),
+		},
+		{
+			Name:       "RS256",
+			SigningKey: newRSAKey(t, "rs"),
+		},
+		{
+			Name:       "EdDSA",
+			SigningKey: newEdKey(t, "ed"),
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			strg := newStorage(t, token.Config{SigningKey: testCase.SigningKey})
+
+			// generate token
+			expSession := newTestSession(time.Now().Add(time.Hour).Unix())
+			accessToken, err := strg.GenerateToken(context.Background(), expSession)
+			require.NoError(t, err)
+
+			// the token should carry the signing key id
+			parsedToken, _, err := jwt.NewParser().ParseUnverified(accessToken, jwt.MapClaims{})
+			require.NoError(t, err)
+			require.Equal(t, testCase.SigningKey.ID, parsedToken.Header["kid"], "mismatch kid")
+
+			// parse token, the session should match the generated one
+			session, err := strg.ParseToken(context.Background(), accessToken)
+			require.NoError(t, err)
+			require.NotNil(t, session, "session is nil")
+
+			expSession.AccessToken = accessToken
+			require.Equal(t, expSession, *session, "mismatch session")
+		})
+	}
+}
+
+func TestGenerateTokenClaims(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t, token.Config{
+		SigningKey: newHMACKey("hs"),
+		Issuer:     "hex-monscape",
+		Audience:   "hex-monscape-web",
+	})
+
+	// generate token
+	session := newTestSession(time.Now().Add(time.Hour).Unix())
+	accessToken, err := strg.GenerateToken(context.Background(), session)
+	require.NoError(t, err)
+
+	// decode token, it should contain documented claims
+	claims := jwt.MapClaims{}
+	_, _, err = jwt.NewParser().ParseUnverified(accessToken, claims)
+	require.NoError(t, err)
+	expClaims := jwt.MapClaims{
+		"id":       float64(session.ID),
+		"username": session.Username,
+		"email":    session.Email,
+		"jti":      session.TokenID,
+		"sid":      session.FamilyID,
+		"iat":      float64(session.IssuedAt),
+		"exp":      float64(session.ExpiresAt),
+		"iss":      "hex-monscape",
+		"aud":      []interface{}{"hex-monscape-web"},
+	}
+	require.Equal(t, expClaims, claims, "mismatch claims")
+
+	// parse token, issuer & audience should be populated
+	parsedSession, err := strg.ParseToken(context.Background(), accessToken)
+	require.NoError(t, err)
+	require.NotNil(t, parsedSession, "session is nil")
+	require.Equal(t, "hex-monscape", parsedSession.Issuer, "mismatch issuer")
+	require.Equal(t, []string{"hex-monscape-web"}, parsedSession.Audience, "mismatch audience")
+
+	// token must be rejected by storage that expects different issuer or audience
+	signingKey := newHMACKey("hs")
+	for _, cfg := range []token.Config{
+		{SigningKey: signingKey, Issuer: "other", Audience: "hex-monscape-web"},
+		{SigningKey: signingKey, Issuer: "hex-monscape", Audience: "other"},
+	} {
+		otherStrg := newStorage(t, cfg)
+		parsedSession, err := otherStrg.ParseToken(context.Background(), accessToken)
+		require.NoError(t, err)
+		require.Nil(t, parsedSession, "unexpected session")
+	}
+}
+
+func TestParseTokenAfterRotation(t *testing.T) {
+	// generate token using old key
+	oldKey := newHMACKey("old")
+	oldStrg := newStorage(t, token.Config{SigningKey: oldKey})
+	oldToken, err := oldStrg.GenerateToken(context.Background(), newTestSession(time.Now().Add(time.Hour).Unix()))
+	require.NoError(t, err)
+
+	// rotate signing key, old token is still valid since old key is
+	// registered as verification key
+	newKey := newRSAKey(t, "new")
+	strg := newStorage(t, token.Config{
+		SigningKey:       newKey,
+		VerificationKeys: []token.KeyConfig{oldKey},
+	})
+	session, err := strg.ParseToken(context.Background(), oldToken)
+	require.NoError(t, err)
+	require.NotNil(t, session, "session is nil")
+
+	// once old key is removed, old token is no longer valid
+	strg = newStorage(t, token.Config{SigningKey: newKey})
+	session, err = strg.ParseToken(context.Background(), oldToken)
+	require.NoError(t, err)
+	require.Nil(t, session, "unexpected session")
+}
+
+func TestParseInvalidToken(t *testing.T) {
+	// initialize storage
+	signingKey := newHMACKey("hs")
+	strg := newStorage(t, token.Config{SigningKey: signingKey})
+
+	// generate valid token for tampering
+	accessToken, err := strg.GenerateToken(context.Background(), newTestSession(time.Now().Add(time.Hour).Unix()))
+	require.NoError(t, err)
+
+	// generate expired token
+	expiredToken, err := strg.GenerateToken(context.Background(), newTestSession(time.Now().Add(-time.Minute).Unix()))
+	require.NoError(t, err)
+
+	// generate token signed with unknown key
+	foreignStrg := newStorage(t, token.Config{SigningKey: newHMACKey("foreign")})
+	foreignToken, err := foreignStrg.GenerateToken(context.Background(), newTestSession(time.Now().Add(time.Hour).Unix()))
+	require.NoError(t, err)
+
+	// generate token signed with different key but using known kid
+	forgedStrg := newStorage(t, token.Config{
+		SigningKey: token.KeyConfig{ID: signingKey.ID, Algorithm: token.AlgorithmHS256, Key: []byte("forged")},
+	})
+	forgedToken, err := forgedStrg.GenerateToken(context.Background(), newTestSession(time.Now().Add(time.Hour).Unix()))
+	require.NoError(t, err)
+
+	// define test cases
+	testCases := []struct {
+		Name        string
+		AccessToken string
+	}{
+		{
+			Name:        "Malformed Token",
+			AccessToken: "invalid",
+		},
+		{
+			Name:        "Tampered Token",
+			AccessToken: accessToken + "abc",
+		},
+		{
+			Name:        "Expired Token",
+			AccessToken: expiredToken,
+		},
+		{
+			Name:        "Unknown Key Token",
+			AccessToken: foreignToken,
+		},
+		{
+			Name:        "Forged Token",
+			AccessToken: forgedToken,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			session, err := strg.ParseToken(context.Background(), testCase.AccessToken)
+			require.NoError(t, err)
+			require.Nil(t, session, "unexpected session")
+		})
+	}
+}
+
+func TestParseTokenExpiredByClock(t *testing.T) {
+	// initialize storage using fake clock
+	clk := testutil.NewFakeClock(time.Now())
+	strg := newStorage(t, token.Config{SigningKey: newHMACKey("hs"), Clock: clk})
+
+	accessToken, err := strg.GenerateToken(context.Background(), newTestSession(clk.Now().Add(time.Hour).Unix()))
+	require.NoError(t, err)
+
+	session, err := strg.ParseToken(context.Background(), accessToken)
+	require.NoError(t, err)
+	require.NotNil(t, session, "session is nil")
+
+	// the token expires once the clock passes its expiry time
+	clk.Advance(2 * time.Hour)
+	session, err = strg.ParseToken(context.Background(), accessToken)
+	require.NoError(t, err)
+	require.Nil(t, session, "unexpected session")
+}
+
+func TestParseTokenAlgorithmConfusion(t *testing.T) {
+	// initialize storage that verifies using rsa public key
+	rsaKey := newRSAKey(t, "rs")
+	strg := newStorage(t, token.Config{SigningKey: rsaKey})
+
+	// sign token with hmac using the public key as secret
+	privKey, err := jwt.ParseRSAPrivateKeyFromPEM(rsaKey.Key)
+	require.NoError(t, err)
+	pubKeyBytes, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+	require.NoError(t, err)
+	pubKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKeyBytes})
+
+	forgedToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 1,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	forgedToken.Header["kid"] = rsaKey.ID
+	accessToken, err := forgedToken.SignedString(pubKeyPEM)
+	require.NoError(t, err)
+
+	// the token must be rejected
+	session, err := strg.ParseToken(context.Background(), accessToken)
+	require.NoError(t, err)
+	require.Nil(t, session, "unexpected session")
+}
+
+func TestNew(t *testing.T) {
+	rsaKey := newRSAKey(t, "rs")
+	privKey, err := jwt.ParseRSAPrivateKeyFromPEM(rsaKey.Key)
+	require.NoError(t, err)
+	pubKeyBytes, err := x509.MarshalPKIXPublicKey(&privKey.PublicKey)
+	require.NoError(t, err)
+	rsaPubKey := token.KeyConfig{
+		ID:        "rs-pub",
+		Algorithm: token.AlgorithmRS256,
+		Key:       pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubKeyBytes}),
+	}
+
+	// define test cases
+	testCases := []struct {
+		Name    string
+		Config  token.Config
+		IsError bool
+	}{
+		{
+			Name:    "Valid Config",
+			Config:  token.Config{SigningKey: newHMACKey("hs"), Clock: clock.New()},
+			IsError: false,
+		},
+		{
+			Name: "Public Key As Verification Key",
+			Config: token.Config{
+				SigningKey:       newHMACKey("hs"),
+				VerificationKeys: []token.KeyConfig{rsaPubKey},
+				Clock:            clock.New(),
+			},
+			IsError: false,
+		},
+		{
+			Name:    "Missing Signing Key",
+			Config:  token.Config{Clock: clock.New()},
+			IsError: true,
+		},
+		{
+			Name:    "Missing Clock",
+			Config:  token.Config{SigningKey: newHMACKey("hs")},
+			IsError: true,
+		},
+		{
+			Name:    "Public Key As Signing Key",
+			Config:  token.Config{SigningKey: rsaPubKey, Clock: clock.New()},
+			IsError: true,
+		},
+		{
+			Name: "Unsupported Algorithm",
+			Config: token.Config{
+				SigningKey: token.KeyConfig{ID: "hs", Algorithm: "HS512", Key: []byte("secret")},
+				Clock:      clock.New(),
+			},
+			IsError: true,
+		},
+		{
+			Name: "Duplicate Key ID",
+			Config: token.Config{
+				SigningKey:       newHMACKey("hs"),
+				VerificationKeys: []token.KeyConfig{newHMACKey("hs")},
+				Clock:            clock.New(),
+			},
+			IsError: true,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := token.New(testCase.Config)
+			require.Equal(t, testCase.IsError, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+// newStorage returns storage for given config, it uses the system clock when
+// the config has no clock.
+func newStorage(t *testing.T, cfg token.Config) *token.Storage {
+	if cfg.Clock == nil {
+		cfg.Clock = clock.New()
+	}
+	strg, err := token.New(cfg)
+	require.NoError(t, err)
+
+	return strg
+}
+
+func newTestSession(exp int64) entity.Session {
+	return entity.Session{
+		ID:        1,
+		Username:  "marion",
+		Email:     "marion@eveners.com",
+		TokenID:   uuid.NewString(),
+		FamilyID:  uuid.NewString(),
+		IssuedAt:  time.Now().Unix(),
+		ExpiresAt: exp,
+	}
+}
+
+func newHMACKey(id string) token.KeyConfig {
+	return token.KeyConfig{
+		ID:        id,
+		Algorithm: token.AlgorithmHS256,
+		Key:       []byte("secret-" + id),
+	}
+}
+
+func newRSAKey(t *testing.T, id string) token.KeyConfig {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return token.KeyConfig{
+		ID:        id,
+		Algorithm: token.AlgorithmRS256,
+		Key: pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(privKey),
+		}),
+	}
+}
+
+func newEdKey(t *testing.T, id string) token.KeyConfig {
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(privKey)
+	require.NoError(t, err)
+	return token.KeyConfig{
+		ID:        id,
+		Algorithm: token.AlgorithmEdDSA,
+		Key:       pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}),
+	}
+}
@@ -0,0 +1,22 @@
+package eventstrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+type eventRow struct {
+	ID   int    `dynamodbav:"id"`
+	Name string `dynamodbav:"name"`
+}
+
+func toEventRow(e entity.Event) eventRow {
+	return eventRow{
+		ID:   e.ID,
+		Name: e.Name,
+	}
+}
+
+func (r eventRow) toEvent() entity.Event {
+	return entity.Event{
+		ID:   r.ID,
+		Name: r.Name,
+	}
+}
@@ -0,0 +1,81 @@
+package eventstrg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"gopkg.in/validator.v2"
+)
+
+type Storage struct {
+	dynamoClient *dynamodb.DynamoDB
+	tableName    string
+}
+
+func (s *Storage) GetEvents(ctx context.Context) ([]entity.Event, error) {
+	// scan whole event table, the events are few so it is fine
+	var items []map[string]*dynamodb.AttributeValue
+	err := s.dynamoClient.ScanPagesWithContext(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(s.tableName),
+	}, func(output *dynamodb.ScanOutput, lastPage bool) bool {
+		items = append(items, output.Items...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan table %s due to: %w", s.tableName, err)
+	}
+	var rows []eventRow
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal items from %s due to: %w", s.tableName, err)
+	}
+	var events []entity.Event
+	for _, row := range rows {
+		events = append(events, row.toEvent())
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
+
+// SaveEvent is used for registering event, it is not part of the core ports
+// since the events are managed outside of the application.
+func (s *Storage) SaveEvent(ctx context.Context, event entity.Event) error {
+	item, _ := dynamodbattribute.MarshalMap(toEventRow(event))
+	_, err := s.dynamoClient.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to put item to %s due to: %w", s.tableName, err)
+	}
+	return nil
+}
+
+type Config struct {
+	DynamoClient *dynamodb.DynamoDB `validate:"nonnil"`
+	TableName    string             `validate:"nonzero"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+// New returns new instance of eventstrg dynamoDB Storage
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	strg := &Storage{
+		dynamoClient: cfg.DynamoClient,
+		tableName:    cfg.TableName,
+	}
+	return strg, nil
+}
@@ -0,0 +1,49 @@
+package eventstrg
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/shared"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveGetEvents(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save events, use random ids so the test could be executed repeatedly
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var expEvents []entity.Event
+	for i := 0; i < 3; i++ {
+		event := entity.Event{ID: rnd.Intn(1000000) + 1000, Name: fmt.Sprintf("event_%v", i)}
+		err := strg.SaveEvent(context.Background(), event)
+		require.NoError(t, err)
+		expEvents = append(expEvents, event)
+	}
+
+	// get events, the table may contain events from other tests
+	events, err := strg.GetEvents(context.Background())
+	require.NoError(t, err)
+	for _, expEvent := range expEvents {
+		require.Contains(t, events, expEvent)
+	}
+	for i := 1; i < len(events); i++ {
+		require.Less(t, events[i-1].ID, events[i].ID, "events are not sorted")
+	}
+}
+
+func newStorage(t *testing.T) *Storage {
+	s, err := New(Config{
+		DynamoClient: shared.NewLocalTestDDBClient(),
+		TableName:    os.Getenv(shared.TestConfig.EnvKeyEventTableName),
+	})
+	require.NoError(t, err)
+
+	return s
+}
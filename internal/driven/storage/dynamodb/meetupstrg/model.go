@@ -0,0 +1,192 @@
+package meetupstrg
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// the table is keyed by meetup id & sort key. Each meetup has single meetup
+// item holding the meetup along with its joined persons, and a participant
+// item for each joined person so the meetups could be looked up by their
+// participants. The counter item for assigning meetup ids is stored under
+// meetup id `0`.
+const (
+	skMeetup          = "meetup"
+	skCounter         = "counter"
+	prefixParticipant = "participant#"
+	counterMeetupID   = 0
+
+	// prefixBucket & bucketCount shard the meetup items across multiple
+	// buckets in `bucket` index so they could be listed by their start time
+	// without writing all of them into single partition
+	prefixBucket = "meetup#"
+	bucketCount  = 10
+)
+
+type itemKey struct {
+	MeetupID int    `dynamodbav:"meetup_id"`
+	SK       string `dynamodbav:"sk"`
+}
+
+func (k itemKey) toDDBKey() map[string]*dynamodb.AttributeValue {
+	item, _ := dynamodbattribute.MarshalMap(k)
+	return item
+}
+
+func newMeetupKey(meetupID int) itemKey {
+	return itemKey{MeetupID: meetupID, SK: skMeetup}
+}
+
+// newBucket returns the bucket of meetup with given id, the meetups are
+// spread evenly across the buckets since the ids are assigned incrementally.
+func newBucket(meetupID int) string {
+	return fmt.Sprintf("%v%v", prefixBucket, meetupID%bucketCount)
+}
+
+func newParticipantKey(meetupID int, userID int) itemKey {
+	return itemKey{MeetupID: meetupID, SK: fmt.Sprintf("%v%v", prefixParticipant, userID)}
+}
+
+type meetupRow struct {
+	MeetupID   int          `dynamodbav:"meetup_id"`
+	SK         string       `dynamodbav:"sk"`
+	Bucket     string       `dynamodbav:"bucket"`
+	Name       string       `dynamodbav:"name"`
+	VenueID    int          `dynamodbav:"venue_id"`
+	VenueName  string       `dynamodbav:"venue_name"`
+	EventID    int          `dynamodbav:"event_id"`
+	EventName  string       `dynamodbav:"event_name"`
+	StartTs    int          `dynamodbav:"start_ts"`
+	EndTs      int          `dynamodbav:"end_ts"`
+	MaxPersons int          `dynamodbav:"max_persons"`
+	Organizer  organizerRow `dynamodbav:"organizer"`
+	// MemberID is the organizer id, it puts the meetup item into `member_id`
+	// index along with the participant items
+	MemberID int `dynamodbav:"member_id"`
+	// JoinedPersons is keyed by the person id so the person could be added
+	// & removed atomically
+	JoinedPersons      map[string]joinedPersonRow `dynamodbav:"joined_persons"`
+	JoinedPersonsCount int                        `dynamodbav:"joined_persons_count"`
+	IsCancelled        bool                       `dynamodbav:"is_cancelled"`
+	CancelledReason    string                     `dynamodbav:"cancelled_reason"`
+	CancelledAt        int64                      `dynamodbav:"cancelled_at"`
+	CancelledBy        int                        `dynamodbav:"cancelled_by"`
+}
+
+func toMeetupRow(m entity.Meetup) meetupRow {
+	row := meetupRow{
+		MeetupID:   m.ID,
+		SK:         skMeetup,
+		Bucket:     newBucket(m.ID),
+		Name:       m.Name,
+		VenueID:    m.Venue.ID,
+		VenueName:  m.Venue.Name,
+		EventID:    m.Event.ID,
+		EventName:  m.Event.Name,
+		StartTs:    m.StartTs,
+		EndTs:      m.EndTs,
+		MaxPersons: m.MaxPersons,
+		Organizer: organizerRow{
+			ID:       m.Organizer.ID,
+			Username: m.Organizer.Username,
+			Email:    m.Organizer.Email,
+		},
+		MemberID: m.Organizer.ID,
+		// joined persons must be stored as map rather than null so the
+		// person could be set on it
+		JoinedPersons:      map[string]joinedPersonRow{},
+		JoinedPersonsCount: m.JoinedPersonsCount,
+		IsCancelled:        m.Status == entity.MeetupStatusCancelled,
+		CancelledReason:    m.CancelledReason,
+		CancelledAt:        m.CancelledAt,
+		CancelledBy:        m.CancelledBy,
+	}
+	for _, person := range m.JoinedPersons {
+		row.JoinedPersons[fmt.Sprintf("%v", person.ID)] = joinedPersonRow(person)
+	}
+	return row
+}
+
+// toMeetup converts the row into meetup, the status is either cancelled or
+// open since the other statuses are derived by `Meetup.RefreshStatus()`.
+func (r meetupRow) toMeetup() entity.Meetup {
+	status := entity.MeetupStatusOpen
+	if r.IsCancelled {
+		status = entity.MeetupStatusCancelled
+	}
+	meetup := entity.Meetup{
+		ID:   r.MeetupID,
+		Name: r.Name,
+		Venue: entity.MeetupVenue{
+			ID:   r.VenueID,
+			Name: r.VenueName,
+		},
+		Event: entity.MeetupEvent{
+			ID:   r.EventID,
+			Name: r.EventName,
+		},
+		StartTs:    r.StartTs,
+		EndTs:      r.EndTs,
+		MaxPersons: r.MaxPersons,
+		Organizer: entity.MeetupOrganizer{
+			ID:       r.Organizer.ID,
+			Username: r.Organizer.Username,
+			Email:    r.Organizer.Email,
+		},
+		JoinedPersonsCount: r.JoinedPersonsCount,
+		Status:             status,
+		CancelledReason:    r.CancelledReason,
+		CancelledAt:        r.CancelledAt,
+		CancelledBy:        r.CancelledBy,
+	}
+	for _, person := range r.JoinedPersons {
+		meetup.JoinedPersons = append(meetup.JoinedPersons, entity.JoinedPerson(person))
+	}
+	// map has no order, so the persons are sorted by their joined time
+	sort.Slice(meetup.JoinedPersons, func(i, j int) bool {
+		pi, pj := meetup.JoinedPersons[i], meetup.JoinedPersons[j]
+		if pi.JoinedAt != pj.JoinedAt {
+			return pi.JoinedAt < pj.JoinedAt
+		}
+		return pi.ID < pj.ID
+	})
+	return meetup
+}
+
+type organizerRow struct {
+	ID       int    `dynamodbav:"id"`
+	Username string `dynamodbav:"username"`
+	Email    string `dynamodbav:"email"`
+}
+
+type joinedPersonRow struct {
+	ID       int    `dynamodbav:"id"`
+	Username string `dynamodbav:"username"`
+	Email    string `dynamodbav:"email"`
+	JoinedAt int    `dynamodbav:"joined_at"`
+}
+
+// participantRow is the participant item, it puts the meetup into
+// `member_id` index of the participant. The start time follows the start
+// time of the meetup.
+type participantRow struct {
+	MeetupID int    `dynamodbav:"meetup_id"`
+	SK       string `dynamodbav:"sk"`
+	MemberID int    `dynamodbav:"member_id"`
+	StartTs  int    `dynamodbav:"start_ts"`
+	JoinedAt int    `dynamodbav:"joined_at"`
+}
+
+func newParticipantRow(meetupID int, startTs int, person entity.JoinedPerson) participantRow {
+	return participantRow{
+		MeetupID: meetupID,
+		SK:       newParticipantKey(meetupID, person.ID).SK,
+		MemberID: person.ID,
+		StartTs:  startTs,
+		JoinedAt: person.JoinedAt,
+	}
+}
@@ -0,0 +1,586 @@
+package meetupstrg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/shared"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"gopkg.in/validator.v2"
+)
+
+const (
+	// indexBucket holds all meetup items keyed by their bucket & start time,
+	// see `newBucket()`
+	indexBucket = "bucket"
+	// indexVenue holds meetup items keyed by venue id & start time
+	indexVenue = "venue_id"
+	// indexMember holds meetup items keyed by organizer id & participant
+	// items keyed by participant id, both are sorted by start time
+	indexMember = "member_id"
+
+	// maxBatchGetKeys is the maximum number of keys in single BatchGetItem
+	// request
+	maxBatchGetKeys = 100
+)
+
+type Storage struct {
+	dynamoClient *dynamodb.DynamoDB
+	tableName    string
+}
+
+func (s *Storage) GetMeetups(ctx context.Context, query entity.MeetupQuery, now int64) ([]entity.Meetup, error) {
+	cursor, err := query.GetCursor()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode cursor due: %w", err)
+	}
+	// use venue index when the venue is specified, otherwise use all buckets
+	// of bucket index, both of them are sorted by start time so the time
+	// range & the cursor could be applied on the key condition
+	indexName, keyCond, hashes := indexVenue, "venue_id = :hash", []interface{}{query.VenueID}
+	if query.VenueID == 0 {
+		indexName, keyCond, hashes = indexBucket, "bucket = :hash", nil
+		for i := 0; i < bucketCount; i++ {
+			hashes = append(hashes, newBucket(i))
+		}
+	}
+	values := map[string]interface{}{}
+	fromTs := query.FromTs
+	if cursor != nil && cursor.StartTs > fromTs {
+		fromTs = cursor.StartTs
+	}
+	switch {
+	case fromTs != 0 && query.ToTs != 0:
+		keyCond += " AND start_ts BETWEEN :from_ts AND :to_ts"
+		values[":from_ts"], values[":to_ts"] = fromTs, query.ToTs-1
+	case fromTs != 0:
+		keyCond += " AND start_ts >= :from_ts"
+		values[":from_ts"] = fromTs
+	case query.ToTs != 0:
+		keyCond += " AND start_ts < :to_ts"
+		values[":to_ts"] = query.ToTs
+	}
+	var candidates []entity.Meetup
+	for _, hash := range hashes {
+		values[":hash"] = hash
+		eav, _ := dynamodbattribute.MarshalMap(values)
+		partition, err := s.queryMeetups(ctx, &dynamodb.QueryInput{
+			TableName:                 aws.String(s.tableName),
+			IndexName:                 aws.String(indexName),
+			KeyConditionExpression:    aws.String(keyCond),
+			ExpressionAttributeValues: eav,
+		})
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, partition...)
+	}
+	// merge the meetups of all partitions, then apply the remaining filters
+	entity.SortMeetups(candidates)
+	var meetups []entity.Meetup
+	for _, meetup := range candidates {
+		if !query.IsMatch(meetup, now) || (cursor != nil && !cursor.IsAfter(meetup)) {
+			continue
+		}
+		meetup.RefreshStatus(now)
+		meetups = append(meetups, meetup)
+		if len(meetups) == query.GetLimit() {
+			break
+		}
+	}
+	return meetups, nil
+}
+
+func (s *Storage) GetParticipantMeetups(ctx context.Context, userID int, startAfterTs int) ([]entity.Meetup, error) {
+	// find meetups organized or joined by the user which start after given
+	// time from member index
+	eav, _ := dynamodbattribute.MarshalMap(map[string]interface{}{
+		":member_id":      userID,
+		":start_after_ts": startAfterTs,
+	})
+	var items []map[string]*dynamodb.AttributeValue
+	err := s.dynamoClient.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String(indexMember),
+		KeyConditionExpression:    aws.String("member_id = :member_id AND start_ts > :start_after_ts"),
+		ExpressionAttributeValues: eav,
+	}, func(output *dynamodb.QueryOutput, lastPage bool) bool {
+		items = append(items, output.Items...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query table %s due to: %w", s.tableName, err)
+	}
+	var keys []itemKey
+	isAdded := map[int]bool{}
+	for _, item := range items {
+		var key itemKey
+		err = dynamodbattribute.UnmarshalMap(item, &key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+		}
+		if isAdded[key.MeetupID] {
+			continue
+		}
+		isAdded[key.MeetupID] = true
+		keys = append(keys, newMeetupKey(key.MeetupID))
+	}
+	// fetch the meetups
+	candidates, err := s.batchGetMeetups(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	// the participant item may still hold the previous start time when the
+	// meetup is just rescheduled, so the start time is checked again
+	var meetups []entity.Meetup
+	for _, meetup := range candidates {
+		if meetup.StartTs > startAfterTs {
+			meetups = append(meetups, meetup)
+		}
+	}
+	entity.SortMeetups(meetups)
+	return meetups, nil
+}
+
+func (s *Storage) GetOverlappingMeetups(ctx context.Context, venueID, eventID, startTs, endTs int) ([]entity.Meetup, error) {
+	meetups, err := s.GetVenueMeetups(ctx, venueID, startTs, endTs)
+	if err != nil {
+		return nil, err
+	}
+	var overlaps []entity.Meetup
+	for _, meetup := range meetups {
+		if meetup.Event.ID == eventID {
+			overlaps = append(overlaps, meetup)
+		}
+	}
+	return overlaps, nil
+}
+
+func (s *Storage) GetVenueMeetups(ctx context.Context, venueID, startTs, endTs int) ([]entity.Meetup, error) {
+	// the meetup lasts for `entity.MaxMeetupDurationSecs` at most, so only
+	// the meetups starting within that duration before the start time are
+	// taken from the index, then those which already ended are filtered out
+	eav, _ := dynamodbattribute.MarshalMap(map[string]interface{}{
+		":venue_id": venueID,
+		":from_ts":  startTs - entity.MaxMeetupDurationSecs,
+		":to_ts":    endTs - 1,
+	})
+	candidates, err := s.queryMeetups(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		IndexName:                 aws.String(indexVenue),
+		KeyConditionExpression:    aws.String("venue_id = :venue_id AND start_ts BETWEEN :from_ts AND :to_ts"),
+		ExpressionAttributeValues: eav,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var meetups []entity.Meetup
+	for _, meetup := range candidates {
+		if meetup.Status == entity.MeetupStatusCancelled {
+			continue
+		}
+		if meetup.IsOverlapping(startTs, endTs) {
+			meetups = append(meetups, meetup)
+		}
+	}
+	return meetups, nil
+}
+
+func (s *Storage) SaveMeetup(ctx context.Context, meetup entity.Meetup) (int, error) {
+	id, err := shared.NextID(ctx, s.dynamoClient, s.tableName, itemKey{MeetupID: counterMeetupID, SK: skCounter}.toDDBKey())
+	if err != nil {
+		return 0, err
+	}
+	meetup.ID = id
+	item, _ := dynamodbattribute.MarshalMap(toMeetupRow(meetup))
+	_, err = s.dynamoClient.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(s.tableName),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(meetup_id)"),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to put item to %s due to: %w", s.tableName, err)
+	}
+	return id, nil
+}
+
+func (s *Storage) GetMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error) {
+	output, err := s.dynamoClient.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       newMeetupKey(meetupID).toDDBKey(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get item from %s due to: %w", s.tableName, err)
+	}
+	if len(output.Item) == 0 {
+		return nil, nil
+	}
+	var row meetupRow
+	err = dynamodbattribute.UnmarshalMap(output.Item, &row)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	meetup := row.toMeetup()
+	return &meetup, nil
+}
+
+func (s *Storage) UpdateMeetup(ctx context.Context, meetup entity.Meetup) (bool, error) {
+	eav, _ := dynamodbattribute.MarshalMap(map[string]interface{}{
+		":name":        meetup.Name,
+		":start_ts":    meetup.StartTs,
+		":end_ts":      meetup.EndTs,
+		":max_persons": meetup.MaxPersons,
+		":false":       false,
+	})
+	output, err := s.dynamoClient.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(s.tableName),
+		Key:              newMeetupKey(meetup.ID).toDDBKey(),
+		UpdateExpression: aws.String("SET #name = :name, start_ts = :start_ts, end_ts = :end_ts, max_persons = :max_persons"),
+		// the condition guarantees nobody joined in between the check & the
+		// cancelled meetup is left untouched
+		ConditionExpression: aws.String("attribute_exists(meetup_id) AND is_cancelled = :false AND joined_persons_count <= :max_persons"),
+		ExpressionAttributeNames: map[string]*string{
+			"#name": aws.String("name"),
+		},
+		ExpressionAttributeValues: eav,
+		ReturnValues:              aws.String(dynamodb.ReturnValueUpdatedOld),
+	})
+	if err != nil {
+		var conditionErr *dynamodb.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to update item on %s due to: %w", s.tableName, err)
+	}
+	var old meetupRow
+	err = dynamodbattribute.UnmarshalMap(output.Attributes, &old)
+	if err != nil {
+		return false, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	// the participant items must follow the new start time so the meetup
+	// could still be found from member index
+	if old.StartTs != meetup.StartTs {
+		err = s.syncParticipantsStartTs(ctx, meetup.ID, meetup.StartTs)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (s *Storage) CancelMeetup(ctx context.Context, m entity.Meetup) error {
+	eav, _ := dynamodbattribute.MarshalMap(map[string]interface{}{
+		":false":            false,
+		":is_cancelled":     true,
+		":cancelled_reason": m.CancelledReason,
+		":cancelled_at":     m.CancelledAt,
+		":cancelled_by":     m.CancelledBy,
+	})
+	_, err := s.dynamoClient.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(s.tableName),
+		Key:              newMeetupKey(m.ID).toDDBKey(),
+		UpdateExpression: aws.String("SET is_cancelled = :is_cancelled, cancelled_reason = :cancelled_reason, cancelled_at = :cancelled_at, cancelled_by = :cancelled_by"),
+		// the existing cancellation is never overwritten
+		ConditionExpression:       aws.String("attribute_exists(meetup_id) AND is_cancelled = :false"),
+		ExpressionAttributeValues: eav,
+	})
+	if err != nil {
+		var conditionErr *dynamodb.ConditionalCheckFailedException
+		if !errors.As(err, &conditionErr) {
+			return fmt.Errorf("unable to update item on %s due to: %w", s.tableName, err)
+		}
+		// the condition fails either when the meetup is not found or when
+		// it is already cancelled
+		stored, err := s.GetMeetup(ctx, m.ID)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return fmt.Errorf("meetup %v is not found", m.ID)
+		}
+		return meetup.ErrMeetupCancelled
+	}
+	return nil
+}
+
+func (s *Storage) JoinMeetup(ctx context.Context, meetupID int, person entity.JoinedPerson) (bool, error) {
+	// the participant item holds the start time of the meetup for member
+	// index, so the join is retried when the meetup is rescheduled in between
+	startTs, err := s.getStartTs(ctx, meetupID)
+	if err != nil || startTs == nil {
+		return false, err
+	}
+	for {
+		isJoined, err := s.joinMeetup(ctx, meetupID, *startTs, person)
+		if err != nil || isJoined {
+			return isJoined, err
+		}
+		latestStartTs, err := s.getStartTs(ctx, meetupID)
+		if err != nil || latestStartTs == nil || *latestStartTs == *startTs {
+			return false, err
+		}
+		startTs = latestStartTs
+	}
+}
+
+func (s *Storage) LeaveMeetup(ctx context.Context, meetupID int, userID int) (bool, error) {
+	// remove the person from the meetup item & delete the participant item
+	// in single transaction
+	minusOne, _ := dynamodbattribute.Marshal(-1)
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Update: &dynamodb.Update{
+					TableName:           aws.String(s.tableName),
+					Key:                 newMeetupKey(meetupID).toDDBKey(),
+					UpdateExpression:    aws.String("REMOVE joined_persons.#person_id ADD joined_persons_count :minus_one"),
+					ConditionExpression: aws.String("attribute_exists(joined_persons.#person_id)"),
+					ExpressionAttributeNames: map[string]*string{
+						"#person_id": aws.String(fmt.Sprintf("%v", userID)),
+					},
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":minus_one": minusOne,
+					},
+				},
+			},
+			{
+				Delete: &dynamodb.Delete{
+					TableName: aws.String(s.tableName),
+					Key:       newParticipantKey(meetupID, userID).toDDBKey(),
+				},
+			},
+		},
+	}
+	return s.transact(ctx, input)
+}
+
+// joinMeetup adds the person to the meetup item & puts the participant item
+// in single transaction. The conditions guarantee the meetup is not cancelled
+// nor rescheduled, the capacity & no double join.
+func (s *Storage) joinMeetup(ctx context.Context, meetupID int, startTs int, person entity.JoinedPerson) (bool, error) {
+	personValue, _ := dynamodbattribute.Marshal(joinedPersonRow(person))
+	one, _ := dynamodbattribute.Marshal(1)
+	falseValue, _ := dynamodbattribute.Marshal(false)
+	startTsValue, _ := dynamodbattribute.Marshal(startTs)
+	participantItem, _ := dynamodbattribute.MarshalMap(newParticipantRow(meetupID, startTs, person))
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Update: &dynamodb.Update{
+					TableName:           aws.String(s.tableName),
+					Key:                 newMeetupKey(meetupID).toDDBKey(),
+					UpdateExpression:    aws.String("SET joined_persons.#person_id = :person ADD joined_persons_count :one"),
+					ConditionExpression: aws.String("attribute_exists(meetup_id) AND is_cancelled = :false AND start_ts = :start_ts AND joined_persons_count < max_persons AND attribute_not_exists(joined_persons.#person_id)"),
+					ExpressionAttributeNames: map[string]*string{
+						"#person_id": aws.String(fmt.Sprintf("%v", person.ID)),
+					},
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":person":   personValue,
+						":one":      one,
+						":false":    falseValue,
+						":start_ts": startTsValue,
+					},
+				},
+			},
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(s.tableName),
+					Item:                participantItem,
+					ConditionExpression: aws.String("attribute_not_exists(meetup_id)"),
+				},
+			},
+		},
+	}
+	return s.transact(ctx, input)
+}
+
+// syncParticipantsStartTs sets given start time on the participant items of
+// the meetup. Each participant item is only updated while the meetup still
+// starts at given time, so the earlier reschedule never overrides the later
+// one.
+func (s *Storage) syncParticipantsStartTs(ctx context.Context, meetupID int, startTs int) error {
+	eav, _ := dynamodbattribute.MarshalMap(map[string]interface{}{
+		":meetup_id": meetupID,
+		":prefix":    prefixParticipant,
+	})
+	var items []map[string]*dynamodb.AttributeValue
+	err := s.dynamoClient.QueryPagesWithContext(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(s.tableName),
+		KeyConditionExpression:    aws.String("meetup_id = :meetup_id AND begins_with(sk, :prefix)"),
+		ProjectionExpression:      aws.String("meetup_id, sk"),
+		ExpressionAttributeValues: eav,
+		ConsistentRead:            aws.Bool(true),
+	}, func(output *dynamodb.QueryOutput, lastPage bool) bool {
+		items = append(items, output.Items...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("unable to query table %s due to: %w", s.tableName, err)
+	}
+	startTsValue, _ := dynamodbattribute.Marshal(startTs)
+	for _, item := range items {
+		input := &dynamodb.TransactWriteItemsInput{
+			TransactItems: []*dynamodb.TransactWriteItem{
+				{
+					ConditionCheck: &dynamodb.ConditionCheck{
+						TableName:           aws.String(s.tableName),
+						Key:                 newMeetupKey(meetupID).toDDBKey(),
+						ConditionExpression: aws.String("start_ts = :start_ts"),
+						ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+							":start_ts": startTsValue,
+						},
+					},
+				},
+				{
+					Update: &dynamodb.Update{
+						TableName:           aws.String(s.tableName),
+						Key:                 item,
+						UpdateExpression:    aws.String("SET start_ts = :start_ts"),
+						ConditionExpression: aws.String("attribute_exists(meetup_id)"),
+						ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+							":start_ts": startTsValue,
+						},
+					},
+				},
+			},
+		}
+		// the transaction is cancelled when the person already left or the
+		// meetup is rescheduled again, both are fine to be skipped
+		_, err = s.transact(ctx, input)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getStartTs returns the latest start time of the meetup. Returns nil when
+// the meetup is not found.
+func (s *Storage) getStartTs(ctx context.Context, meetupID int) (*int, error) {
+	output, err := s.dynamoClient.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:            aws.String(s.tableName),
+		Key:                  newMeetupKey(meetupID).toDDBKey(),
+		ProjectionExpression: aws.String("start_ts"),
+		ConsistentRead:       aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get item from %s due to: %w", s.tableName, err)
+	}
+	if len(output.Item) == 0 {
+		return nil, nil
+	}
+	var row meetupRow
+	err = dynamodbattribute.UnmarshalMap(output.Item, &row)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	return &row.StartTs, nil
+}
+
+// transact executes given transaction, returns false when the transaction
+// is cancelled due to its conditions.
+func (s *Storage) transact(ctx context.Context, input *dynamodb.TransactWriteItemsInput) (bool, error) {
+	_, err := s.dynamoClient.TransactWriteItemsWithContext(ctx, input)
+	if err != nil {
+		var cancelledErr *dynamodb.TransactionCanceledException
+		if errors.As(err, &cancelledErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to execute transaction on %s due to: %w", s.tableName, err)
+	}
+	return true, nil
+}
+
+// queryMeetups returns the sorted meetups from all pages of given query.
+func (s *Storage) queryMeetups(ctx context.Context, input *dynamodb.QueryInput) ([]entity.Meetup, error) {
+	var items []map[string]*dynamodb.AttributeValue
+	err := s.dynamoClient.QueryPagesWithContext(ctx, input, func(output *dynamodb.QueryOutput, lastPage bool) bool {
+		items = append(items, output.Items...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query table %s due to: %w", s.tableName, err)
+	}
+	meetups, err := s.toMeetups(items)
+	if err != nil {
+		return nil, err
+	}
+	// the index is not sorted by id, so the meetups sharing the same start
+	// time are sorted here
+	entity.SortMeetups(meetups)
+	return meetups, nil
+}
+
+// batchGetMeetups returns meetups for given keys, the meetups which are not
+// found are skipped.
+func (s *Storage) batchGetMeetups(ctx context.Context, keys []itemKey) ([]entity.Meetup, error) {
+	var items []map[string]*dynamodb.AttributeValue
+	for start := 0; start < len(keys); start += maxBatchGetKeys {
+		end := start + maxBatchGetKeys
+		if end > len(keys) {
+			end = len(keys)
+		}
+		var ddbKeys []map[string]*dynamodb.AttributeValue
+		for _, key := range keys[start:end] {
+			ddbKeys = append(ddbKeys, key.toDDBKey())
+		}
+		requestItems := map[string]*dynamodb.KeysAndAttributes{
+			s.tableName: {Keys: ddbKeys},
+		}
+		// keep requesting until all keys are processed
+		for len(requestItems) > 0 {
+			output, err := s.dynamoClient.BatchGetItemWithContext(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: requestItems,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("unable to batch get items from %s due to: %w", s.tableName, err)
+			}
+			items = append(items, output.Responses[s.tableName]...)
+			requestItems = output.UnprocessedKeys
+		}
+	}
+	return s.toMeetups(items)
+}
+
+func (s *Storage) toMeetups(items []map[string]*dynamodb.AttributeValue) ([]entity.Meetup, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	var rows []meetupRow
+	err := dynamodbattribute.UnmarshalListOfMaps(items, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal items from %s due to: %w", s.tableName, err)
+	}
+	meetups := make([]entity.Meetup, 0, len(rows))
+	for _, row := range rows {
+		meetups = append(meetups, row.toMeetup())
+	}
+	return meetups, nil
+}
+
+type Config struct {
+	DynamoClient *dynamodb.DynamoDB `validate:"nonnil"`
+	TableName    string             `validate:"nonzero"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+// New returns new instance of meetupstrg dynamoDB Storage
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	strg := &Storage{
+		dynamoClient: cfg.DynamoClient,
+		tableName:    cfg.TableName,
+	}
+	return strg, nil
+}
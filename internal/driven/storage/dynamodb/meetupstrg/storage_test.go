@@ -0,0 +1,450 @@
+package meetupstrg
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/shared"
+	"github.com/stretchr/testify/require"
+)
+
+// rnd is used for generating random venue & user ids so the tests could be
+// executed repeatedly on the same table
+var rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+func TestSaveGetMeetup(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetup
+	expMeetup := newTestMeetup(newTestVenueID(), 1000, 2000, 2)
+	id, err := strg.SaveMeetup(context.Background(), expMeetup)
+	require.NoError(t, err)
+	expMeetup.ID = id
+
+	// get meetup
+	meetup, err := strg.GetMeetup(context.Background(), expMeetup.ID)
+	require.NoError(t, err)
+	require.Equal(t, expMeetup, *meetup)
+
+	// get unknown meetup
+	meetup, err = strg.GetMeetup(context.Background(), -1)
+	require.NoError(t, err)
+	require.Nil(t, meetup)
+}
+
+func TestJoinLeaveMeetup(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetup
+	expMeetup := saveTestMeetup(t, strg, newTestMeetup(newTestVenueID(), 1000, 2000, 2))
+
+	// join meetup until it is full
+	persons := []entity.JoinedPerson{newTestPerson(100), newTestPerson(200), newTestPerson(300)}
+	ok, err := strg.JoinMeetup(context.Background(), expMeetup.ID, persons[0])
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = strg.JoinMeetup(context.Background(), expMeetup.ID, persons[0])
+	require.NoError(t, err)
+	require.False(t, ok, "person joined twice")
+
+	ok, err = strg.JoinMeetup(context.Background(), expMeetup.ID, persons[1])
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = strg.JoinMeetup(context.Background(), expMeetup.ID, persons[2])
+	require.NoError(t, err)
+	require.False(t, ok, "person joined full meetup")
+
+	expMeetup.JoinedPersons = persons[:2]
+	expMeetup.JoinedPersonsCount = 2
+	meetup, err := strg.GetMeetup(context.Background(), expMeetup.ID)
+	require.NoError(t, err)
+	require.Equal(t, expMeetup, *meetup)
+
+	// leave meetup
+	ok, err = strg.LeaveMeetup(context.Background(), expMeetup.ID, persons[0].ID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = strg.LeaveMeetup(context.Background(), expMeetup.ID, persons[0].ID)
+	require.NoError(t, err)
+	require.False(t, ok, "non participant left meetup")
+
+	expMeetup.JoinedPersons = persons[1:2]
+	expMeetup.JoinedPersonsCount = 1
+	meetup, err = strg.GetMeetup(context.Background(), expMeetup.ID)
+	require.NoError(t, err)
+	require.Equal(t, expMeetup, *meetup)
+}
+
+func TestUpdateMeetup(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetup & join it
+	expMeetup := saveTestMeetup(t, strg, newTestMeetup(newTestVenueID(), 1000, 2000, 3))
+	for i := 0; i < 2; i++ {
+		person := newTestPerson(100 * (i + 1))
+		ok, err := strg.JoinMeetup(context.Background(), expMeetup.ID, person)
+		require.NoError(t, err)
+		require.True(t, ok)
+		expMeetup.JoinedPersons = append(expMeetup.JoinedPersons, person)
+		expMeetup.JoinedPersonsCount++
+	}
+
+	// max persons below the joined persons is rejected
+	newMeetup := expMeetup
+	newMeetup.MaxPersons = 1
+	ok, err := strg.UpdateMeetup(context.Background(), newMeetup)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// update meetup
+	expMeetup.Name = "updated meetup"
+	expMeetup.StartTs = 3000
+	expMeetup.EndTs = 4000
+	expMeetup.MaxPersons = 2
+	ok, err = strg.UpdateMeetup(context.Background(), expMeetup)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	meetup, err := strg.GetMeetup(context.Background(), expMeetup.ID)
+	require.NoError(t, err)
+	require.Equal(t, expMeetup, *meetup)
+
+	// update unknown meetup
+	newMeetup.ID = -1
+	newMeetup.MaxPersons = 10
+	ok, err = strg.UpdateMeetup(context.Background(), newMeetup)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestCancelMeetup(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetups
+	venueID := newTestVenueID()
+	expMeetup := saveTestMeetup(t, strg, newTestMeetup(venueID, 1000, 2000, 2))
+	otherMeetup := saveTestMeetup(t, strg, newTestMeetup(venueID, 1500, 2500, 2))
+
+	// cancel meetup
+	cancelledMeetup := expMeetup
+	require.NoError(t, cancelledMeetup.Cancel(expMeetup.Organizer.ID, "venue is flooded", 500))
+	err := strg.CancelMeetup(context.Background(), cancelledMeetup)
+	require.NoError(t, err)
+
+	// cancelling twice is rejected & the first cancellation is kept
+	otherCancellation := cancelledMeetup
+	otherCancellation.CancelledReason = "organizer is sick"
+	err = strg.CancelMeetup(context.Background(), otherCancellation)
+	require.ErrorIs(t, err, meetup.ErrMeetupCancelled)
+
+	// cancelled meetup could not be joined nor updated
+	ok, err := strg.JoinMeetup(context.Background(), expMeetup.ID, newTestPerson(100))
+	require.NoError(t, err)
+	require.False(t, ok, "person joined cancelled meetup")
+
+	newMeetup := expMeetup
+	newMeetup.Name = "Revived Meetup"
+	ok, err = strg.UpdateMeetup(context.Background(), newMeetup)
+	require.NoError(t, err)
+	require.False(t, ok, "cancelled meetup is updated")
+
+	meetup, err := strg.GetMeetup(context.Background(), expMeetup.ID)
+	require.NoError(t, err)
+	require.Equal(t, cancelledMeetup, *meetup)
+
+	// cancelled meetup is not overlapping anymore
+	meetups, err := strg.GetOverlappingMeetups(context.Background(), venueID, expMeetup.Event.ID, 0, 3000)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Meetup{otherMeetup}, meetups)
+}
+
+func TestGetOverlappingMeetups(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetups, the last one is of other event
+	venueID := newTestVenueID()
+	meetups := []entity.Meetup{
+		saveTestMeetup(t, strg, newTestMeetup(venueID, 1000, 2000, 2)),
+		saveTestMeetup(t, strg, newTestMeetup(venueID, 2000, 3000, 2)),
+		saveTestMeetup(t, strg, newTestMeetup(venueID, 3000, 4000, 2)),
+	}
+	otherMeetup := newTestMeetup(venueID, 2000, 3000, 2)
+	otherMeetup.Event = entity.MeetupEvent{ID: 2, Name: "Exhibition"}
+	saveTestMeetup(t, strg, otherMeetup)
+
+	// meetups touching the time range are not overlapping
+	overlaps, err := strg.GetOverlappingMeetups(context.Background(), venueID, meetups[0].Event.ID, 2000, 3000)
+	require.NoError(t, err)
+	require.Equal(t, meetups[1:2], overlaps)
+
+	// no overlapping meetups
+	overlaps, err = strg.GetOverlappingMeetups(context.Background(), venueID, meetups[0].Event.ID, 5000, 6000)
+	require.NoError(t, err)
+	require.Nil(t, overlaps)
+}
+
+func TestGetMeetups(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetups: finished, closed, open & cancelled at time 2500, two of
+	// them start at the same time
+	now := int64(2500)
+	venueID := newTestVenueID()
+	finished := saveTestMeetup(t, strg, newTestMeetup(venueID, 1000, 2000, 2))
+	closed := saveTestMeetup(t, strg, newTestMeetup(venueID, 3000, 4000, 1))
+	person := newTestPerson(100)
+	ok, err := strg.JoinMeetup(context.Background(), closed.ID, person)
+	require.NoError(t, err)
+	require.True(t, ok)
+	closed.JoinedPersons = []entity.JoinedPerson{person}
+	closed.JoinedPersonsCount = 1
+	open := saveTestMeetup(t, strg, newTestMeetup(venueID, 3000, 4000, 2))
+	cancelled := saveTestMeetup(t, strg, newTestMeetup(venueID, 5000, 6000, 2))
+	require.NoError(t, cancelled.Cancel(cancelled.Organizer.ID, "organizer is sick", 100))
+	err = strg.CancelMeetup(context.Background(), cancelled)
+	require.NoError(t, err)
+
+	finished.Status = entity.MeetupStatusFinished
+	closed.Status = entity.MeetupStatusClosed
+	all := []entity.Meetup{finished, closed, open, cancelled}
+
+	// define test cases
+	testCases := []struct {
+		Name       string
+		Query      entity.MeetupQuery
+		ExpMeetups []entity.Meetup
+	}{
+		{
+			Name:       "All Meetups",
+			Query:      entity.MeetupQuery{},
+			ExpMeetups: all,
+		},
+		{
+			Name:       "Finished Meetups",
+			Query:      entity.MeetupQuery{Status: entity.MeetupStatusFinished},
+			ExpMeetups: []entity.Meetup{finished},
+		},
+		{
+			Name:       "Closed Meetups",
+			Query:      entity.MeetupQuery{Status: entity.MeetupStatusClosed},
+			ExpMeetups: []entity.Meetup{closed},
+		},
+		{
+			Name:       "Open Meetups",
+			Query:      entity.MeetupQuery{Status: entity.MeetupStatusOpen},
+			ExpMeetups: []entity.Meetup{open},
+		},
+		{
+			Name:       "Cancelled Meetups",
+			Query:      entity.MeetupQuery{Status: entity.MeetupStatusCancelled},
+			ExpMeetups: []entity.Meetup{cancelled},
+		},
+		{
+			Name:       "Time Range",
+			Query:      entity.MeetupQuery{FromTs: 3000, ToTs: 5000},
+			ExpMeetups: []entity.Meetup{closed, open},
+		},
+		{
+			Name:       "Other Event",
+			Query:      entity.MeetupQuery{EventID: finished.Event.ID + 1},
+			ExpMeetups: nil,
+		},
+		{
+			Name:       "Limit",
+			Query:      entity.MeetupQuery{Limit: 2},
+			ExpMeetups: []entity.Meetup{finished, closed},
+		},
+		{
+			Name: "Cursor At Same Start Time",
+			Query: entity.MeetupQuery{
+				Cursor: entity.NewMeetupCursor(closed).Encode(),
+			},
+			ExpMeetups: []entity.Meetup{open, cancelled},
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			// scope the query to the test venue since the storage may
+			// contain meetups from other tests
+			testCase.Query.VenueID = venueID
+			meetups, err := strg.GetMeetups(context.Background(), testCase.Query, now)
+			require.NoError(t, err)
+			require.Equal(t, testCase.ExpMeetups, meetups)
+		})
+	}
+}
+
+func TestGetMeetupsAllVenues(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save more meetups than the buckets on different venues so they are
+	// spread across all buckets, the time range is made unique since the
+	// table may contain meetups from other tests
+	fromTs := newTestVenueID() * 100000
+	var expMeetups []entity.Meetup
+	for i := 0; i < bucketCount+2; i++ {
+		meetup := newTestMeetup(newTestVenueID(), fromTs+i*1000, fromTs+i*1000+500, 2)
+		expMeetups = append(expMeetups, saveTestMeetup(t, strg, meetup))
+	}
+	// the last meetup starts at the same time as the second one
+	meetup := saveTestMeetup(t, strg, newTestMeetup(newTestVenueID(), fromTs+1000, fromTs+1500, 2))
+	expMeetups = append(expMeetups[:2], append([]entity.Meetup{meetup}, expMeetups[2:]...)...)
+
+	// read the meetups page by page using the cursor, the meetups of all
+	// buckets are merged in order
+	var meetups []entity.Meetup
+	query := entity.MeetupQuery{Limit: 1, FromTs: fromTs, ToTs: fromTs + 100000}
+	for {
+		page, err := strg.GetMeetups(context.Background(), query, 0)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		require.Len(t, page, 1)
+		meetups = append(meetups, page...)
+		query.Cursor = entity.NewMeetupCursor(page[0]).Encode()
+	}
+	require.Equal(t, expMeetups, meetups)
+}
+
+func TestGetVenueMeetups(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetups, the second one is of other event & the last one is
+	// cancelled
+	venueID := newTestVenueID()
+	first := saveTestMeetup(t, strg, newTestMeetup(venueID, 1000, 2000, 2))
+	otherMeetup := newTestMeetup(venueID, 1500, 2500, 2)
+	otherMeetup.Event = entity.MeetupEvent{ID: 2, Name: "Exhibition"}
+	otherMeetup = saveTestMeetup(t, strg, otherMeetup)
+	saveTestMeetup(t, strg, newTestMeetup(venueID, 3000, 4000, 2))
+	cancelled := saveTestMeetup(t, strg, newTestMeetup(venueID, 1000, 2000, 2))
+	require.NoError(t, cancelled.Cancel(cancelled.Organizer.ID, "venue is flooded", 500))
+	err := strg.CancelMeetup(context.Background(), cancelled)
+	require.NoError(t, err)
+
+	// meetups of all events are included, cancelled & non overlapping are
+	// excluded
+	meetups, err := strg.GetVenueMeetups(context.Background(), venueID, 1500, 3000)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Meetup{first, otherMeetup}, meetups)
+}
+
+func TestGetParticipantMeetups(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save meetups, the user organizes the first & joins the second
+	venueID := newTestVenueID()
+	organized := saveTestMeetup(t, strg, newTestMeetup(venueID, 3000, 4000, 2))
+	joined := newTestMeetup(venueID, 2000, 3000, 2)
+	joined.Organizer = newTestOrganizer()
+	joined = saveTestMeetup(t, strg, joined)
+	person := entity.JoinedPerson{
+		ID:       organized.Organizer.ID,
+		Username: organized.Organizer.Username,
+		Email:    organized.Organizer.Email,
+		JoinedAt: 100,
+	}
+	ok, err := strg.JoinMeetup(context.Background(), joined.ID, person)
+	require.NoError(t, err)
+	require.True(t, ok)
+	joined.JoinedPersons = []entity.JoinedPerson{person}
+	joined.JoinedPersonsCount = 1
+
+	// the meetup which has started is excluded
+	started := newTestMeetup(venueID, 1000, 2000, 2)
+	started.Organizer = organized.Organizer
+	saveTestMeetup(t, strg, started)
+
+	meetups, err := strg.GetParticipantMeetups(context.Background(), person.ID, 1000)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Meetup{joined, organized}, meetups)
+
+	meetups, err = strg.GetParticipantMeetups(context.Background(), person.ID, 2000)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Meetup{organized}, meetups)
+
+	// the joined meetup is still found after it is rescheduled
+	joined.StartTs = 5000
+	joined.EndTs = 6000
+	ok, err = strg.UpdateMeetup(context.Background(), joined)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	meetups, err = strg.GetParticipantMeetups(context.Background(), person.ID, 4000)
+	require.NoError(t, err)
+	require.Equal(t, []entity.Meetup{joined}, meetups)
+}
+
+func newTestVenueID() int {
+	return rnd.Intn(1000000) + 1000
+}
+
+// newTestMeetup returns new open meetup of event `1` organized by new user.
+func newTestMeetup(venueID, startTs, endTs, maxPersons int) entity.Meetup {
+	return entity.Meetup{
+		Name:       fmt.Sprintf("meetup_%v", rnd.Int()),
+		Venue:      entity.MeetupVenue{ID: venueID, Name: fmt.Sprintf("venue_%v", venueID)},
+		Event:      entity.MeetupEvent{ID: 1, Name: "Wedding"},
+		StartTs:    startTs,
+		EndTs:      endTs,
+		MaxPersons: maxPersons,
+		Organizer:  newTestOrganizer(),
+		Status:     entity.MeetupStatusOpen,
+	}
+}
+
+func saveTestMeetup(t *testing.T, strg *Storage, meetup entity.Meetup) entity.Meetup {
+	id, err := strg.SaveMeetup(context.Background(), meetup)
+	require.NoError(t, err)
+	meetup.ID = id
+	return meetup
+}
+
+func newTestOrganizer() entity.MeetupOrganizer {
+	id := rnd.Intn(1000000) + 1000
+	return entity.MeetupOrganizer{
+		ID:       id,
+		Username: fmt.Sprintf("user_%v", id),
+		Email:    fmt.Sprintf("user_%v@eveners.com", id),
+	}
+}
+
+func newTestPerson(joinedAt int) entity.JoinedPerson {
+	id := rnd.Intn(1000000) + 1000
+	return entity.JoinedPerson{
+		ID:       id,
+		Username: fmt.Sprintf("user_%v", id),
+		Email:    fmt.Sprintf("user_%v@eveners.com", id),
+		JoinedAt: joinedAt,
+	}
+}
+
+func newStorage(t *testing.T) *Storage {
+	s, err := New(Config{
+		DynamoClient: shared.NewLocalTestDDBClient(),
+		TableName:    os.Getenv(shared.TestConfig.EnvKeyMeetupTableName),
+	})
+	require.NoError(t, err)
+
+	return s
+}
@@ -0,0 +1,79 @@
+package sessionstrg
+
+import (
+	"strings"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// all items are stored in single table, the item type is distinguished by
+// the prefix of its id
+const (
+	prefixRefreshToken  = "refresh_token#"
+	prefixRevokedFamily = "revoked_family#"
+	prefixRevokedToken  = "revoked_access_token#"
+)
+
+type itemKey struct {
+	ID string `dynamodbav:"id"`
+}
+
+func (k itemKey) toDDBKey() map[string]*dynamodb.AttributeValue {
+	item, _ := dynamodbattribute.MarshalMap(k)
+	return item
+}
+
+func newRefreshTokenKey(tokenID string) itemKey {
+	return itemKey{ID: prefixRefreshToken + tokenID}
+}
+
+func newRevokedFamilyKey(familyID string) itemKey {
+	return itemKey{ID: prefixRevokedFamily + familyID}
+}
+
+func newRevokedTokenKey(tokenID string) itemKey {
+	return itemKey{ID: prefixRevokedToken + tokenID}
+}
+
+type refreshTokenRow struct {
+	ID        string `dynamodbav:"id"`
+	FamilyID  string `dynamodbav:"family_id"`
+	UserID    int    `dynamodbav:"user_id"`
+	IssuedAt  int64  `dynamodbav:"issued_at"`
+	ExpiresAt int64  `dynamodbav:"expires_at"`
+	RotatedAt int64  `dynamodbav:"rotated_at"`
+}
+
+func toRefreshTokenRow(t entity.RefreshToken) refreshTokenRow {
+	return refreshTokenRow{
+		ID:        newRefreshTokenKey(t.ID).ID,
+		FamilyID:  t.FamilyID,
+		UserID:    t.UserID,
+		IssuedAt:  t.IssuedAt,
+		ExpiresAt: t.ExpiresAt,
+		RotatedAt: t.RotatedAt,
+	}
+}
+
+func (r refreshTokenRow) toRefreshToken() *entity.RefreshToken {
+	return &entity.RefreshToken{
+		ID:        strings.TrimPrefix(r.ID, prefixRefreshToken),
+		FamilyID:  r.FamilyID,
+		UserID:    r.UserID,
+		IssuedAt:  r.IssuedAt,
+		ExpiresAt: r.ExpiresAt,
+		RotatedAt: r.RotatedAt,
+	}
+}
+
+type revokedFamilyRow struct {
+	ID        string `dynamodbav:"id"`
+	RevokedAt int64  `dynamodbav:"revoked_at"`
+}
+
+type revokedTokenRow struct {
+	ID        string `dynamodbav:"id"`
+	ExpiresAt int64  `dynamodbav:"expires_at"`
+}
@@ -0,0 +1,195 @@
+package sessionstrg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"gopkg.in/validator.v2"
+)
+
+type Storage struct {
+	dynamoClient *dynamodb.DynamoDB
+	tableName    string
+}
+
+func (s *Storage) SaveRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	item, _ := dynamodbattribute.MarshalMap(toRefreshTokenRow(token))
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      item,
+	}
+	_, err := s.dynamoClient.PutItemWithContext(ctx, input)
+	if err != nil {
+		return fmt.Errorf("unable to put item to %s due to: %w", s.tableName, err)
+	}
+	return nil
+}
+
+func (s *Storage) GetRefreshToken(ctx context.Context, tokenID string) (*entity.RefreshToken, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       newRefreshTokenKey(tokenID).toDDBKey(),
+	}
+	output, err := s.dynamoClient.GetItemWithContext(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get item from %s due to: %w", s.tableName, err)
+	}
+	if len(output.Item) == 0 {
+		return nil, nil
+	}
+	var row refreshTokenRow
+	err = dynamodbattribute.UnmarshalMap(output.Item, &row)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	return row.toRefreshToken(), nil
+}
+
+func (s *Storage) RotateRefreshToken(ctx context.Context, oldTokenID string, newToken entity.RefreshToken) (bool, error) {
+	// get old token to find its family
+	oldToken, err := s.GetRefreshToken(ctx, oldTokenID)
+	if err != nil {
+		return false, err
+	}
+	if oldToken == nil {
+		return false, nil
+	}
+	// mark old token as rotated, ensure its family is not revoked, then save
+	// new token in single transaction
+	rotatedAt, _ := dynamodbattribute.Marshal(newToken.IssuedAt)
+	zero, _ := dynamodbattribute.Marshal(0)
+	newItem, _ := dynamodbattribute.MarshalMap(toRefreshTokenRow(newToken))
+	input := &dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Update: &dynamodb.Update{
+					TableName:           aws.String(s.tableName),
+					Key:                 newRefreshTokenKey(oldTokenID).toDDBKey(),
+					UpdateExpression:    aws.String("SET rotated_at = :rotated_at"),
+					ConditionExpression: aws.String("attribute_exists(id) AND rotated_at = :zero"),
+					ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+						":rotated_at": rotatedAt,
+						":zero":       zero,
+					},
+				},
+			},
+			{
+				ConditionCheck: &dynamodb.ConditionCheck{
+					TableName:           aws.String(s.tableName),
+					Key:                 newRevokedFamilyKey(oldToken.FamilyID).toDDBKey(),
+					ConditionExpression: aws.String("attribute_not_exists(id)"),
+				},
+			},
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(s.tableName),
+					Item:                newItem,
+					ConditionExpression: aws.String("attribute_not_exists(id)"),
+				},
+			},
+		},
+	}
+	_, err = s.dynamoClient.TransactWriteItemsWithContext(ctx, input)
+	if err != nil {
+		var cancelledErr *dynamodb.TransactionCanceledException
+		if errors.As(err, &cancelledErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to execute transaction on %s due to: %w", s.tableName, err)
+	}
+	return true, nil
+}
+
+func (s *Storage) RevokeFamily(ctx context.Context, familyID string, revokedAt int64) error {
+	item, _ := dynamodbattribute.MarshalMap(revokedFamilyRow{
+		ID:        newRevokedFamilyKey(familyID).ID,
+		RevokedAt: revokedAt,
+	})
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      item,
+	}
+	_, err := s.dynamoClient.PutItemWithContext(ctx, input)
+	if err != nil {
+		return fmt.Errorf("unable to put item to %s due to: %w", s.tableName, err)
+	}
+	return nil
+}
+
+func (s *Storage) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       newRevokedFamilyKey(familyID).toDDBKey(),
+	}
+	output, err := s.dynamoClient.GetItemWithContext(ctx, input)
+	if err != nil {
+		return false, fmt.Errorf("unable to get item from %s due to: %w", s.tableName, err)
+	}
+	return len(output.Item) > 0, nil
+}
+
+func (s *Storage) RevokeAccessToken(ctx context.Context, tokenID string, exp int64) error {
+	item, _ := dynamodbattribute.MarshalMap(revokedTokenRow{
+		ID:        newRevokedTokenKey(tokenID).ID,
+		ExpiresAt: exp,
+	})
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      item,
+	}
+	_, err := s.dynamoClient.PutItemWithContext(ctx, input)
+	if err != nil {
+		return fmt.Errorf("unable to put item to %s due to: %w", s.tableName, err)
+	}
+	return nil
+}
+
+func (s *Storage) IsAccessTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       newRevokedTokenKey(tokenID).toDDBKey(),
+	}
+	output, err := s.dynamoClient.GetItemWithContext(ctx, input)
+	if err != nil {
+		return false, fmt.Errorf("unable to get item from %s due to: %w", s.tableName, err)
+	}
+	if len(output.Item) == 0 {
+		return false, nil
+	}
+	var row revokedTokenRow
+	err = dynamodbattribute.UnmarshalMap(output.Item, &row)
+	if err != nil {
+		return false, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	// dynamodb ttl may delete expired item lazily, so we need to check it
+	// by ourselves
+	return row.ExpiresAt > time.Now().Unix(), nil
+}
+
+type Config struct {
+	DynamoClient *dynamodb.DynamoDB `validate:"nonnil"`
+	TableName    string             `validate:"nonzero"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+// New returns new instance of sessionstrg dynamoDB Storage
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	strg := &Storage{
+		dynamoClient: cfg.DynamoClient,
+		tableName:    cfg.TableName,
+	}
+	return strg, nil
+}
@@ -0,0 +1,131 @@
+package sessionstrg
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveGetRefreshToken(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save token
+	expToken := newRefreshToken(uuid.NewString())
+	err := strg.SaveRefreshToken(context.Background(), expToken)
+	require.NoError(t, err)
+
+	// get token
+	token, err := strg.GetRefreshToken(context.Background(), expToken.ID)
+	require.NoError(t, err)
+	require.Equal(t, expToken, *token)
+
+	// get unknown token
+	token, err = strg.GetRefreshToken(context.Background(), uuid.NewString())
+	require.NoError(t, err)
+	require.Nil(t, token)
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save token
+	oldToken := newRefreshToken(uuid.NewString())
+	err := strg.SaveRefreshToken(context.Background(), oldToken)
+	require.NoError(t, err)
+
+	// rotate token
+	newToken := newRefreshToken(oldToken.FamilyID)
+	ok, err := strg.RotateRefreshToken(context.Background(), oldToken.ID, newToken)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// old token should be marked as rotated
+	token, err := strg.GetRefreshToken(context.Background(), oldToken.ID)
+	require.NoError(t, err)
+	require.Equal(t, newToken.IssuedAt, token.RotatedAt)
+
+	// new token should be saved
+	token, err = strg.GetRefreshToken(context.Background(), newToken.ID)
+	require.NoError(t, err)
+	require.Equal(t, newToken, *token)
+
+	// rotating old token again should fail
+	ok, err = strg.RotateRefreshToken(context.Background(), oldToken.ID, newRefreshToken(oldToken.FamilyID))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// rotating token of revoked family should fail
+	err = strg.RevokeFamily(context.Background(), oldToken.FamilyID, time.Now().Unix())
+	require.NoError(t, err)
+	ok, err = strg.RotateRefreshToken(context.Background(), newToken.ID, newRefreshToken(oldToken.FamilyID))
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestRevokeFamily(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+	familyID := uuid.NewString()
+
+	// family is not revoked yet
+	isRevoked, err := strg.IsFamilyRevoked(context.Background(), familyID)
+	require.NoError(t, err)
+	require.False(t, isRevoked)
+
+	// revoke family twice, should be idempotent
+	for i := 0; i < 2; i++ {
+		err = strg.RevokeFamily(context.Background(), familyID, time.Now().Unix())
+		require.NoError(t, err)
+	}
+	isRevoked, err = strg.IsFamilyRevoked(context.Background(), familyID)
+	require.NoError(t, err)
+	require.True(t, isRevoked)
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+	tokenID := uuid.NewString()
+
+	// token is not revoked yet
+	isRevoked, err := strg.IsAccessTokenRevoked(context.Background(), tokenID)
+	require.NoError(t, err)
+	require.False(t, isRevoked)
+
+	// revoke token
+	err = strg.RevokeAccessToken(context.Background(), tokenID, time.Now().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	isRevoked, err = strg.IsAccessTokenRevoked(context.Background(), tokenID)
+	require.NoError(t, err)
+	require.True(t, isRevoked)
+}
+
+func newRefreshToken(familyID string) entity.RefreshToken {
+	now := time.Now()
+	token, _ := entity.NewRefreshToken(entity.RefreshTokenConfig{
+		FamilyID:  familyID,
+		UserID:    1,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	})
+	return *token
+}
+
+func newStorage(t *testing.T) *Storage {
+	s, err := New(Config{
+		DynamoClient: shared.NewLocalTestDDBClient(),
+		TableName:    os.Getenv(shared.TestConfig.EnvKeySessionTableName),
+	})
+	require.NoError(t, err)
+
+	return s
+}
@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// NextID atomically increments the counter stored in the item with given key
+// and returns the incremented value. DynamoDB has no auto increment, so this
+// is used for assigning numeric ids, the first id is `1`.
+func NextID(ctx context.Context, dynamoClient *dynamodb.DynamoDB, tableName string, key map[string]*dynamodb.AttributeValue) (int, error) {
+	one, _ := dynamodbattribute.Marshal(1)
+	output, err := dynamoClient.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(tableName),
+		Key:              key,
+		UpdateExpression: aws.String("ADD last_id :one"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":one": one,
+		},
+		ReturnValues: aws.String(dynamodb.ReturnValueUpdatedNew),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to increment counter on %s due to: %w", tableName, err)
+	}
+	var counter struct {
+		LastID int `dynamodbav:"last_id"`
+	}
+	err = dynamodbattribute.UnmarshalMap(output.Attributes, &counter)
+	if err != nil {
+		return 0, fmt.Errorf("unable to unmarshal counter from %s due to: %w", tableName, err)
+	}
+	return counter.LastID, nil
+}
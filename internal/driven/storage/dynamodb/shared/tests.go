@@ -13,11 +13,21 @@ var TestConfig = struct {
 	EnvKeyBattleTableName    string
 	EnvKeyGameTableName      string
 	EnvKeyMonsterTableName   string
+	EnvKeySessionTableName   string
+	EnvKeyUserTableName      string
+	EnvKeyEventTableName     string
+	EnvKeyVenueTableName     string
+	EnvKeyMeetupTableName    string
 }{
 	EnvKeyLocalstackEndpoint: "LOCALSTACK_ENDPOINT",
 	EnvKeyBattleTableName:    "DDB_TABLE_BATTLE_NAME",
 	EnvKeyGameTableName:      "DDB_TABLE_GAME_NAME",
 	EnvKeyMonsterTableName:   "DDB_TABLE_MONSTER_NAME",
+	EnvKeySessionTableName:   "DDB_TABLE_SESSION_NAME",
+	EnvKeyUserTableName:      "DDB_TABLE_USER_NAME",
+	EnvKeyEventTableName:     "DDB_TABLE_EVENT_NAME",
+	EnvKeyVenueTableName:     "DDB_TABLE_VENUE_NAME",
+	EnvKeyMeetupTableName:    "DDB_TABLE_MEETUP_NAME",
 }
 
 func NewLocalTestDDBClient() *dynamodb.DynamoDB {
@@ -0,0 +1,76 @@
+package userstrg
+
+import (
+	"fmt"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+// all items are stored in single table, the item type is distinguished by
+// the prefix of its id. Besides the user items, the table holds marker items
+// for claiming usernames & emails since GSI couldn't enforce uniqueness, and
+// the counter item for assigning user ids.
+const (
+	prefixUser     = "user#"
+	prefixUsername = "username#"
+	prefixEmail    = "email#"
+	counterID      = "counter"
+)
+
+type itemKey struct {
+	ID string `dynamodbav:"id"`
+}
+
+func (k itemKey) toDDBKey() map[string]*dynamodb.AttributeValue {
+	item, _ := dynamodbattribute.MarshalMap(k)
+	return item
+}
+
+func newUserKey(userID int) itemKey {
+	return itemKey{ID: fmt.Sprintf("%v%v", prefixUser, userID)}
+}
+
+func newUsernameKey(username string) itemKey {
+	return itemKey{ID: prefixUsername + username}
+}
+
+func newEmailKey(email string) itemKey {
+	return itemKey{ID: prefixEmail + email}
+}
+
+// userRow is the user item, only user item has `username` & `email`
+// attributes so the GSIs on them contain user items only.
+type userRow struct {
+	ID           string `dynamodbav:"id"`
+	UserID       int    `dynamodbav:"user_id"`
+	Username     string `dynamodbav:"username"`
+	Email        string `dynamodbav:"email"`
+	PasswordHash string `dynamodbav:"password_hash"`
+}
+
+func toUserRow(u entity.User) userRow {
+	return userRow{
+		ID:           newUserKey(u.ID).ID,
+		UserID:       u.ID,
+		Username:     u.Username,
+		Email:        u.Email,
+		PasswordHash: u.PasswordHash,
+	}
+}
+
+func (r userRow) toUser() *entity.User {
+	return &entity.User{
+		ID:           r.UserID,
+		Username:     r.Username,
+		Email:        r.Email,
+		PasswordHash: r.PasswordHash,
+	}
+}
+
+// markerRow claims the username or email for the user.
+type markerRow struct {
+	ID     string `dynamodbav:"id"`
+	UserID int    `dynamodbav:"user_id"`
+}
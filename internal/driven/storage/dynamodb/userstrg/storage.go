@@ -0,0 +1,220 @@
+package userstrg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/shared"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"gopkg.in/validator.v2"
+)
+
+const (
+	indexUsername = "username"
+	indexEmail    = "email"
+)
+
+type Storage struct {
+	dynamoClient *dynamodb.DynamoDB
+	tableName    string
+}
+
+func (s *Storage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	input := &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       newUserKey(userID).toDDBKey(),
+	}
+	output, err := s.dynamoClient.GetItemWithContext(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get item from %s due to: %w", s.tableName, err)
+	}
+	if len(output.Item) == 0 {
+		return nil, nil
+	}
+	var row userRow
+	err = dynamodbattribute.UnmarshalMap(output.Item, &row)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	return row.toUser(), nil
+}
+
+func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return s.queryUser(ctx, indexUsername, username)
+}
+
+func (s *Storage) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return s.queryUser(ctx, indexEmail, email)
+}
+
+// queryUser returns user whose attribute with the same name as given index
+// equals to given value. Returns nil when the user is not found.
+func (s *Storage) queryUser(ctx context.Context, indexName string, value string) (*entity.User, error) {
+	eav, _ := dynamodbattribute.MarshalMap(map[string]interface{}{
+		":value": value,
+	})
+	output, err := s.dynamoClient.QueryWithContext(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.tableName),
+		IndexName:              aws.String(indexName),
+		KeyConditionExpression: aws.String("#attr = :value"),
+		ExpressionAttributeNames: map[string]*string{
+			"#attr": aws.String(indexName),
+		},
+		ExpressionAttributeValues: eav,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to query table %s due to: %w", s.tableName, err)
+	}
+	if len(output.Items) == 0 {
+		return nil, nil
+	}
+	var row userRow
+	err = dynamodbattribute.UnmarshalMap(output.Items[0], &row)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	return row.toUser(), nil
+}
+
+func (s *Storage) CreateUser(ctx context.Context, u entity.User) (int, error) {
+	id, err := shared.NextID(ctx, s.dynamoClient, s.tableName, itemKey{ID: counterID}.toDDBKey())
+	if err != nil {
+		return 0, err
+	}
+	u.ID = id
+	// save the user along with the claims of its username & email in single
+	// transaction, the claims fail when they are already taken
+	userItem, _ := dynamodbattribute.MarshalMap(toUserRow(u))
+	items := []*dynamodb.TransactWriteItem{
+		s.newPutIfNotExists(userItem),
+		s.newPutIfNotExists(s.newMarkerItem(newUsernameKey(u.Username), u.ID)),
+		s.newPutIfNotExists(s.newMarkerItem(newEmailKey(u.Email), u.ID)),
+	}
+	err = s.transact(ctx, items, map[int]error{
+		1: user.ErrUsernameTaken,
+		2: user.ErrEmailTaken,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, u entity.User) error {
+	oldUser, err := s.GetUserByID(ctx, u.ID)
+	if err != nil {
+		return err
+	}
+	if oldUser == nil {
+		return fmt.Errorf("user %v is not found", u.ID)
+	}
+	// the user item is only overwritten when its username & email are still
+	// the same, this is to keep the claims in sync on concurrent updates
+	userItem, _ := dynamodbattribute.MarshalMap(toUserRow(u))
+	eav, _ := dynamodbattribute.MarshalMap(map[string]interface{}{
+		":username": oldUser.Username,
+		":email":    oldUser.Email,
+	})
+	items := []*dynamodb.TransactWriteItem{
+		{
+			Put: &dynamodb.Put{
+				TableName:                 aws.String(s.tableName),
+				Item:                      userItem,
+				ConditionExpression:       aws.String("username = :username AND email = :email"),
+				ExpressionAttributeValues: eav,
+			},
+		},
+	}
+	errs := map[int]error{}
+	// move the claims when the username or email is changed
+	if u.Username != oldUser.Username {
+		errs[len(items)] = user.ErrUsernameTaken
+		items = append(items,
+			s.newPutIfNotExists(s.newMarkerItem(newUsernameKey(u.Username), u.ID)),
+			s.newDelete(newUsernameKey(oldUser.Username)),
+		)
+	}
+	if u.Email != oldUser.Email {
+		errs[len(items)] = user.ErrEmailTaken
+		items = append(items,
+			s.newPutIfNotExists(s.newMarkerItem(newEmailKey(u.Email), u.ID)),
+			s.newDelete(newEmailKey(oldUser.Email)),
+		)
+	}
+	return s.transact(ctx, items, errs)
+}
+
+// transact executes given items in single transaction. When the transaction
+// is cancelled due to failed condition of an item, the error mapped to the
+// item index is returned.
+func (s *Storage) transact(ctx context.Context, items []*dynamodb.TransactWriteItem, errs map[int]error) error {
+	_, err := s.dynamoClient.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+	if err != nil {
+		var cancelledErr *dynamodb.TransactionCanceledException
+		if errors.As(err, &cancelledErr) {
+			for i, reason := range cancelledErr.CancellationReasons {
+				if aws.StringValue(reason.Code) != "ConditionalCheckFailed" {
+					continue
+				}
+				if mappedErr, ok := errs[i]; ok {
+					return mappedErr
+				}
+			}
+		}
+		return fmt.Errorf("unable to execute transaction on %s due to: %w", s.tableName, err)
+	}
+	return nil
+}
+
+func (s *Storage) newMarkerItem(key itemKey, userID int) map[string]*dynamodb.AttributeValue {
+	item, _ := dynamodbattribute.MarshalMap(markerRow{ID: key.ID, UserID: userID})
+	return item
+}
+
+func (s *Storage) newPutIfNotExists(item map[string]*dynamodb.AttributeValue) *dynamodb.TransactWriteItem {
+	return &dynamodb.TransactWriteItem{
+		Put: &dynamodb.Put{
+			TableName:           aws.String(s.tableName),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(id)"),
+		},
+	}
+}
+
+func (s *Storage) newDelete(key itemKey) *dynamodb.TransactWriteItem {
+	return &dynamodb.TransactWriteItem{
+		Delete: &dynamodb.Delete{
+			TableName: aws.String(s.tableName),
+			Key:       key.toDDBKey(),
+		},
+	}
+}
+
+type Config struct {
+	DynamoClient *dynamodb.DynamoDB `validate:"nonnil"`
+	TableName    string             `validate:"nonzero"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+// New returns new instance of userstrg dynamoDB Storage
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	strg := &Storage{
+		dynamoClient: cfg.DynamoClient,
+		tableName:    cfg.TableName,
+	}
+	return strg, nil
+}
@@ -0,0 +1,93 @@
+package venuestrg
+
+import (
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+)
+
+type venueKey struct {
+	ID int `dynamodbav:"id"`
+}
+
+func (k venueKey) toDDBKey() map[string]*dynamodb.AttributeValue {
+	item, _ := dynamodbattribute.MarshalMap(k)
+	return item
+}
+
+// venueRow holds the venue along with its schedule & supported events in
+// single item since they are always read together.
+type venueRow struct {
+	ID              int                 `dynamodbav:"id"`
+	Name            string              `dynamodbav:"name"`
+	OpenDays        []int               `dynamodbav:"open_days"`
+	OpenAt          string              `dynamodbav:"open_at"`
+	ClosedAt        string              `dynamodbav:"closed_at"`
+	TimeZone        string              `dynamodbav:"timezone"`
+	WeekdayHours    []weekdayHoursRow   `dynamodbav:"weekday_hours"`
+	Closures        []closureRow        `dynamodbav:"closures"`
+	SupportedEvents []supportedEventRow `dynamodbav:"supported_events"`
+}
+
+func toVenueRow(v entity.Venue) venueRow {
+	row := venueRow{
+		ID:       v.ID,
+		Name:     v.Name,
+		OpenDays: v.OpenDays,
+		OpenAt:   v.OpenAt,
+		ClosedAt: v.ClosedAt,
+		TimeZone: v.TimeZone,
+		// closures must be stored as list rather than null so new closure
+		// could be appended to it
+		Closures: []closureRow{},
+	}
+	for _, hours := range v.WeekdayHours {
+		row.WeekdayHours = append(row.WeekdayHours, weekdayHoursRow(hours))
+	}
+	for _, closure := range v.Closures {
+		row.Closures = append(row.Closures, closureRow(closure))
+	}
+	for _, supportedEvent := range v.SupportedEvents {
+		row.SupportedEvents = append(row.SupportedEvents, supportedEventRow(supportedEvent))
+	}
+	return row
+}
+
+func (r venueRow) toVenue() entity.Venue {
+	venue := entity.Venue{
+		ID:       r.ID,
+		Name:     r.Name,
+		OpenAt:   r.OpenAt,
+		ClosedAt: r.ClosedAt,
+		TimeZone: r.TimeZone,
+	}
+	venue.OpenDays = append(venue.OpenDays, r.OpenDays...)
+	for _, hours := range r.WeekdayHours {
+		venue.WeekdayHours = append(venue.WeekdayHours, entity.WeekdayHours(hours))
+	}
+	for _, closure := range r.Closures {
+		venue.Closures = append(venue.Closures, entity.VenueClosure(closure))
+	}
+	for _, supportedEvent := range r.SupportedEvents {
+		venue.SupportedEvents = append(venue.SupportedEvents, entity.SupportedEvent(supportedEvent))
+	}
+	return venue
+}
+
+type weekdayHoursRow struct {
+	Day      int    `dynamodbav:"day"`
+	OpenAt   string `dynamodbav:"open_at"`
+	ClosedAt string `dynamodbav:"closed_at"`
+}
+
+type closureRow struct {
+	StartTs int    `dynamodbav:"start_ts"`
+	EndTs   int    `dynamodbav:"end_ts"`
+	Reason  string `dynamodbav:"reason"`
+}
+
+type supportedEventRow struct {
+	ID            int    `dynamodbav:"id"`
+	Name          string `dynamodbav:"name"`
+	EventCapacity int    `dynamodbav:"event_capacity"`
+}
@@ -0,0 +1,125 @@
+package venuestrg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"gopkg.in/validator.v2"
+)
+
+type Storage struct {
+	dynamoClient *dynamodb.DynamoDB
+	tableName    string
+}
+
+func (s *Storage) GetVenues(ctx context.Context) ([]entity.Venue, error) {
+	// scan whole venue table, the venues are few so it is fine
+	var items []map[string]*dynamodb.AttributeValue
+	err := s.dynamoClient.ScanPagesWithContext(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(s.tableName),
+	}, func(output *dynamodb.ScanOutput, lastPage bool) bool {
+		items = append(items, output.Items...)
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to scan table %s due to: %w", s.tableName, err)
+	}
+	var rows []venueRow
+	err = dynamodbattribute.UnmarshalListOfMaps(items, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal items from %s due to: %w", s.tableName, err)
+	}
+	var venues []entity.Venue
+	for _, row := range rows {
+		venues = append(venues, row.toVenue())
+	}
+	sort.Slice(venues, func(i, j int) bool {
+		return venues[i].ID < venues[j].ID
+	})
+	return venues, nil
+}
+
+func (s *Storage) GetVenue(ctx context.Context, venueID int) (*entity.Venue, error) {
+	output, err := s.dynamoClient.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.tableName),
+		Key:       venueKey{ID: venueID}.toDDBKey(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get item from %s due to: %w", s.tableName, err)
+	}
+	if len(output.Item) == 0 {
+		return nil, nil
+	}
+	var row venueRow
+	err = dynamodbattribute.UnmarshalMap(output.Item, &row)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal item from %s due to: %w", s.tableName, err)
+	}
+	venue := row.toVenue()
+	return &venue, nil
+}
+
+func (s *Storage) AddVenueClosure(ctx context.Context, venueID int, closure entity.VenueClosure) error {
+	closures, _ := dynamodbattribute.Marshal([]closureRow{closureRow(closure)})
+	empty, _ := dynamodbattribute.Marshal([]closureRow{})
+	_, err := s.dynamoClient.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(s.tableName),
+		Key:                 venueKey{ID: venueID}.toDDBKey(),
+		UpdateExpression:    aws.String("SET closures = list_append(if_not_exists(closures, :empty), :closures)"),
+		ConditionExpression: aws.String("attribute_exists(id)"),
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":closures": closures,
+			":empty":    empty,
+		},
+	})
+	if err != nil {
+		var conditionErr *dynamodb.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			return fmt.Errorf("venue %v is not found", venueID)
+		}
+		return fmt.Errorf("unable to update item on %s due to: %w", s.tableName, err)
+	}
+	return nil
+}
+
+// SaveVenue is used for registering venue, it is not part of the core ports
+// since the venues are managed outside of the application.
+func (s *Storage) SaveVenue(ctx context.Context, venue entity.Venue) error {
+	item, _ := dynamodbattribute.MarshalMap(toVenueRow(venue))
+	_, err := s.dynamoClient.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.tableName),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to put item to %s due to: %w", s.tableName, err)
+	}
+	return nil
+}
+
+type Config struct {
+	DynamoClient *dynamodb.DynamoDB `validate:"nonnil"`
+	TableName    string             `validate:"nonzero"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+// New returns new instance of venuestrg dynamoDB Storage
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	strg := &Storage{
+		dynamoClient: cfg.DynamoClient,
+		tableName:    cfg.TableName,
+	}
+	return strg, nil
+}
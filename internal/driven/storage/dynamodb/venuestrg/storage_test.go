@@ -0,0 +1,103 @@
+package venuestrg
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/dynamodb/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveGetVenue(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save venue
+	expVenue := newTestVenue()
+	err := strg.SaveVenue(context.Background(), expVenue)
+	require.NoError(t, err)
+
+	// get venue
+	venue, err := strg.GetVenue(context.Background(), expVenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, expVenue, *venue)
+
+	// get venues
+	venues, err := strg.GetVenues(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, venues, expVenue)
+
+	// get unknown venue
+	venue, err = strg.GetVenue(context.Background(), -1)
+	require.NoError(t, err)
+	require.Nil(t, venue)
+}
+
+func TestAddVenueClosure(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// save venue without closures
+	expVenue := newTestVenue()
+	expVenue.Closures = nil
+	err := strg.SaveVenue(context.Background(), expVenue)
+	require.NoError(t, err)
+
+	// add closures
+	for i := 1; i <= 2; i++ {
+		closure := entity.VenueClosure{
+			StartTs: i * 1000,
+			EndTs:   i*1000 + 500,
+			Reason:  "renovation",
+		}
+		err = strg.AddVenueClosure(context.Background(), expVenue.ID, closure)
+		require.NoError(t, err)
+		expVenue.Closures = append(expVenue.Closures, closure)
+	}
+
+	venue, err := strg.GetVenue(context.Background(), expVenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, expVenue, *venue)
+
+	// add closure to unknown venue
+	err = strg.AddVenueClosure(context.Background(), -1, expVenue.Closures[0])
+	require.Error(t, err)
+}
+
+func newTestVenue() entity.Venue {
+	// use random id so the test could be executed repeatedly
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return entity.Venue{
+		ID:       rnd.Intn(1000000) + 1000,
+		Name:     "venue_" + uuid.NewString(),
+		OpenDays: []int{0, 1, 2, 3, 4},
+		OpenAt:   "08:00",
+		ClosedAt: "22:00",
+		TimeZone: "Asia/Jakarta",
+		WeekdayHours: []entity.WeekdayHours{
+			{Day: 4, OpenAt: "13:00", ClosedAt: "22:00"},
+		},
+		Closures: []entity.VenueClosure{
+			{StartTs: 5000, EndTs: 6000, Reason: "public holiday"},
+		},
+		SupportedEvents: []entity.SupportedEvent{
+			{ID: 1, Name: "Wedding", EventCapacity: 1},
+			{ID: 2, Name: "Exhibition", EventCapacity: 2},
+		},
+	}
+}
+
+func newStorage(t *testing.T) *Storage {
+	s, err := New(Config{
+		DynamoClient: shared.NewLocalTestDDBClient(),
+		TableName:    os.Getenv(shared.TestConfig.EnvKeyVenueTableName),
+	})
+	require.NoError(t, err)
+
+	return s
+}
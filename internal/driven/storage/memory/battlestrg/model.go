@@ -0,0 +1,84 @@
+package battlestrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+// battleRow is the battle in the snapshot.
+type battleRow struct {
+	GameID     string        `json:"game_id"`
+	State      string        `json:"state"`
+	Partner    *monsterRow   `json:"partner"`
+	Enemy      *monsterRow   `json:"enemy"`
+	LastDamage lastDamageRow `json:"last_damage"`
+}
+
+func toBattleRow(b entity.Battle) battleRow {
+	row := battleRow{
+		GameID:     b.GameID,
+		State:      string(b.State),
+		LastDamage: lastDamageRow(b.LastDamage),
+	}
+	if b.Partner != nil {
+		partner := toMonsterRow(*b.Partner)
+		row.Partner = &partner
+	}
+	if b.Enemy != nil {
+		enemy := toMonsterRow(*b.Enemy)
+		row.Enemy = &enemy
+	}
+	return row
+}
+
+func (r battleRow) toBattle() entity.Battle {
+	battle := entity.Battle{
+		GameID:     r.GameID,
+		State:      entity.State(r.State),
+		LastDamage: entity.LastDamage(r.LastDamage),
+	}
+	if r.Partner != nil {
+		partner := r.Partner.toMonster()
+		battle.Partner = &partner
+	}
+	if r.Enemy != nil {
+		enemy := r.Enemy.toMonster()
+		battle.Enemy = &enemy
+	}
+	return battle
+}
+
+type monsterRow struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	BattleStats battleStatsRow `json:"battle_stats"`
+	AvatarURL   string         `json:"avatar_url"`
+}
+
+func toMonsterRow(m entity.Monster) monsterRow {
+	return monsterRow{
+		ID:          m.ID,
+		Name:        m.Name,
+		BattleStats: battleStatsRow(m.BattleStats),
+		AvatarURL:   m.AvatarURL,
+	}
+}
+
+func (r monsterRow) toMonster() entity.Monster {
+	return entity.Monster{
+		ID:          r.ID,
+		Name:        r.Name,
+		BattleStats: entity.BattleStats(r.BattleStats),
+		AvatarURL:   r.AvatarURL,
+	}
+}
+
+type battleStatsRow struct {
+	Health    int `json:"health"`
+	MaxHealth int `json:"max_health"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	Speed     int `json:"speed"`
+}
+
+type lastDamageRow struct {
+	Partner int `json:"partner"`
+	Enemy   int `json:"enemy"`
+}
@@ -2,29 +2,88 @@ package battlestrg
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 )
 
 type Storage struct {
+	mtx  sync.RWMutex
 	data map[string]entity.Battle
 }
 
 func (s *Storage) GetBattle(ctx context.Context, gameID string) (*entity.Battle, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
 	b, exist := s.data[gameID]
 	if !exist {
 		// if item is not found, returns nil as expected by battle interface
 		return nil, nil
 	}
+	b = copyBattle(b)
 
 	return &b, nil
 }
 
 func (s *Storage) SaveBattle(ctx context.Context, b entity.Battle) error {
-	s.data[b.GameID] = b
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data[b.GameID] = copyBattle(b)
 	return nil
 }
 
+// MarshalSnapshot implements snapshot.Source.
+func (s *Storage) MarshalSnapshot() ([]byte, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	rows := []battleRow{}
+	for _, battle := range s.data {
+		rows = append(rows, toBattleRow(battle))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].GameID < rows[j].GameID
+	})
+	return json.Marshal(rows)
+}
+
+// UnmarshalSnapshot implements snapshot.Source.
+func (s *Storage) UnmarshalSnapshot(data []byte) error {
+	var rows []battleRow
+	err := json.Unmarshal(data, &rows)
+	if err != nil {
+		return fmt.Errorf("unable to parse battles due: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data = make(map[string]entity.Battle)
+	for _, row := range rows {
+		s.data[row.GameID] = row.toBattle()
+	}
+	return nil
+}
+
+// copyBattle returns a copy of given battle which shares no monsters with it,
+// so the stored battle could not be modified through the returned pointers.
+func copyBattle(b entity.Battle) entity.Battle {
+	if b.Partner != nil {
+		partner := *b.Partner
+		b.Partner = &partner
+	}
+	if b.Enemy != nil {
+		enemy := *b.Enemy
+		b.Enemy = &enemy
+	}
+	return b
+}
+
 func New() *Storage {
 	return &Storage{data: make(map[string]entity.Battle)}
 }
@@ -2,6 +2,7 @@ package battlestrg_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
@@ -33,6 +34,71 @@ func TestSaveGetBattle(t *testing.T) {
 	require.Equal(t, expBattle, battle, "unexpected battle")
 }
 
+func TestBattleIsolation(t *testing.T) {
+	// init storage & save battle
+	strg := battlestrg.New()
+	expBattle := newBattle()
+	err := strg.SaveBattle(context.Background(), *expBattle)
+	require.NoError(t, err)
+
+	// modify the monsters of saved & returned battle
+	expBattle.Partner.BattleStats.Health = 0
+	battle, err := strg.GetBattle(context.Background(), expBattle.GameID)
+	require.NoError(t, err)
+	battle.Enemy.BattleStats.Health = 0
+
+	// the stored monsters should not be affected
+	battle, err = strg.GetBattle(context.Background(), expBattle.GameID)
+	require.NoError(t, err)
+	require.NotZero(t, battle.Partner.BattleStats.Health, "stored partner is modified")
+	require.NotZero(t, battle.Enemy.BattleStats.Health, "stored enemy is modified")
+}
+
+func TestSaveGetBattleConcurrently(t *testing.T) {
+	// init storage & save battle
+	strg := battlestrg.New()
+	expBattle := newBattle()
+	err := strg.SaveBattle(context.Background(), *expBattle)
+	require.NoError(t, err)
+
+	// attack & save the battle while other goroutines read it
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			battle, err := strg.GetBattle(context.Background(), expBattle.GameID)
+			require.NoError(t, err)
+			battle.State = entity.StatePartnerTurn
+			err = battle.PartnerAttack()
+			require.NoError(t, err)
+			err = strg.SaveBattle(context.Background(), *battle)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSnapshot(t *testing.T) {
+	// init storage & save battle
+	strg := battlestrg.New()
+	expBattle := newBattle()
+	expBattle.LastDamage = entity.LastDamage{Partner: 10, Enemy: 25}
+	err := strg.SaveBattle(context.Background(), *expBattle)
+	require.NoError(t, err)
+
+	// restore the snapshot into new storage
+	data, err := strg.MarshalSnapshot()
+	require.NoError(t, err)
+	restored := battlestrg.New()
+	err = restored.UnmarshalSnapshot(data)
+	require.NoError(t, err)
+
+	battle, err := restored.GetBattle(context.Background(), expBattle.GameID)
+	require.NoError(t, err)
+	require.Equal(t, expBattle, battle, "unexpected battle")
+}
+
 func newBattle() *entity.Battle {
 	game, _ := entity.NewBattle(entity.BattleConfig{
 		GameID:  uuid.NewString(),
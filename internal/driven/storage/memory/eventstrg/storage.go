@@ -9,6 +9,8 @@ import (
 	"gopkg.in/validator.v2"
 )
 
+// Storage is safe for concurrent use without locking since its data is never
+// modified after New().
 type Storage struct {
 	data map[int]entity.Event
 }
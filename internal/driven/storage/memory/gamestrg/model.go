@@ -0,0 +1,76 @@
+package gamestrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+// gameRow is the game in the snapshot.
+type gameRow struct {
+	ID         string      `json:"id"`
+	PlayerName string      `json:"player_name"`
+	Partner    *monsterRow `json:"partner"`
+	CreatedAt  int64       `json:"created_at"`
+	BattleWon  int         `json:"battle_won"`
+	Scenario   string      `json:"scenario"`
+}
+
+func toGameRow(g entity.Game) gameRow {
+	row := gameRow{
+		ID:         g.ID,
+		PlayerName: g.PlayerName,
+		CreatedAt:  g.CreatedAt,
+		BattleWon:  g.BattleWon,
+		Scenario:   string(g.Scenario),
+	}
+	if g.Partner != nil {
+		partner := toMonsterRow(*g.Partner)
+		row.Partner = &partner
+	}
+	return row
+}
+
+func (r gameRow) toGame() entity.Game {
+	game := entity.Game{
+		ID:         r.ID,
+		PlayerName: r.PlayerName,
+		CreatedAt:  r.CreatedAt,
+		BattleWon:  r.BattleWon,
+		Scenario:   entity.Scenario(r.Scenario),
+	}
+	if r.Partner != nil {
+		partner := r.Partner.toMonster()
+		game.Partner = &partner
+	}
+	return game
+}
+
+type monsterRow struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	BattleStats battleStatsRow `json:"battle_stats"`
+	AvatarURL   string         `json:"avatar_url"`
+}
+
+func toMonsterRow(m entity.Monster) monsterRow {
+	return monsterRow{
+		ID:          m.ID,
+		Name:        m.Name,
+		BattleStats: battleStatsRow(m.BattleStats),
+		AvatarURL:   m.AvatarURL,
+	}
+}
+
+func (r monsterRow) toMonster() entity.Monster {
+	return entity.Monster{
+		ID:          r.ID,
+		Name:        r.Name,
+		BattleStats: entity.BattleStats(r.BattleStats),
+		AvatarURL:   r.AvatarURL,
+	}
+}
+
+type battleStatsRow struct {
+	Health    int `json:"health"`
+	MaxHealth int `json:"max_health"`
+	Attack    int `json:"attack"`
+	Defense   int `json:"defense"`
+	Speed     int `json:"speed"`
+}
@@ -2,29 +2,84 @@ package gamestrg
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 )
 
 type Storage struct {
+	mtx  sync.RWMutex
 	data map[string]entity.Game
 }
 
 func (s *Storage) GetGame(ctx context.Context, gameID string) (*entity.Game, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
 	g, exist := s.data[gameID]
 	if !exist {
 		// if item is not found, returns nil as expected by game interface
 		return nil, nil
 	}
+	g = copyGame(g)
 
 	return &g, nil
 }
 
 func (s *Storage) SaveGame(ctx context.Context, game entity.Game) error {
-	s.data[game.ID] = game
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data[game.ID] = copyGame(game)
+	return nil
+}
+
+// MarshalSnapshot implements snapshot.Source.
+func (s *Storage) MarshalSnapshot() ([]byte, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	rows := []gameRow{}
+	for _, game := range s.data {
+		rows = append(rows, toGameRow(game))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].ID < rows[j].ID
+	})
+	return json.Marshal(rows)
+}
+
+// UnmarshalSnapshot implements snapshot.Source.
+func (s *Storage) UnmarshalSnapshot(data []byte) error {
+	var rows []gameRow
+	err := json.Unmarshal(data, &rows)
+	if err != nil {
+		return fmt.Errorf("unable to parse games due: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data = make(map[string]entity.Game)
+	for _, row := range rows {
+		s.data[row.ID] = row.toGame()
+	}
 	return nil
 }
 
+// copyGame returns a copy of given game which shares no partner with it, so
+// the stored game could not be modified through the returned pointers.
+func copyGame(g entity.Game) entity.Game {
+	if g.Partner != nil {
+		partner := *g.Partner
+		g.Partner = &partner
+	}
+	return g
+}
+
 func New() *Storage {
 	return &Storage{data: make(map[string]entity.Game)}
 }
@@ -2,6 +2,7 @@ package gamestrg_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,6 +34,72 @@ func TestSaveGetGame(t *testing.T) {
 	require.Equal(t, expGame, game, "unexpected game")
 }
 
+func TestGameIsolation(t *testing.T) {
+	// init storage & save game
+	strg := gamestrg.New()
+	expGame := initNewGame()
+	expPartner := *expGame.Partner
+	err := strg.SaveGame(context.Background(), *expGame)
+	require.NoError(t, err)
+
+	// modify the partner of saved & returned game
+	expGame.Partner.BattleStats.Health = 0
+	game, err := strg.GetGame(context.Background(), expGame.ID)
+	require.NoError(t, err)
+	game.Partner.BattleStats.Attack = 0
+
+	// the stored partner should not be affected
+	game, err = strg.GetGame(context.Background(), expGame.ID)
+	require.NoError(t, err)
+	require.Equal(t, expPartner, *game.Partner, "stored partner is modified")
+}
+
+func TestSaveGetGameConcurrently(t *testing.T) {
+	// init storage & save game
+	strg := gamestrg.New()
+	expGame := initNewGame()
+	err := strg.SaveGame(context.Background(), *expGame)
+	require.NoError(t, err)
+
+	// advance & save the game while other goroutines read it, also save
+	// other games to grow the map concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			game, err := strg.GetGame(context.Background(), expGame.ID)
+			require.NoError(t, err)
+			game.IncBattleWon()
+			game.Partner.ResetBattleStats()
+			err = strg.SaveGame(context.Background(), *game)
+			require.NoError(t, err)
+			err = strg.SaveGame(context.Background(), *initNewGame())
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSnapshot(t *testing.T) {
+	// init storage & save game
+	strg := gamestrg.New()
+	expGame := initNewGame()
+	err := strg.SaveGame(context.Background(), *expGame)
+	require.NoError(t, err)
+
+	// restore the snapshot into new storage
+	data, err := strg.MarshalSnapshot()
+	require.NoError(t, err)
+	restored := gamestrg.New()
+	err = restored.UnmarshalSnapshot(data)
+	require.NoError(t, err)
+
+	game, err := restored.GetGame(context.Background(), expGame.ID)
+	require.NoError(t, err)
+	require.Equal(t, expGame, game, "unexpected game")
+}
+
 func initNewGame() *entity.Game {
 	currentTs := time.Now().Unix()
 	game, _ := entity.NewGame(entity.GameConfig{
@@ -0,0 +1,76 @@
+package loginstrg
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+)
+
+// sweepIntervalSecs is the minimum interval between removals of expired
+// records, this is to prevent the storage from growing indefinitely while
+// keeping the writes cheap.
+const sweepIntervalSecs = 60
+
+type Storage struct {
+	mtx         sync.Mutex
+	attempts    map[string]entity.LoginAttempt
+	lastSweepAt int64
+}
+
+// GetLoginAttempt implements session.LoginAttemptStorage.
+func (s *Storage) GetLoginAttempt(ctx context.Context, key string, now int64) (*entity.LoginAttempt, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	attempt, ok := s.attempts[key]
+	if !ok || attempt.IsExpired(now) {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+// AddLoginFailure implements session.LoginAttemptStorage.
+func (s *Storage) AddLoginFailure(ctx context.Context, key string, failedAt int64, expiresAt int64) (*entity.LoginAttempt, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.sweep(failedAt)
+
+	attempt, ok := s.attempts[key]
+	if !ok || attempt.IsExpired(failedAt) {
+		attempt = entity.LoginAttempt{Key: key}
+	}
+	attempt.Failures++
+	attempt.LastFailedAt = failedAt
+	attempt.ExpiresAt = expiresAt
+	s.attempts[key] = attempt
+
+	return &attempt, nil
+}
+
+// DeleteLoginAttempt implements session.LoginAttemptStorage.
+func (s *Storage) DeleteLoginAttempt(ctx context.Context, key string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
+
+// sweep removes expired records, the caller must hold the lock.
+func (s *Storage) sweep(now int64) {
+	if now-s.lastSweepAt < sweepIntervalSecs {
+		return
+	}
+	for key, attempt := range s.attempts {
+		if attempt.IsExpired(now) {
+			delete(s.attempts, key)
+		}
+	}
+	s.lastSweepAt = now
+}
+
+func New() *Storage {
+	return &Storage{attempts: map[string]entity.LoginAttempt{}}
+}
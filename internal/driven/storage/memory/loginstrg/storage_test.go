@@ -0,0 +1,63 @@
+package loginstrg_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/loginstrg"
+	"github.com/stretchr/testify/require"
+)
+
+// the timestamps are far from the wall clock, so the tests fail when the
+// storage uses the wall clock instead of given time
+const testNow = int64(1000)
+
+func TestAddLoginFailure(t *testing.T) {
+	strg := loginstrg.New()
+	now := testNow
+
+	// unknown key
+	attempt, err := strg.GetLoginAttempt(context.Background(), "username#marion", now)
+	require.NoError(t, err)
+	require.Nil(t, attempt)
+
+	// add failures
+	for i := 1; i <= 3; i++ {
+		attempt, err = strg.AddLoginFailure(context.Background(), "username#marion", now, now+60)
+		require.NoError(t, err)
+		require.Equal(t, i, attempt.Failures, "unexpected failures")
+	}
+	attempt, err = strg.GetLoginAttempt(context.Background(), "username#marion", now)
+	require.NoError(t, err)
+	require.Equal(t, 3, attempt.Failures, "unexpected failures")
+	require.Equal(t, now, attempt.LastFailedAt, "unexpected last failed at")
+
+	// failure after the record expired should start from one again
+	attempt, err = strg.AddLoginFailure(context.Background(), "username#marion", now+60, now+120)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempt.Failures, "failures is not reset")
+
+	// delete the record
+	err = strg.DeleteLoginAttempt(context.Background(), "username#marion")
+	require.NoError(t, err)
+	attempt, err = strg.GetLoginAttempt(context.Background(), "username#marion", now)
+	require.NoError(t, err)
+	require.Nil(t, attempt)
+}
+
+func TestGetExpiredLoginAttempt(t *testing.T) {
+	strg := loginstrg.New()
+	now := testNow
+
+	_, err := strg.AddLoginFailure(context.Background(), "ip#127.0.0.1", now, now+60)
+	require.NoError(t, err)
+
+	// the record is still returned right before it expires
+	attempt, err := strg.GetLoginAttempt(context.Background(), "ip#127.0.0.1", now+59)
+	require.NoError(t, err)
+	require.NotNil(t, attempt, "record is not returned")
+
+	attempt, err = strg.GetLoginAttempt(context.Background(), "ip#127.0.0.1", now+60)
+	require.NoError(t, err)
+	require.Nil(t, attempt, "expired record is returned")
+}
@@ -0,0 +1,89 @@
+package meetupstrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+// meetupRow is the meetup in the snapshot, the status is either cancelled or
+// open since the other statuses are derived by `Meetup.RefreshStatus()`.
+type meetupRow struct {
+	ID                 int                `json:"id"`
+	Name               string             `json:"name"`
+	Venue              meetupVenueRow     `json:"venue"`
+	Event              meetupEventRow     `json:"event"`
+	StartTs            int                `json:"start_ts"`
+	EndTs              int                `json:"end_ts"`
+	MaxPersons         int                `json:"max_persons"`
+	Organizer          meetupOrganizerRow `json:"organizer"`
+	JoinedPersons      []joinedPersonRow  `json:"joined_persons"`
+	JoinedPersonsCount int                `json:"joined_persons_count"`
+	Status             string             `json:"status"`
+	CancelledReason    string             `json:"cancelled_reason"`
+	CancelledAt        int64              `json:"cancelled_at"`
+	CancelledBy        int                `json:"cancelled_by"`
+}
+
+func toMeetupRow(m entity.Meetup) meetupRow {
+	row := meetupRow{
+		ID:                 m.ID,
+		Name:               m.Name,
+		Venue:              meetupVenueRow(m.Venue),
+		Event:              meetupEventRow(m.Event),
+		StartTs:            m.StartTs,
+		EndTs:              m.EndTs,
+		MaxPersons:         m.MaxPersons,
+		Organizer:          meetupOrganizerRow(m.Organizer),
+		JoinedPersonsCount: m.JoinedPersonsCount,
+		Status:             string(m.Status),
+		CancelledReason:    m.CancelledReason,
+		CancelledAt:        m.CancelledAt,
+		CancelledBy:        m.CancelledBy,
+	}
+	for _, person := range m.JoinedPersons {
+		row.JoinedPersons = append(row.JoinedPersons, joinedPersonRow(person))
+	}
+	return row
+}
+
+func (r meetupRow) toMeetup() entity.Meetup {
+	meetup := entity.Meetup{
+		ID:                 r.ID,
+		Name:               r.Name,
+		Venue:              entity.MeetupVenue(r.Venue),
+		Event:              entity.MeetupEvent(r.Event),
+		StartTs:            r.StartTs,
+		EndTs:              r.EndTs,
+		MaxPersons:         r.MaxPersons,
+		Organizer:          entity.MeetupOrganizer(r.Organizer),
+		JoinedPersonsCount: r.JoinedPersonsCount,
+		Status:             entity.MeetupStatus(r.Status),
+		CancelledReason:    r.CancelledReason,
+		CancelledAt:        r.CancelledAt,
+		CancelledBy:        r.CancelledBy,
+	}
+	for _, person := range r.JoinedPersons {
+		meetup.JoinedPersons = append(meetup.JoinedPersons, entity.JoinedPerson(person))
+	}
+	return meetup
+}
+
+type meetupVenueRow struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type meetupEventRow struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type meetupOrganizerRow struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+type joinedPersonRow struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	JoinedAt int    `json:"joined_at"`
+}
@@ -0,0 +1,249 @@
+package meetupstrg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
+)
+
+type Storage struct {
+	mtx    sync.RWMutex
+	data   map[int]entity.Meetup
+	lastID int
+}
+
+// GetMeetups implements meetup.MeetupStorage.
+func (s *Storage) GetMeetups(ctx context.Context, query entity.MeetupQuery, now int64) ([]entity.Meetup, error) {
+	cursor, err := query.GetCursor()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode cursor due: %w", err)
+	}
+
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var meetups []entity.Meetup
+	for _, m := range s.data {
+		if !query.IsMatch(m, now) || (cursor != nil && !cursor.IsAfter(m)) {
+			continue
+		}
+		m = copyMeetup(m)
+		m.RefreshStatus(now)
+		meetups = append(meetups, m)
+	}
+	entity.SortMeetups(meetups)
+	if len(meetups) > query.GetLimit() {
+		meetups = meetups[:query.GetLimit()]
+	}
+	return meetups, nil
+}
+
+// GetParticipantMeetups implements meetup.MeetupStorage.
+func (s *Storage) GetParticipantMeetups(ctx context.Context, userID int, startAfterTs int) ([]entity.Meetup, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var meetups []entity.Meetup
+	for _, m := range s.data {
+		if m.StartTs > startAfterTs && m.IsMember(userID) {
+			meetups = append(meetups, copyMeetup(m))
+		}
+	}
+	entity.SortMeetups(meetups)
+	return meetups, nil
+}
+
+// GetOverlappingMeetups implements meetup.MeetupStorage.
+func (s *Storage) GetOverlappingMeetups(ctx context.Context, venueID, eventID, startTs, endTs int) ([]entity.Meetup, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var meetups []entity.Meetup
+	for _, m := range s.data {
+		if m.Venue.ID != venueID || m.Event.ID != eventID || m.Status == entity.MeetupStatusCancelled {
+			continue
+		}
+		if m.IsOverlapping(startTs, endTs) {
+			meetups = append(meetups, copyMeetup(m))
+		}
+	}
+	entity.SortMeetups(meetups)
+	return meetups, nil
+}
+
+// GetVenueMeetups implements venue.MeetupStorage.
+func (s *Storage) GetVenueMeetups(ctx context.Context, venueID, startTs, endTs int) ([]entity.Meetup, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var meetups []entity.Meetup
+	for _, m := range s.data {
+		if m.Venue.ID != venueID || m.Status == entity.MeetupStatusCancelled {
+			continue
+		}
+		if m.IsOverlapping(startTs, endTs) {
+			meetups = append(meetups, copyMeetup(m))
+		}
+	}
+	entity.SortMeetups(meetups)
+	return meetups, nil
+}
+
+// SaveMeetup implements meetup.MeetupStorage.
+func (s *Storage) SaveMeetup(ctx context.Context, m entity.Meetup) (int, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.lastID++
+	m.ID = s.lastID
+	s.data[m.ID] = copyMeetup(m)
+
+	return m.ID, nil
+}
+
+// GetMeetup implements meetup.MeetupStorage.
+func (s *Storage) GetMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	m, ok := s.data[meetupID]
+	if !ok {
+		return nil, nil
+	}
+	m = copyMeetup(m)
+	return &m, nil
+}
+
+// UpdateMeetup implements meetup.MeetupStorage.
+func (s *Storage) UpdateMeetup(ctx context.Context, m entity.Meetup) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stored, ok := s.data[m.ID]
+	if !ok || stored.Status == entity.MeetupStatusCancelled || m.MaxPersons < stored.JoinedPersonsCount {
+		return false, nil
+	}
+	stored.Name = m.Name
+	stored.StartTs = m.StartTs
+	stored.EndTs = m.EndTs
+	stored.MaxPersons = m.MaxPersons
+	s.data[m.ID] = stored
+
+	return true, nil
+}
+
+// CancelMeetup implements meetup.MeetupStorage.
+func (s *Storage) CancelMeetup(ctx context.Context, m entity.Meetup) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	stored, ok := s.data[m.ID]
+	if !ok {
+		return fmt.Errorf("meetup %v is not found", m.ID)
+	}
+	if stored.Status == entity.MeetupStatusCancelled {
+		return meetup.ErrMeetupCancelled
+	}
+	stored.Status = entity.MeetupStatusCancelled
+	stored.CancelledReason = m.CancelledReason
+	stored.CancelledAt = m.CancelledAt
+	stored.CancelledBy = m.CancelledBy
+	s.data[m.ID] = stored
+
+	return nil
+}
+
+// JoinMeetup implements meetup.MeetupStorage.
+func (s *Storage) JoinMeetup(ctx context.Context, meetupID int, person entity.JoinedPerson) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	m, ok := s.data[meetupID]
+	if !ok || m.Status == entity.MeetupStatusCancelled || m.IsParticipant(person.ID) || m.JoinedPersonsCount >= m.MaxPersons {
+		return false, nil
+	}
+	// build new slice so the participants previously returned to the callers
+	// are not affected
+	persons := make([]entity.JoinedPerson, 0, len(m.JoinedPersons)+1)
+	persons = append(persons, m.JoinedPersons...)
+	m.JoinedPersons = append(persons, person)
+	m.JoinedPersonsCount = len(m.JoinedPersons)
+	s.data[meetupID] = m
+
+	return true, nil
+}
+
+// LeaveMeetup implements meetup.MeetupStorage.
+func (s *Storage) LeaveMeetup(ctx context.Context, meetupID int, userID int) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	m, ok := s.data[meetupID]
+	if !ok || !m.IsParticipant(userID) {
+		return false, nil
+	}
+	var persons []entity.JoinedPerson
+	for _, person := range m.JoinedPersons {
+		if person.ID != userID {
+			persons = append(persons, person)
+		}
+	}
+	m.JoinedPersons = persons
+	m.JoinedPersonsCount = len(persons)
+	s.data[meetupID] = m
+
+	return true, nil
+}
+
+// MarshalSnapshot implements snapshot.Source.
+func (s *Storage) MarshalSnapshot() ([]byte, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	meetups := []entity.Meetup{}
+	for _, m := range s.data {
+		meetups = append(meetups, m)
+	}
+	entity.SortMeetups(meetups)
+	rows := make([]meetupRow, 0, len(meetups))
+	for _, m := range meetups {
+		rows = append(rows, toMeetupRow(m))
+	}
+	return json.Marshal(rows)
+}
+
+// UnmarshalSnapshot implements snapshot.Source.
+func (s *Storage) UnmarshalSnapshot(data []byte) error {
+	var rows []meetupRow
+	err := json.Unmarshal(data, &rows)
+	if err != nil {
+		return fmt.Errorf("unable to parse meetups due: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data = map[int]entity.Meetup{}
+	s.lastID = 0
+	for _, row := range rows {
+		s.data[row.ID] = row.toMeetup()
+		if row.ID > s.lastID {
+			s.lastID = row.ID
+		}
+	}
+	return nil
+}
+
+// copyMeetup returns a copy of given meetup which shares no slices with it.
+func copyMeetup(m entity.Meetup) entity.Meetup {
+	m.JoinedPersons = append([]entity.JoinedPerson(nil), m.JoinedPersons...)
+	return m
+}
+
+func New() *Storage {
+	return &Storage{data: map[int]entity.Meetup{}}
+}
@@ -0,0 +1,359 @@
+package meetupstrg_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/meetupstrg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveGetMeetup(t *testing.T) {
+	strg := meetupstrg.New()
+
+	// the ids should be assigned incrementally
+	m := newTestMeetup(1, 1000, 2000, 2)
+	for expID := 1; expID <= 3; expID++ {
+		id, err := strg.SaveMeetup(context.Background(), m)
+		require.NoError(t, err)
+		require.Equal(t, expID, id, "unexpected id")
+	}
+
+	stored, err := strg.GetMeetup(context.Background(), 2)
+	require.NoError(t, err)
+	m.ID = 2
+	require.Equal(t, m, *stored, "mismatch meetup")
+
+	// unknown meetup
+	stored, err = strg.GetMeetup(context.Background(), 4)
+	require.NoError(t, err)
+	require.Nil(t, stored, "meetup is not nil")
+}
+
+func TestGetMeetups(t *testing.T) {
+	strg := meetupstrg.New()
+	for _, m := range []entity.Meetup{
+		newTestMeetup(1, 3000, 4000, 1),
+		newTestMeetup(2, 1000, 2000, 1),
+		newTestMeetup(1, 1000, 2000, 1),
+		newTestMeetup(1, 5000, 6000, 1),
+	} {
+		_, err := strg.SaveMeetup(context.Background(), m)
+		require.NoError(t, err)
+	}
+
+	// define test cases
+	testCases := []struct {
+		Name      string
+		Query     entity.MeetupQuery
+		ExpIDs    []int
+		ExpStatus entity.MeetupStatus
+	}{
+		{
+			Name:   "All Meetups",
+			Query:  entity.MeetupQuery{},
+			ExpIDs: []int{2, 3, 1, 4},
+		},
+		{
+			Name:   "Venue Meetups",
+			Query:  entity.MeetupQuery{VenueID: 1},
+			ExpIDs: []int{3, 1, 4},
+		},
+		{
+			Name:   "Limited Meetups After Cursor",
+			Query:  entity.MeetupQuery{Limit: 2, Cursor: entity.MeetupCursor{StartTs: 1000, ID: 2}.Encode()},
+			ExpIDs: []int{3, 1},
+		},
+		{
+			Name:      "Finished Meetups",
+			Query:     entity.MeetupQuery{Status: entity.MeetupStatusFinished},
+			ExpIDs:    []int{2, 3},
+			ExpStatus: entity.MeetupStatusFinished,
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			meetups, err := strg.GetMeetups(context.Background(), testCase.Query, 2500)
+			require.NoError(t, err)
+			for _, m := range meetups {
+				if testCase.ExpStatus != "" {
+					require.Equal(t, testCase.ExpStatus, m.Status, "mismatch status")
+				}
+			}
+			require.Equal(t, testCase.ExpIDs, getMeetupIDs(meetups), "mismatch meetups")
+		})
+	}
+}
+
+func TestJoinLeaveMeetup(t *testing.T) {
+	strg := meetupstrg.New()
+	meetupID, err := strg.SaveMeetup(context.Background(), newTestMeetup(1, 1000, 2000, 1))
+	require.NoError(t, err)
+
+	before, err := strg.GetMeetup(context.Background(), meetupID)
+	require.NoError(t, err)
+
+	// the second person could not join since the meetup is full
+	ok, err := strg.JoinMeetup(context.Background(), meetupID, entity.JoinedPerson{ID: 2})
+	require.NoError(t, err)
+	require.True(t, ok, "unable to join meetup")
+	ok, err = strg.JoinMeetup(context.Background(), meetupID, entity.JoinedPerson{ID: 3})
+	require.NoError(t, err)
+	require.False(t, ok, "able to join full meetup")
+
+	// the meetup returned previously should not be affected
+	require.Empty(t, before.JoinedPersons, "previous meetup is modified")
+
+	// max persons could not go below the number of joined persons
+	m := newTestMeetup(1, 1000, 2000, 0)
+	m.ID = meetupID
+	ok, err = strg.UpdateMeetup(context.Background(), m)
+	require.NoError(t, err)
+	require.False(t, ok, "able to update max persons below joined persons")
+
+	// leave the meetup twice
+	ok, err = strg.LeaveMeetup(context.Background(), meetupID, 2)
+	require.NoError(t, err)
+	require.True(t, ok, "unable to leave meetup")
+	ok, err = strg.LeaveMeetup(context.Background(), meetupID, 2)
+	require.NoError(t, err)
+	require.False(t, ok, "able to leave meetup twice")
+
+	stored, err := strg.GetMeetup(context.Background(), meetupID)
+	require.NoError(t, err)
+	require.Empty(t, stored.JoinedPersons, "mismatch joined persons")
+	require.Equal(t, 0, stored.JoinedPersonsCount, "mismatch joined persons count")
+}
+
+func TestUpdateMeetup(t *testing.T) {
+	strg := meetupstrg.New()
+	meetupID, err := strg.SaveMeetup(context.Background(), newTestMeetup(1, 1000, 2000, 2))
+	require.NoError(t, err)
+	ok, err := strg.JoinMeetup(context.Background(), meetupID, entity.JoinedPerson{ID: 2})
+	require.NoError(t, err)
+	require.True(t, ok, "unable to join meetup")
+
+	// the participants & the status are kept while the other fields are
+	// updated
+	m := newTestMeetup(1, 3000, 4000, 1)
+	m.ID = meetupID
+	m.Name = "Updated Meetup"
+	m.Status = entity.MeetupStatusCancelled
+	ok, err = strg.UpdateMeetup(context.Background(), m)
+	require.NoError(t, err)
+	require.True(t, ok, "unable to update meetup")
+
+	m.JoinedPersons = []entity.JoinedPerson{{ID: 2}}
+	m.JoinedPersonsCount = 1
+	m.Status = entity.MeetupStatusOpen
+	stored, err := strg.GetMeetup(context.Background(), meetupID)
+	require.NoError(t, err)
+	require.Equal(t, m, *stored, "mismatch meetup")
+
+	// unknown meetup
+	m.ID = 99
+	ok, err = strg.UpdateMeetup(context.Background(), m)
+	require.NoError(t, err)
+	require.False(t, ok, "able to update unknown meetup")
+}
+
+func TestJoinMeetupConcurrently(t *testing.T) {
+	strg := meetupstrg.New()
+	meetupID, err := strg.SaveMeetup(context.Background(), newTestMeetup(1, 1000, 2000, 5))
+	require.NoError(t, err)
+
+	// only max persons out of all the persons should be able to join
+	var wg sync.WaitGroup
+	results := make(chan bool, 20)
+	for userID := 2; userID < 22; userID++ {
+		wg.Add(1)
+		go func(userID int) {
+			defer wg.Done()
+			ok, err := strg.JoinMeetup(context.Background(), meetupID, entity.JoinedPerson{ID: userID})
+			require.NoError(t, err)
+			results <- ok
+		}(userID)
+	}
+	wg.Wait()
+	close(results)
+
+	joinedCount := 0
+	for ok := range results {
+		if ok {
+			joinedCount++
+		}
+	}
+	require.Equal(t, 5, joinedCount, "mismatch joined count")
+
+	stored, err := strg.GetMeetup(context.Background(), meetupID)
+	require.NoError(t, err)
+	require.Len(t, stored.JoinedPersons, 5, "mismatch joined persons")
+	require.Equal(t, 5, stored.JoinedPersonsCount, "mismatch joined persons count")
+}
+
+func TestCancelMeetup(t *testing.T) {
+	strg := meetupstrg.New()
+	meetupID, err := strg.SaveMeetup(context.Background(), newTestMeetup(1, 1000, 2000, 2))
+	require.NoError(t, err)
+
+	m := newTestMeetup(1, 1000, 2000, 2)
+	m.ID = meetupID
+	require.NoError(t, m.Cancel(1, "venue is flooded", 500))
+	err = strg.CancelMeetup(context.Background(), m)
+	require.NoError(t, err)
+
+	stored, err := strg.GetMeetup(context.Background(), meetupID)
+	require.NoError(t, err)
+	require.Equal(t, m, *stored, "mismatch meetup")
+
+	// the second cancellation is rejected & the first one is kept
+	other := m
+	other.CancelledReason = "organizer is sick"
+	err = strg.CancelMeetup(context.Background(), other)
+	require.ErrorIs(t, err, meetup.ErrMeetupCancelled)
+	stored, err = strg.GetMeetup(context.Background(), meetupID)
+	require.NoError(t, err)
+	require.Equal(t, m, *stored, "mismatch meetup")
+
+	// cancelled meetup could not be joined nor updated
+	ok, err := strg.JoinMeetup(context.Background(), meetupID, entity.JoinedPerson{ID: 2})
+	require.NoError(t, err)
+	require.False(t, ok, "able to join cancelled meetup")
+
+	m = newTestMeetup(1, 3000, 4000, 2)
+	m.ID = meetupID
+	ok, err = strg.UpdateMeetup(context.Background(), m)
+	require.NoError(t, err)
+	require.False(t, ok, "able to update cancelled meetup")
+
+	// unknown meetup
+	m.ID = 99
+	err = strg.CancelMeetup(context.Background(), m)
+	require.Error(t, err)
+}
+
+func TestGetOverlappingMeetups(t *testing.T) {
+	strg := meetupstrg.New()
+	cancelled := newTestMeetup(1, 1000, 2000, 1)
+	cancelled.Status = entity.MeetupStatusCancelled
+	other := newTestMeetup(1, 1000, 2000, 1)
+	other.Event.ID = 2
+	for _, m := range []entity.Meetup{
+		newTestMeetup(1, 1000, 2000, 1),
+		cancelled,
+		other,
+		newTestMeetup(2, 1000, 2000, 1),
+		newTestMeetup(1, 2000, 3000, 1),
+	} {
+		_, err := strg.SaveMeetup(context.Background(), m)
+		require.NoError(t, err)
+	}
+
+	// cancelled, other event, other venue & non overlapping are excluded
+	meetups, err := strg.GetOverlappingMeetups(context.Background(), 1, 1, 1500, 2000)
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, getMeetupIDs(meetups), "mismatch overlapping meetups")
+}
+
+func TestGetVenueMeetups(t *testing.T) {
+	strg := meetupstrg.New()
+	cancelled := newTestMeetup(1, 1000, 2000, 1)
+	cancelled.Status = entity.MeetupStatusCancelled
+	other := newTestMeetup(1, 1000, 2000, 1)
+	other.Event.ID = 2
+	for _, m := range []entity.Meetup{
+		newTestMeetup(1, 1500, 2500, 1),
+		cancelled,
+		other,
+		newTestMeetup(2, 1000, 2000, 1),
+		newTestMeetup(1, 2000, 3000, 1),
+	} {
+		_, err := strg.SaveMeetup(context.Background(), m)
+		require.NoError(t, err)
+	}
+
+	// meetups of all events are included, cancelled, other venue & non
+	// overlapping are excluded
+	meetups, err := strg.GetVenueMeetups(context.Background(), 1, 1000, 2000)
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 1}, getMeetupIDs(meetups), "mismatch venue meetups")
+}
+
+func TestGetParticipantMeetups(t *testing.T) {
+	strg := meetupstrg.New()
+	joined := newTestMeetup(1, 2000, 3000, 2)
+	joined.Organizer.ID = 2
+	joined.JoinedPersons = []entity.JoinedPerson{{ID: 1}}
+	joined.JoinedPersonsCount = 1
+	other := newTestMeetup(1, 2000, 3000, 2)
+	other.Organizer.ID = 2
+	for _, m := range []entity.Meetup{
+		newTestMeetup(1, 3000, 4000, 2),
+		joined,
+		other,
+		newTestMeetup(1, 1000, 2000, 2),
+	} {
+		_, err := strg.SaveMeetup(context.Background(), m)
+		require.NoError(t, err)
+	}
+
+	// the user organizes the first & joins the second meetup, the last one
+	// is excluded since it has started
+	meetups, err := strg.GetParticipantMeetups(context.Background(), 1, 1000)
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 1}, getMeetupIDs(meetups), "mismatch participant meetups")
+}
+
+func getMeetupIDs(meetups []entity.Meetup) []int {
+	ids := []int{}
+	for _, m := range meetups {
+		ids = append(ids, m.ID)
+	}
+	return ids
+}
+
+func newTestMeetup(venueID, startTs, endTs, maxPersons int) entity.Meetup {
+	return entity.Meetup{
+		Name:       "Test Meetup",
+		Venue:      entity.MeetupVenue{ID: venueID, Name: "Test Venue"},
+		Event:      entity.MeetupEvent{ID: 1, Name: "Wedding"},
+		StartTs:    startTs,
+		EndTs:      endTs,
+		MaxPersons: maxPersons,
+		Organizer:  entity.MeetupOrganizer{ID: 1, Username: "marion"},
+		Status:     entity.MeetupStatusOpen,
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	strg := meetupstrg.New()
+	for i := 0; i < 2; i++ {
+		_, err := strg.SaveMeetup(context.Background(), newTestMeetup(1, 1000, 2000, 2))
+		require.NoError(t, err)
+	}
+	ok, err := strg.JoinMeetup(context.Background(), 2, entity.JoinedPerson{ID: 2, Username: "todd", JoinedAt: 500})
+	require.NoError(t, err)
+	require.True(t, ok, "unable to join meetup")
+	expMeetup, err := strg.GetMeetup(context.Background(), 2)
+	require.NoError(t, err)
+
+	// restore the snapshot into new storage
+	data, err := strg.MarshalSnapshot()
+	require.NoError(t, err)
+	restored := meetupstrg.New()
+	err = restored.UnmarshalSnapshot(data)
+	require.NoError(t, err)
+
+	m, err := restored.GetMeetup(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, expMeetup, m, "mismatch meetup")
+
+	// the id should continue from the restored meetups
+	id, err := restored.SaveMeetup(context.Background(), newTestMeetup(1, 1000, 2000, 2))
+	require.NoError(t, err)
+	require.Equal(t, 3, id, "unexpected id")
+}
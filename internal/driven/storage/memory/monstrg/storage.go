@@ -9,6 +9,8 @@ import (
 	"gopkg.in/validator.v2"
 )
 
+// Storage is safe for concurrent use without locking since its data is never
+// modified after New() and the monsters are returned by value.
 type Storage struct {
 	partnerMap map[string]entity.Monster
 	enemyMap   map[string]entity.Monster
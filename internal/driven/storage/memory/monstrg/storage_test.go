@@ -2,6 +2,7 @@ package monstrg_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
@@ -162,6 +163,32 @@ func TestGetPossibleEnemies(t *testing.T) {
 	require.ElementsMatch(t, expPossibleEnemies, possibleEnemies, "enemies is not equal")
 }
 
+func TestGetPartnerConcurrently(t *testing.T) {
+	// init storage
+	strg := initStorage(t)
+
+	// modify the returned partners from many goroutines, the stored partner
+	// should not be affected
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partner, err := strg.GetPartner(context.Background(), "b1c87c5c-2ac3-471d-9880-4812552ee15d")
+			require.NoError(t, err)
+			partner.BattleStats.Health = 0
+			partners, err := strg.GetAvailablePartners(context.Background())
+			require.NoError(t, err)
+			partners[0].BattleStats.Health = 0
+		}()
+	}
+	wg.Wait()
+
+	partner, err := strg.GetPartner(context.Background(), "b1c87c5c-2ac3-471d-9880-4812552ee15d")
+	require.NoError(t, err)
+	require.Equal(t, 100, partner.BattleStats.Health, "stored partner is modified")
+}
+
 var monsterData = []byte(`
 	[
 		{
@@ -0,0 +1,98 @@
+package ratestrg
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/validator.v2"
+)
+
+// sweepInterval is the minimum interval between removals of full buckets,
+// this is to prevent the storage from growing indefinitely while keeping the
+// writes cheap.
+const sweepInterval = time.Minute
+
+type Storage struct {
+	mtx            sync.Mutex
+	capacity       float64
+	refillInterval time.Duration
+	buckets        map[string]bucket
+	lastSweepAt    time.Time
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// TakeToken implements rest.RateLimitStorage.
+func (s *Storage) TakeToken(ctx context.Context, key string, now time.Time) (bool, time.Duration, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = bucket{tokens: s.capacity, updatedAt: now}
+	}
+	// refill the bucket for the elapsed time since the last update
+	if elapsed := now.Sub(b.updatedAt); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(s.refillInterval)
+		if b.tokens > s.capacity {
+			b.tokens = s.capacity
+		}
+		b.updatedAt = now
+	}
+	if b.tokens < 1 {
+		s.buckets[key] = b
+		retryAfter := time.Duration((1 - b.tokens) * float64(s.refillInterval))
+		return false, retryAfter, nil
+	}
+	b.tokens--
+	s.buckets[key] = b
+
+	return true, 0, nil
+}
+
+// sweep removes buckets which are already full at given time since they are
+// the same as new buckets, the caller must hold the lock.
+func (s *Storage) sweep(now time.Time) {
+	if now.Sub(s.lastSweepAt) < sweepInterval {
+		return
+	}
+	for key, b := range s.buckets {
+		refill := float64(now.Sub(b.updatedAt)) / float64(s.refillInterval)
+		if b.tokens+refill >= s.capacity {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweepAt = now
+}
+
+type Config struct {
+	// Capacity is the maximum number of tokens in a bucket, it is the
+	// number of requests allowed in a burst
+	Capacity int `validate:"min=1"`
+	// RefillInterval is the interval of adding a token into a bucket
+	RefillInterval time.Duration `validate:"min=1"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	s := &Storage{
+		capacity:       float64(cfg.Capacity),
+		refillInterval: cfg.RefillInterval,
+		buckets:        map[string]bucket{},
+	}
+	return s, nil
+}
@@ -0,0 +1,63 @@
+package ratestrg_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/ratestrg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeToken(t *testing.T) {
+	strg, err := ratestrg.New(ratestrg.Config{
+		Capacity:       3,
+		RefillInterval: time.Second,
+	})
+	require.NoError(t, err)
+	now := time.Now()
+
+	// take all tokens in a burst
+	for i := 0; i < 3; i++ {
+		ok, _, err := strg.TakeToken(context.Background(), "127.0.0.1", now)
+		require.NoError(t, err)
+		require.True(t, ok, "token is not available")
+	}
+
+	// the bucket is empty
+	ok, retryAfter, err := strg.TakeToken(context.Background(), "127.0.0.1", now.Add(400*time.Millisecond))
+	require.NoError(t, err)
+	require.False(t, ok, "token should not be available")
+	require.Equal(t, 600*time.Millisecond, retryAfter, "unexpected retry after")
+
+	// other key has its own bucket
+	ok, _, err = strg.TakeToken(context.Background(), "127.0.0.2", now)
+	require.NoError(t, err)
+	require.True(t, ok, "token is not available")
+
+	// one token is refilled after the refill interval
+	ok, _, err = strg.TakeToken(context.Background(), "127.0.0.1", now.Add(time.Second))
+	require.NoError(t, err)
+	require.True(t, ok, "token is not refilled")
+	ok, _, err = strg.TakeToken(context.Background(), "127.0.0.1", now.Add(time.Second))
+	require.NoError(t, err)
+	require.False(t, ok, "token should not be available")
+
+	// the bucket never exceeds its capacity
+	later := now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		ok, _, err := strg.TakeToken(context.Background(), "127.0.0.1", later)
+		require.NoError(t, err)
+		require.True(t, ok, "token is not available")
+	}
+	ok, _, err = strg.TakeToken(context.Background(), "127.0.0.1", later)
+	require.NoError(t, err)
+	require.False(t, ok, "bucket exceeds its capacity")
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	_, err := ratestrg.New(ratestrg.Config{Capacity: 0, RefillInterval: time.Second})
+	require.Error(t, err)
+	_, err = ratestrg.New(ratestrg.Config{Capacity: 1, RefillInterval: 0})
+	require.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package sessionstrg
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+)
+
+type Storage struct {
+	mtx                 sync.RWMutex
+	refreshTokens       map[string]entity.RefreshToken
+	revokedFamilies     map[string]int64
+	revokedAccessTokens map[string]int64
+}
+
+// SaveRefreshToken implements session.RefreshTokenStorage.
+func (s *Storage) SaveRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.refreshTokens[token.ID] = token
+	return nil
+}
+
+// GetRefreshToken implements session.RefreshTokenStorage.
+func (s *Storage) GetRefreshToken(ctx context.Context, tokenID string) (*entity.RefreshToken, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	token, ok := s.refreshTokens[tokenID]
+	if !ok {
+		return nil, nil
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken implements session.RefreshTokenStorage.
+func (s *Storage) RotateRefreshToken(ctx context.Context, oldTokenID string, newToken entity.RefreshToken) (bool, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	oldToken, ok := s.refreshTokens[oldTokenID]
+	if !ok || oldToken.IsRotated() {
+		return false, nil
+	}
+	if _, ok := s.revokedFamilies[oldToken.FamilyID]; ok {
+		return false, nil
+	}
+	oldToken.RotatedAt = newToken.IssuedAt
+	s.refreshTokens[oldToken.ID] = oldToken
+	s.refreshTokens[newToken.ID] = newToken
+
+	return true, nil
+}
+
+// RevokeFamily implements session.RefreshTokenStorage.
+func (s *Storage) RevokeFamily(ctx context.Context, familyID string, revokedAt int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.revokedFamilies[familyID]; !ok {
+		s.revokedFamilies[familyID] = revokedAt
+	}
+	return nil
+}
+
+// IsFamilyRevoked implements session.RefreshTokenStorage.
+func (s *Storage) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	_, ok := s.revokedFamilies[familyID]
+	return ok, nil
+}
+
+// RevokeAccessToken implements session.RevocationStorage.
+func (s *Storage) RevokeAccessToken(ctx context.Context, tokenID string, exp int64) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// remove expired tokens since they are rejected anyway, this is to
+	// prevent the revocation list from growing indefinitely
+	now := time.Now().Unix()
+	for id, tokenExp := range s.revokedAccessTokens {
+		if now >= tokenExp {
+			delete(s.revokedAccessTokens, id)
+		}
+	}
+	s.revokedAccessTokens[tokenID] = exp
+
+	return nil
+}
+
+// IsAccessTokenRevoked implements session.RevocationStorage.
+func (s *Storage) IsAccessTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	_, ok := s.revokedAccessTokens[tokenID]
+	return ok, nil
+}
+
+func New() *Storage {
+	return &Storage{
+		refreshTokens:       map[string]entity.RefreshToken{},
+		revokedFamilies:     map[string]int64{},
+		revokedAccessTokens: map[string]int64{},
+	}
+}
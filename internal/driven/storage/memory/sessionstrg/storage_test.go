@@ -0,0 +1,148 @@
+package sessionstrg_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/sessionstrg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveGetRefreshToken(t *testing.T) {
+	// init storage & token
+	strg := sessionstrg.New()
+	expToken := newRefreshToken(uuid.NewString())
+
+	// get token, supposedly the returned token is nil
+	token, err := strg.GetRefreshToken(context.Background(), expToken.ID)
+	require.NoError(t, err)
+	require.Nil(t, token, "token is not nil")
+
+	// save token
+	err = strg.SaveRefreshToken(context.Background(), expToken)
+	require.NoError(t, err)
+
+	// get token
+	token, err = strg.GetRefreshToken(context.Background(), expToken.ID)
+	require.NoError(t, err)
+	require.Equal(t, expToken, *token, "unexpected token")
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	// init storage & save token
+	strg := sessionstrg.New()
+	oldToken := newRefreshToken(uuid.NewString())
+	err := strg.SaveRefreshToken(context.Background(), oldToken)
+	require.NoError(t, err)
+
+	// rotate token
+	newToken := newRefreshToken(oldToken.FamilyID)
+	ok, err := strg.RotateRefreshToken(context.Background(), oldToken.ID, newToken)
+	require.NoError(t, err)
+	require.True(t, ok, "token is not rotated")
+
+	// old token should be marked as rotated
+	token, err := strg.GetRefreshToken(context.Background(), oldToken.ID)
+	require.NoError(t, err)
+	require.Equal(t, newToken.IssuedAt, token.RotatedAt, "mismatch rotated at")
+
+	// new token should be saved
+	token, err = strg.GetRefreshToken(context.Background(), newToken.ID)
+	require.NoError(t, err)
+	require.Equal(t, newToken, *token, "unexpected token")
+
+	// rotating old token again should fail
+	ok, err = strg.RotateRefreshToken(context.Background(), oldToken.ID, newRefreshToken(oldToken.FamilyID))
+	require.NoError(t, err)
+	require.False(t, ok, "rotated token is rotated again")
+
+	// rotating unknown token should fail
+	ok, err = strg.RotateRefreshToken(context.Background(), uuid.NewString(), newRefreshToken(oldToken.FamilyID))
+	require.NoError(t, err)
+	require.False(t, ok, "unknown token is rotated")
+
+	// rotating token of revoked family should fail
+	err = strg.RevokeFamily(context.Background(), oldToken.FamilyID, time.Now().Unix())
+	require.NoError(t, err)
+	ok, err = strg.RotateRefreshToken(context.Background(), newToken.ID, newRefreshToken(oldToken.FamilyID))
+	require.NoError(t, err)
+	require.False(t, ok, "token of revoked family is rotated")
+}
+
+func TestRotateRefreshTokenConcurrently(t *testing.T) {
+	// init storage & save token
+	strg := sessionstrg.New()
+	oldToken := newRefreshToken(uuid.NewString())
+	err := strg.SaveRefreshToken(context.Background(), oldToken)
+	require.NoError(t, err)
+
+	// rotate the same token concurrently, only one of them should succeed
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	succeed := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := strg.RotateRefreshToken(context.Background(), oldToken.ID, newRefreshToken(oldToken.FamilyID))
+			if err == nil && ok {
+				mtx.Lock()
+				succeed++
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 1, succeed, "unexpected number of rotation")
+}
+
+func TestRevokeFamily(t *testing.T) {
+	strg := sessionstrg.New()
+	familyID := uuid.NewString()
+
+	// family is not revoked yet
+	isRevoked, err := strg.IsFamilyRevoked(context.Background(), familyID)
+	require.NoError(t, err)
+	require.False(t, isRevoked, "family is revoked")
+
+	// revoke family
+	err = strg.RevokeFamily(context.Background(), familyID, time.Now().Unix())
+	require.NoError(t, err)
+
+	isRevoked, err = strg.IsFamilyRevoked(context.Background(), familyID)
+	require.NoError(t, err)
+	require.True(t, isRevoked, "family is not revoked")
+}
+
+func TestRevokeAccessToken(t *testing.T) {
+	strg := sessionstrg.New()
+	tokenID := uuid.NewString()
+
+	// token is not revoked yet
+	isRevoked, err := strg.IsAccessTokenRevoked(context.Background(), tokenID)
+	require.NoError(t, err)
+	require.False(t, isRevoked, "token is revoked")
+
+	// revoke token
+	err = strg.RevokeAccessToken(context.Background(), tokenID, time.Now().Add(time.Hour).Unix())
+	require.NoError(t, err)
+
+	isRevoked, err = strg.IsAccessTokenRevoked(context.Background(), tokenID)
+	require.NoError(t, err)
+	require.True(t, isRevoked, "token is not revoked")
+}
+
+func newRefreshToken(familyID string) entity.RefreshToken {
+	now := time.Now()
+	token, _ := entity.NewRefreshToken(entity.RefreshTokenConfig{
+		FamilyID:  familyID,
+		UserID:    1,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	})
+	return *token
+}
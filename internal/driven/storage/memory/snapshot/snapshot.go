@@ -0,0 +1,157 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/validator.v2"
+)
+
+// Source is memory storage whose data could be saved to & restored from the
+// snapshot file.
+type Source interface {
+	// MarshalSnapshot returns the JSON encoded data of the storage.
+	MarshalSnapshot() ([]byte, error)
+
+	// UnmarshalSnapshot replaces the data of the storage with given JSON
+	// encoded data returned by MarshalSnapshot().
+	UnmarshalSnapshot(data []byte) error
+}
+
+// Snapshotter saves the data of the memory storages into single JSON file
+// keyed by the source name. Each source is saved consistently on its own,
+// but the sources are not saved at the same instant.
+type Snapshotter struct {
+	path     string
+	interval time.Duration
+	sources  map[string]Source
+}
+
+// Load restores the data of the sources from the snapshot file. It does
+// nothing when the file doesn't exist yet, and the sources missing from the
+// file are left untouched.
+func (s *Snapshotter) Load() error {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read snapshot due: %w", err)
+	}
+	var snapshot map[string]json.RawMessage
+	err = json.Unmarshal(b, &snapshot)
+	if err != nil {
+		return fmt.Errorf("unable to parse snapshot due: %w", err)
+	}
+	for name, source := range s.sources {
+		data, ok := snapshot[name]
+		if !ok {
+			continue
+		}
+		err = source.UnmarshalSnapshot(data)
+		if err != nil {
+			return fmt.Errorf("unable to restore %v due: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Save writes the data of the sources into the snapshot file. The data is
+// written into temporary file which then replaces the snapshot file, so the
+// snapshot file is never left partially written. The directory is synced
+// after the rename so the replacement survives a crash.
+func (s *Snapshotter) Save() error {
+	snapshot := map[string]json.RawMessage{}
+	for name, source := range s.sources {
+		data, err := source.MarshalSnapshot()
+		if err != nil {
+			return fmt.Errorf("unable to snapshot %v due: %w", name, err)
+		}
+		snapshot[name] = data
+	}
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("unable to encode snapshot due: %w", err)
+	}
+	// the temporary file must be in the same directory for the rename to be atomic
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary snapshot due: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	_, err = tmpFile.Write(b)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write temporary snapshot due: %w", err)
+	}
+	err = os.Rename(tmpFile.Name(), s.path)
+	if err != nil {
+		return fmt.Errorf("unable to replace snapshot due: %w", err)
+	}
+	// sync the directory as well, otherwise the rename itself may be lost on
+	// crash and the previous snapshot comes back
+	dir, err := os.Open(filepath.Dir(s.path))
+	if err != nil {
+		return fmt.Errorf("unable to open snapshot directory due: %w", err)
+	}
+	err = dir.Sync()
+	if closeErr := dir.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to sync snapshot directory due: %w", err)
+	}
+	return nil
+}
+
+// Run saves the snapshot periodically until given context is done. The
+// failed save is only logged since it is retried on the next tick.
+func (s *Snapshotter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Save()
+			if err != nil {
+				log.Printf("[ERROR] unable to save memory snapshot due: %v", err)
+			}
+		}
+	}
+}
+
+type Config struct {
+	Path     string            `validate:"nonzero"`
+	Interval time.Duration     `validate:"min=1"`
+	Sources  map[string]Source `validate:"nonzero"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Snapshotter, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	s := &Snapshotter{
+		path:     cfg.Path,
+		interval: cfg.Interval,
+		sources:  cfg.Sources,
+	}
+	return s, nil
+}
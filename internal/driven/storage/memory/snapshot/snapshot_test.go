@@ -0,0 +1,176 @@
+package snapshot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Config snapshot.Config
+		ExpErr bool
+	}{
+		{
+			Name:   "Empty Config",
+			Config: snapshot.Config{},
+			ExpErr: true,
+		},
+		{
+			Name: "Missing Sources",
+			Config: snapshot.Config{
+				Path:     "snapshot.json",
+				Interval: time.Minute,
+			},
+			ExpErr: true,
+		},
+		{
+			Name: "Valid Config",
+			Config: snapshot.Config{
+				Path:     "snapshot.json",
+				Interval: time.Minute,
+				Sources:  map[string]snapshot.Source{"games": &mockSource{}},
+			},
+			ExpErr: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			_, err := snapshot.New(testCase.Config)
+			require.Equal(t, testCase.ExpErr, err != nil, "unexpected error")
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	games := &mockSource{data: []byte(`["game_1"]`)}
+	battles := &mockSource{data: []byte(`["battle_1"]`)}
+	snapshotter := newSnapshotter(t, path, map[string]snapshot.Source{"games": games, "battles": battles})
+
+	// loading missing snapshot should leave the sources untouched
+	err := snapshotter.Load()
+	require.NoError(t, err)
+	require.Equal(t, `["game_1"]`, string(games.data), "games is modified")
+
+	// save the snapshot, no temporary file should be left behind
+	err = snapshotter.Save()
+	require.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "unexpected files")
+
+	// the sources should be restored from the snapshot, the source missing
+	// from the snapshot should be left untouched
+	restoredGames := &mockSource{}
+	meetups := &mockSource{data: []byte(`["meetup_1"]`)}
+	snapshotter = newSnapshotter(t, path, map[string]snapshot.Source{"games": restoredGames, "meetups": meetups})
+	err = snapshotter.Load()
+	require.NoError(t, err)
+	require.Equal(t, `["game_1"]`, string(restoredGames.data), "mismatch games")
+	require.Equal(t, `["meetup_1"]`, string(meetups.data), "meetups is modified")
+}
+
+func TestSaveKeepsPreviousSnapshotOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	games := &mockSource{data: []byte(`["game_1"]`)}
+	snapshotter := newSnapshotter(t, path, map[string]snapshot.Source{"games": games})
+	err := snapshotter.Save()
+	require.NoError(t, err)
+
+	// the failed save should not touch the previous snapshot
+	games.setErr(errors.New("intentional error"))
+	err = snapshotter.Save()
+	require.Error(t, err)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var snapshotData map[string]json.RawMessage
+	err = json.Unmarshal(b, &snapshotData)
+	require.NoError(t, err)
+	require.Equal(t, `["game_1"]`, string(snapshotData["games"]), "mismatch games")
+}
+
+func TestRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	snapshotter, err := snapshot.New(snapshot.Config{
+		Path:     path,
+		Interval: 10 * time.Millisecond,
+		Sources:  map[string]snapshot.Source{"games": &mockSource{data: []byte(`[]`)}},
+	})
+	require.NoError(t, err)
+
+	// the snapshot should be saved periodically until the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		snapshotter.Run(ctx)
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond, "snapshot is not saved")
+	cancel()
+	<-done
+}
+
+func TestLoadInvalidSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	err := os.WriteFile(path, []byte(`invalid`), 0644)
+	require.NoError(t, err)
+
+	snapshotter := newSnapshotter(t, path, map[string]snapshot.Source{"games": &mockSource{}})
+	err = snapshotter.Load()
+	require.Error(t, err)
+}
+
+func newSnapshotter(t *testing.T, path string, sources map[string]snapshot.Source) *snapshot.Snapshotter {
+	snapshotter, err := snapshot.New(snapshot.Config{
+		Path:     path,
+		Interval: time.Minute,
+		Sources:  sources,
+	})
+	require.NoError(t, err)
+	return snapshotter
+}
+
+type mockSource struct {
+	mtx  sync.Mutex
+	data []byte
+	err  error
+}
+
+func (s *mockSource) setErr(err error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.err = err
+}
+
+func (s *mockSource) MarshalSnapshot() ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.data, nil
+}
+
+func (s *mockSource) UnmarshalSnapshot(data []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data = data
+	return nil
+}
@@ -6,14 +6,33 @@ type userRow struct {
 	ID       int    `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
+	// Password is either bcrypt/argon2id hash or plaintext password, the
+	// plaintext is still accepted for backward compatibility
 	Password string `json:"password"`
 }
 
-func (r userRow) toUser() entity.User {
-	return entity.User{
-		ID:       r.ID,
-		Username: r.Username,
-		Email:    r.Email,
-		Password: r.Password,
+func toUserRow(u entity.User) userRow {
+	return userRow{
+		ID:       u.ID,
+		Username: u.Username,
+		Email:    u.Email,
+		Password: u.PasswordHash,
 	}
 }
+
+func (r userRow) toUser() (*entity.User, error) {
+	passwordHash := r.Password
+	if !entity.IsPasswordHash(passwordHash) {
+		hash, err := entity.HashPassword(r.Password)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = hash
+	}
+	return &entity.User{
+		ID:           r.ID,
+		Username:     r.Username,
+		Email:        r.Email,
+		PasswordHash: passwordHash,
+	}, nil
+}
@@ -4,19 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
 	"gopkg.in/validator.v2"
 )
 
 type Storage struct {
-	data map[int]entity.User
+	mtx    sync.RWMutex
+	data   map[int]entity.User
+	lastID int
 }
 
-// GetUser implements session.UserStorage.
-func (s *Storage) GetUser(ctx context.Context, username string, password string) (*entity.User, error) {
+// GetUserByUsername implements session.UserStorage & user.UserStorage.
+func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
 	for _, user := range s.data {
-		if user.Username == username && user.Password == password {
+		if user.Username == username {
 			return &user, nil
 		}
 	}
@@ -24,8 +32,37 @@ func (s *Storage) GetUser(ctx context.Context, username string, password string)
 	return nil, nil
 }
 
-// GetUsers implements user.UserStorage.
+// GetUserByEmail implements user.UserStorage.
+func (s *Storage) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	for _, user := range s.data {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetUserByID implements session.UserStorage & user.UserStorage.
+func (s *Storage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	user, ok := s.data[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+// GetUsers returns all users in storage.
 func (s *Storage) GetUsers(ctx context.Context) ([]entity.User, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
 	var users []entity.User
 	for _, user := range s.data {
 		users = append(users, user)
@@ -33,6 +70,86 @@ func (s *Storage) GetUsers(ctx context.Context) ([]entity.User, error) {
 	return users, nil
 }
 
+// CreateUser implements user.UserStorage.
+func (s *Storage) CreateUser(ctx context.Context, u entity.User) (int, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	err := s.validateUniqueness(u)
+	if err != nil {
+		return 0, err
+	}
+	s.lastID++
+	u.ID = s.lastID
+	s.data[u.ID] = u
+
+	return u.ID, nil
+}
+
+// UpdateUser implements user.UserStorage.
+func (s *Storage) UpdateUser(ctx context.Context, u entity.User) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if _, ok := s.data[u.ID]; !ok {
+		return fmt.Errorf("user %v is not found", u.ID)
+	}
+	err := s.validateUniqueness(u)
+	if err != nil {
+		return err
+	}
+	s.data[u.ID] = u
+
+	return nil
+}
+
+// MarshalSnapshot implements snapshot.Source.
+func (s *Storage) MarshalSnapshot() ([]byte, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	rows := []userRow{}
+	for _, user := range s.data {
+		rows = append(rows, toUserRow(user))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].ID < rows[j].ID
+	})
+	return json.Marshal(rows)
+}
+
+// UnmarshalSnapshot implements snapshot.Source.
+func (s *Storage) UnmarshalSnapshot(data []byte) error {
+	restored, err := parseUserData(data)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data = restored.data
+	s.lastID = restored.lastID
+	return nil
+}
+
+// validateUniqueness makes sure username & email of given user are not used
+// by other user, the caller must hold the lock.
+func (s *Storage) validateUniqueness(u entity.User) error {
+	for _, other := range s.data {
+		if other.ID == u.ID {
+			continue
+		}
+		if other.Username == u.Username {
+			return user.ErrUsernameTaken
+		}
+		if other.Email == u.Email {
+			return user.ErrEmailTaken
+		}
+	}
+	return nil
+}
+
 type Config struct {
 	UserData []byte `validate:"nonzero"`
 }
@@ -48,15 +165,30 @@ func New(cfg Config) (*Storage, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 	// parse user data
+	return parseUserData(cfg.UserData)
+}
+
+// parseUserData returns new storage holding users encoded in JSON.
+func parseUserData(userData []byte) (*Storage, error) {
 	var rows []userRow
-	err = json.Unmarshal(cfg.UserData, &rows)
+	err := json.Unmarshal(userData, &rows)
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse user data due: %w", err)
 	}
-	data := map[int]entity.User{}
+	s := &Storage{data: map[int]entity.User{}}
 	for _, userRow := range rows {
-		user := userRow.toUser()
-		data[user.ID] = user
+		user, err := userRow.toUser()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse user %v due: %w", userRow.ID, err)
+		}
+		err = s.validateUniqueness(*user)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user %v due: %w", userRow.ID, err)
+		}
+		s.data[user.ID] = *user
+		if user.ID > s.lastID {
+			s.lastID = user.ID
+		}
 	}
-	return &Storage{data: data}, nil
+	return s, nil
 }
@@ -0,0 +1,156 @@
+package userstrg_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/userstrg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHashesPlaintextPassword(t *testing.T) {
+	// the first user uses plaintext password, the second uses bcrypt hash of `123456`
+	userData := []byte(`[
+		{"id": 1, "username": "marion", "email": "marion@eveners.com", "password": "123456"},
+		{"id": 2, "username": "todd", "email": "todd@eveners.com", "password": "$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO"}
+	]`)
+	strg, err := userstrg.New(userstrg.Config{UserData: userData})
+	require.NoError(t, err)
+
+	for _, username := range []string{"marion", "todd"} {
+		user, err := strg.GetUserByUsername(context.Background(), username)
+		require.NoError(t, err)
+		require.NotNil(t, user, "user is nil")
+		require.NotEqual(t, "123456", user.PasswordHash, "password is stored as plaintext")
+		require.True(t, user.IsPasswordMatch("123456"), "password is not match")
+	}
+
+	// hashed password should be kept as it is
+	user, err := strg.GetUserByUsername(context.Background(), "todd")
+	require.NoError(t, err)
+	require.Equal(t, "$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO", user.PasswordHash)
+
+	// unknown user
+	user, err = strg.GetUserByUsername(context.Background(), "unknown")
+	require.NoError(t, err)
+	require.Nil(t, user, "user is not nil")
+}
+
+func TestNewRejectsDuplicateUser(t *testing.T) {
+	userData := []byte(`[
+		{"id": 1, "username": "marion", "email": "marion@eveners.com", "password": "123456"},
+		{"id": 2, "username": "marion", "email": "todd@eveners.com", "password": "123456"}
+	]`)
+	_, err := userstrg.New(userstrg.Config{UserData: userData})
+	require.ErrorIs(t, err, user.ErrUsernameTaken)
+}
+
+func TestCreateUpdateUser(t *testing.T) {
+	userData := []byte(`[
+		{"id": 5, "username": "marion", "email": "marion@eveners.com", "password": "123456"}
+	]`)
+	strg, err := userstrg.New(userstrg.Config{UserData: userData})
+	require.NoError(t, err)
+
+	// create user, the id should continue from the seed data
+	newUser := entity.User{Username: "todd", Email: "todd@eveners.com", PasswordHash: "hash"}
+	id, err := strg.CreateUser(context.Background(), newUser)
+	require.NoError(t, err)
+	require.Equal(t, 6, id, "unexpected id")
+
+	newUser.ID = id
+	stored, err := strg.GetUserByEmail(context.Background(), newUser.Email)
+	require.NoError(t, err)
+	require.Equal(t, newUser, *stored, "mismatch user")
+
+	// create user with taken username & email
+	_, err = strg.CreateUser(context.Background(), entity.User{Username: "todd", Email: "other@eveners.com"})
+	require.ErrorIs(t, err, user.ErrUsernameTaken)
+	_, err = strg.CreateUser(context.Background(), entity.User{Username: "other", Email: "todd@eveners.com"})
+	require.ErrorIs(t, err, user.ErrEmailTaken)
+
+	// update user
+	newUser.Email = "todd.new@eveners.com"
+	err = strg.UpdateUser(context.Background(), newUser)
+	require.NoError(t, err)
+	stored, err = strg.GetUserByID(context.Background(), newUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, newUser, *stored, "mismatch user")
+
+	// update user using username of other user
+	newUser.Username = "marion"
+	err = strg.UpdateUser(context.Background(), newUser)
+	require.ErrorIs(t, err, user.ErrUsernameTaken)
+}
+
+func TestCreateUserConcurrently(t *testing.T) {
+	userData := []byte(`[
+		{"id": 1, "username": "marion", "email": "marion@eveners.com", "password": "123456"}
+	]`)
+	strg, err := userstrg.New(userstrg.Config{UserData: userData})
+	require.NoError(t, err)
+
+	// create users while other goroutines read them, every user should get
+	// unique id
+	var wg sync.WaitGroup
+	ids := make(chan int, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			newUser := entity.User{
+				Username: fmt.Sprintf("user%v", i),
+				Email:    fmt.Sprintf("user%v@eveners.com", i),
+			}
+			id, err := strg.CreateUser(context.Background(), newUser)
+			require.NoError(t, err)
+			ids <- id
+			_, err = strg.GetUserByUsername(context.Background(), "marion")
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+	close(ids)
+
+	uniqueIDs := map[int]bool{}
+	for id := range ids {
+		uniqueIDs[id] = true
+	}
+	require.Len(t, uniqueIDs, 20, "mismatch number of unique ids")
+}
+
+func TestSnapshot(t *testing.T) {
+	userData := []byte(`[
+		{"id": 1, "username": "marion", "email": "marion@eveners.com", "password": "123456"}
+	]`)
+	strg, err := userstrg.New(userstrg.Config{UserData: userData})
+	require.NoError(t, err)
+	passwordHash, err := entity.HashPassword("123456")
+	require.NoError(t, err)
+	_, err = strg.CreateUser(context.Background(), entity.User{Username: "todd", Email: "todd@eveners.com", PasswordHash: passwordHash})
+	require.NoError(t, err)
+	expUsers, err := strg.GetUsers(context.Background())
+	require.NoError(t, err)
+
+	// restore the snapshot into storage seeded with other users
+	data, err := strg.MarshalSnapshot()
+	require.NoError(t, err)
+	restored, err := userstrg.New(userstrg.Config{UserData: []byte(`[{"id": 9, "username": "other", "email": "other@eveners.com", "password": "123456"}]`)})
+	require.NoError(t, err)
+	err = restored.UnmarshalSnapshot(data)
+	require.NoError(t, err)
+
+	// the password hashes should be kept as they are
+	users, err := restored.GetUsers(context.Background())
+	require.NoError(t, err)
+	require.ElementsMatch(t, expUsers, users, "mismatch users")
+
+	// the id should continue from the restored users
+	id, err := restored.CreateUser(context.Background(), entity.User{Username: "other", Email: "other@eveners.com"})
+	require.NoError(t, err)
+	require.Equal(t, 3, id, "unexpected id")
+}
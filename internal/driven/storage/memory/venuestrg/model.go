@@ -0,0 +1,76 @@
+package venuestrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+type venueRow struct {
+	// ID is optional, venues without id are numbered by their position
+	ID              int                 `json:"id"`
+	Name            string              `json:"name"`
+	OpenDays        []int               `json:"open_days"`
+	OpenAt          string              `json:"open_at"`
+	ClosedAt        string              `json:"closed_at"`
+	TimeZone        string              `json:"timezone"`
+	WeekdayHours    []weekdayHoursRow   `json:"weekday_hours"`
+	Closures        []closureRow        `json:"closures"`
+	SupportedEvents []supportedEventRow `json:"supported_events"`
+}
+
+func toVenueRow(v entity.Venue) venueRow {
+	row := venueRow{
+		ID:       v.ID,
+		Name:     v.Name,
+		OpenDays: v.OpenDays,
+		OpenAt:   v.OpenAt,
+		ClosedAt: v.ClosedAt,
+		TimeZone: v.TimeZone,
+	}
+	for _, hours := range v.WeekdayHours {
+		row.WeekdayHours = append(row.WeekdayHours, weekdayHoursRow(hours))
+	}
+	for _, closure := range v.Closures {
+		row.Closures = append(row.Closures, closureRow(closure))
+	}
+	for _, supportedEvent := range v.SupportedEvents {
+		row.SupportedEvents = append(row.SupportedEvents, supportedEventRow(supportedEvent))
+	}
+	return row
+}
+
+func (r venueRow) toVenue() entity.Venue {
+	venue := entity.Venue{
+		ID:       r.ID,
+		Name:     r.Name,
+		OpenDays: r.OpenDays,
+		OpenAt:   r.OpenAt,
+		ClosedAt: r.ClosedAt,
+		TimeZone: r.TimeZone,
+	}
+	for _, hours := range r.WeekdayHours {
+		venue.WeekdayHours = append(venue.WeekdayHours, entity.WeekdayHours(hours))
+	}
+	for _, closure := range r.Closures {
+		venue.Closures = append(venue.Closures, entity.VenueClosure(closure))
+	}
+	for _, supportedEvent := range r.SupportedEvents {
+		venue.SupportedEvents = append(venue.SupportedEvents, entity.SupportedEvent(supportedEvent))
+	}
+	return venue
+}
+
+type weekdayHoursRow struct {
+	Day      int    `json:"day"`
+	OpenAt   string `json:"open_at"`
+	ClosedAt string `json:"closed_at"`
+}
+
+type closureRow struct {
+	StartTs int    `json:"start_ts"`
+	EndTs   int    `json:"end_ts"`
+	Reason  string `json:"reason"`
+}
+
+type supportedEventRow struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	EventCapacity int    `json:"event_capacity"`
+}
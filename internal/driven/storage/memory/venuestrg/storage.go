@@ -0,0 +1,145 @@
+package venuestrg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"gopkg.in/validator.v2"
+)
+
+type Storage struct {
+	mtx  sync.RWMutex
+	data map[int]entity.Venue
+}
+
+// GetVenues implements venue.VenueStorage.
+func (s *Storage) GetVenues(ctx context.Context) ([]entity.Venue, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	var venues []entity.Venue
+	for _, venue := range s.data {
+		venues = append(venues, copyVenue(venue))
+	}
+	sort.Slice(venues, func(i, j int) bool {
+		return venues[i].ID < venues[j].ID
+	})
+	return venues, nil
+}
+
+// GetVenue implements venue.VenueStorage & meetup.VenueStorage.
+func (s *Storage) GetVenue(ctx context.Context, venueID int) (*entity.Venue, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	venue, ok := s.data[venueID]
+	if !ok {
+		return nil, nil
+	}
+	venue = copyVenue(venue)
+	return &venue, nil
+}
+
+// AddVenueClosure implements venue.VenueStorage.
+func (s *Storage) AddVenueClosure(ctx context.Context, venueID int, closure entity.VenueClosure) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	venue, ok := s.data[venueID]
+	if !ok {
+		return fmt.Errorf("venue %v is not found", venueID)
+	}
+	// copy the venue so the closures slice is not shared with the venues
+	// previously returned to the callers
+	venue = copyVenue(venue)
+	venue.Closures = append(venue.Closures, closure)
+	s.data[venueID] = venue
+
+	return nil
+}
+
+// MarshalSnapshot implements snapshot.Source.
+func (s *Storage) MarshalSnapshot() ([]byte, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	rows := []venueRow{}
+	for _, venue := range s.data {
+		rows = append(rows, toVenueRow(venue))
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].ID < rows[j].ID
+	})
+	return json.Marshal(rows)
+}
+
+// UnmarshalSnapshot implements snapshot.Source.
+func (s *Storage) UnmarshalSnapshot(data []byte) error {
+	venues, err := parseVenueData(data)
+	if err != nil {
+		return err
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.data = venues
+	return nil
+}
+
+// copyVenue returns a copy of given venue which shares no slices with it.
+func copyVenue(v entity.Venue) entity.Venue {
+	v.OpenDays = append([]int(nil), v.OpenDays...)
+	v.WeekdayHours = append([]entity.WeekdayHours(nil), v.WeekdayHours...)
+	v.Closures = append([]entity.VenueClosure(nil), v.Closures...)
+	v.SupportedEvents = append([]entity.SupportedEvent(nil), v.SupportedEvents...)
+	return v
+}
+
+type Config struct {
+	VenueData []byte `validate:"nonzero"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Storage, error) {
+	// validate config
+	err := cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	// parse venue data
+	data, err := parseVenueData(cfg.VenueData)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{data: data}, nil
+}
+
+// parseVenueData parses venues encoded in JSON, the venues without id are
+// numbered by their position.
+func parseVenueData(venueData []byte) (map[int]entity.Venue, error) {
+	var rows []venueRow
+	err := json.Unmarshal(venueData, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse venue data due: %w", err)
+	}
+	data := map[int]entity.Venue{}
+	for i, venueRow := range rows {
+		venue := venueRow.toVenue()
+		if venue.ID == 0 {
+			venue.ID = i + 1
+		}
+		if _, ok := data[venue.ID]; ok {
+			return nil, fmt.Errorf("duplicate venue %v", venue.ID)
+		}
+		data[venue.ID] = venue
+	}
+	return data, nil
+}
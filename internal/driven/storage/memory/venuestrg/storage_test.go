@@ -0,0 +1,133 @@
+package venuestrg_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/memory/venuestrg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNumbersVenuesWithoutID(t *testing.T) {
+	venueData := []byte(`[
+		{"name": "Si Jalak Harupat", "open_days": [0, 1], "open_at": "08:00", "closed_at": "23:59", "timezone": "Asia/Jakarta"},
+		{
+			"name": "Ice BSD",
+			"open_days": [6],
+			"open_at": "05:00",
+			"closed_at": "23:59",
+			"timezone": "Asia/Jakarta",
+			"supported_events": [{"id": 1, "name": "Wedding", "event_capacity": 2}]
+		}
+	]`)
+	strg, err := venuestrg.New(venuestrg.Config{VenueData: venueData})
+	require.NoError(t, err)
+
+	venues, err := strg.GetVenues(context.Background())
+	require.NoError(t, err)
+	expVenues := []entity.Venue{
+		{
+			ID:       1,
+			Name:     "Si Jalak Harupat",
+			OpenDays: []int{0, 1},
+			OpenAt:   "08:00",
+			ClosedAt: "23:59",
+			TimeZone: "Asia/Jakarta",
+		},
+		{
+			ID:              2,
+			Name:            "Ice BSD",
+			OpenDays:        []int{6},
+			OpenAt:          "05:00",
+			ClosedAt:        "23:59",
+			TimeZone:        "Asia/Jakarta",
+			SupportedEvents: []entity.SupportedEvent{{ID: 1, Name: "Wedding", EventCapacity: 2}},
+		},
+	}
+	require.Equal(t, expVenues, venues, "mismatch venues")
+}
+
+func TestNewRejectsDuplicateVenue(t *testing.T) {
+	venueData := []byte(`[
+		{"id": 1, "name": "Si Jalak Harupat"},
+		{"id": 1, "name": "Ice BSD"}
+	]`)
+	_, err := venuestrg.New(venuestrg.Config{VenueData: venueData})
+	require.Error(t, err)
+}
+
+func TestGetVenue(t *testing.T) {
+	venueData := []byte(`[{"id": 3, "name": "Ice BSD", "open_days": [0], "open_at": "05:00", "closed_at": "23:59", "timezone": "Asia/Jakarta"}]`)
+	strg, err := venuestrg.New(venuestrg.Config{VenueData: venueData})
+	require.NoError(t, err)
+
+	// modifying the returned venue should not affect the stored one
+	venue, err := strg.GetVenue(context.Background(), 3)
+	require.NoError(t, err)
+	require.NotNil(t, venue, "venue is nil")
+	venue.OpenDays[0] = 6
+
+	venue, err = strg.GetVenue(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, []int{0}, venue.OpenDays, "stored venue is modified")
+
+	// unknown venue
+	venue, err = strg.GetVenue(context.Background(), 4)
+	require.NoError(t, err)
+	require.Nil(t, venue, "venue is not nil")
+}
+
+func TestAddVenueClosure(t *testing.T) {
+	venueData := []byte(`[{"id": 3, "name": "Ice BSD", "open_days": [0], "open_at": "05:00", "closed_at": "23:59", "timezone": "Asia/Jakarta"}]`)
+	strg, err := venuestrg.New(venuestrg.Config{VenueData: venueData})
+	require.NoError(t, err)
+
+	before, err := strg.GetVenue(context.Background(), 3)
+	require.NoError(t, err)
+	require.NotNil(t, before, "venue is nil")
+
+	closure := entity.VenueClosure{StartTs: 1000, EndTs: 2000, Reason: "maintenance"}
+	err = strg.AddVenueClosure(context.Background(), 3, closure)
+	require.NoError(t, err)
+
+	after, err := strg.GetVenue(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, []entity.VenueClosure{closure}, after.Closures, "mismatch closures")
+
+	// the venue returned previously should not be affected
+	require.Empty(t, before.Closures, "previous venue is modified")
+
+	// unknown venue
+	err = strg.AddVenueClosure(context.Background(), 4, closure)
+	require.Error(t, err)
+	venue, err := strg.GetVenue(context.Background(), 4)
+	require.NoError(t, err)
+	require.Nil(t, venue, "venue is not nil")
+}
+
+func TestAddVenueClosureConcurrently(t *testing.T) {
+	venueData := []byte(`[{"id": 3, "name": "Ice BSD", "open_days": [0], "open_at": "05:00", "closed_at": "23:59", "timezone": "Asia/Jakarta"}]`)
+	strg, err := venuestrg.New(venuestrg.Config{VenueData: venueData})
+	require.NoError(t, err)
+
+	// none of the closures added concurrently should be lost
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			closure := entity.VenueClosure{StartTs: i * 1000, EndTs: i*1000 + 500, Reason: "maintenance"}
+			err := strg.AddVenueClosure(context.Background(), 3, closure)
+			require.NoError(t, err)
+			_, err = strg.GetVenue(context.Background(), 3)
+			require.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	venue, err := strg.GetVenue(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, venue.Closures, 20, "mismatch closures")
+}
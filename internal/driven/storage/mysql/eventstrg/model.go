@@ -0,0 +1,15 @@
+package eventstrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+type eventRow struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+func (r eventRow) toEvent() entity.Event {
+	return entity.Event{
+		ID:   r.ID,
+		Name: r.Name,
+	}
+}
@@ -0,0 +1,45 @@
+package eventstrg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/validator.v2"
+)
+
+type Storage struct {
+	sqlClient *sqlx.DB
+}
+
+type Config struct {
+	SQLClient *sqlx.DB `validate:"nonnil"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	s := &Storage{sqlClient: cfg.SQLClient}
+	return s, nil
+}
+
+func (s *Storage) GetEvents(ctx context.Context) ([]entity.Event, error) {
+	var rows []eventRow
+	query := `SELECT id, name FROM event ORDER BY id`
+	err := s.sqlClient.SelectContext(ctx, &rows, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	var events []entity.Event
+	for _, row := range rows {
+		events = append(events, row.toEvent())
+	}
+	return events, nil
+}
@@ -0,0 +1,41 @@
+package eventstrg_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/eventstrg"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/shared"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func TestGetEvents(t *testing.T) {
+	// initialize sql client
+	sqlClient, err := shared.NewTestSQLClient()
+	require.NoError(t, err)
+
+	// initialize storage
+	strg, err := eventstrg.New(eventstrg.Config{SQLClient: sqlClient})
+	require.NoError(t, err)
+
+	// insert events
+	var expEvents []entity.Event
+	for i := 0; i < 3; i++ {
+		event := entity.Event{Name: fmt.Sprintf("event_%v", uuid.NewString())}
+		event.ID, err = shared.InsertEvent(sqlClient, event)
+		require.NoError(t, err)
+		expEvents = append(expEvents, event)
+	}
+
+	// get events, the storage may contain events from other tests
+	events, err := strg.GetEvents(context.Background())
+	require.NoError(t, err)
+	for _, expEvent := range expEvents {
+		require.Contains(t, events, expEvent)
+	}
+}
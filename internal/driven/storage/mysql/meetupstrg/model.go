@@ -0,0 +1,95 @@
+package meetupstrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+type meetupRow struct {
+	ID                 int    `db:"id"`
+	Name               string `db:"name"`
+	VenueID            int    `db:"venue_id"`
+	VenueName          string `db:"venue_name"`
+	EventID            int    `db:"event_id"`
+	EventName          string `db:"event_name"`
+	StartTs            int    `db:"start_ts"`
+	EndTs              int    `db:"end_ts"`
+	MaxPersons         int    `db:"max_persons"`
+	OrganizerID        int    `db:"organizer_id"`
+	OrganizerUsername  string `db:"organizer_username"`
+	OrganizerEmail     string `db:"organizer_email"`
+	JoinedPersonsCount int    `db:"joined_persons_count"`
+	IsCancelled        bool   `db:"is_cancelled"`
+	CancelledReason    string `db:"cancelled_reason"`
+	CancelledAt        int64  `db:"cancelled_at"`
+	CancelledBy        int    `db:"cancelled_by"`
+}
+
+// toMeetup converts the row into meetup, the status is either cancelled or
+// open since the other statuses are derived by `Meetup.RefreshStatus()`.
+func (r meetupRow) toMeetup() entity.Meetup {
+	status := entity.MeetupStatusOpen
+	if r.IsCancelled {
+		status = entity.MeetupStatusCancelled
+	}
+	return entity.Meetup{
+		ID:   r.ID,
+		Name: r.Name,
+		Venue: entity.MeetupVenue{
+			ID:   r.VenueID,
+			Name: r.VenueName,
+		},
+		Event: entity.MeetupEvent{
+			ID:   r.EventID,
+			Name: r.EventName,
+		},
+		StartTs:    r.StartTs,
+		EndTs:      r.EndTs,
+		MaxPersons: r.MaxPersons,
+		Organizer: entity.MeetupOrganizer{
+			ID:       r.OrganizerID,
+			Username: r.OrganizerUsername,
+			Email:    r.OrganizerEmail,
+		},
+		JoinedPersonsCount: r.JoinedPersonsCount,
+		Status:             status,
+		CancelledReason:    r.CancelledReason,
+		CancelledAt:        r.CancelledAt,
+		CancelledBy:        r.CancelledBy,
+	}
+}
+
+func newMeetupRow(m entity.Meetup) meetupRow {
+	return meetupRow{
+		ID:                 m.ID,
+		Name:               m.Name,
+		VenueID:            m.Venue.ID,
+		VenueName:          m.Venue.Name,
+		EventID:            m.Event.ID,
+		EventName:          m.Event.Name,
+		StartTs:            m.StartTs,
+		EndTs:              m.EndTs,
+		MaxPersons:         m.MaxPersons,
+		OrganizerID:        m.Organizer.ID,
+		OrganizerUsername:  m.Organizer.Username,
+		OrganizerEmail:     m.Organizer.Email,
+		JoinedPersonsCount: m.JoinedPersonsCount,
+		CancelledReason:    m.CancelledReason,
+		CancelledAt:        m.CancelledAt,
+		CancelledBy:        m.CancelledBy,
+	}
+}
+
+type participantRow struct {
+	MeetupID int    `db:"meetup_id"`
+	UserID   int    `db:"user_id"`
+	Username string `db:"username"`
+	Email    string `db:"email"`
+	JoinedAt int    `db:"joined_at"`
+}
+
+func (r participantRow) toJoinedPerson() entity.JoinedPerson {
+	return entity.JoinedPerson{
+		ID:       r.UserID,
+		Username: r.Username,
+		Email:    r.Email,
+		JoinedAt: r.JoinedAt,
+	}
+}
@@ -0,0 +1,412 @@
+package meetupstrg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/validator.v2"
+)
+
+// selectMeetupQuery selects meetup rows, the meetup is cancelled when it has
+// cancellation record.
+const selectMeetupQuery = `
+	SELECT
+		m.id,
+		m.name,
+		m.venue_id,
+		m.venue_name,
+		m.event_id,
+		m.event_name,
+		m.start_ts,
+		m.end_ts,
+		m.max_persons,
+		m.organizer_id,
+		m.organizer_username,
+		m.organizer_email,
+		m.joined_persons_count,
+		c.meetup_id IS NOT NULL AS is_cancelled,
+		COALESCE(c.reason, '') AS cancelled_reason,
+		COALESCE(c.cancelled_at, 0) AS cancelled_at,
+		COALESCE(c.cancelled_by, 0) AS cancelled_by
+	FROM meetup m
+	LEFT JOIN meetup_cancellation c ON c.meetup_id = m.id
+`
+
+type Storage struct {
+	sqlClient *sqlx.DB
+}
+
+type Config struct {
+	SQLClient *sqlx.DB `validate:"nonnil"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	s := &Storage{sqlClient: cfg.SQLClient}
+	return s, nil
+}
+
+func (s *Storage) GetMeetups(ctx context.Context, query entity.MeetupQuery, now int64) ([]entity.Meetup, error) {
+	var conds []string
+	var args []interface{}
+	if query.EventID != 0 {
+		conds = append(conds, "m.event_id = ?")
+		args = append(args, query.EventID)
+	}
+	if query.VenueID != 0 {
+		conds = append(conds, "m.venue_id = ?")
+		args = append(args, query.VenueID)
+	}
+	if query.OrganizerID != 0 {
+		conds = append(conds, "m.organizer_id = ?")
+		args = append(args, query.OrganizerID)
+	}
+	if query.FromTs != 0 {
+		conds = append(conds, "m.start_ts >= ?")
+		args = append(args, query.FromTs)
+	}
+	if query.ToTs != 0 {
+		conds = append(conds, "m.start_ts < ?")
+		args = append(args, query.ToTs)
+	}
+	// the status is derived the same way as `Meetup.RefreshStatus()`
+	switch query.Status {
+	case entity.MeetupStatusCancelled:
+		conds = append(conds, "c.meetup_id IS NOT NULL")
+	case entity.MeetupStatusFinished:
+		conds = append(conds, "c.meetup_id IS NULL", "m.end_ts <= ?")
+		args = append(args, now)
+	case entity.MeetupStatusClosed:
+		conds = append(conds, "c.meetup_id IS NULL", "m.end_ts > ?", "m.joined_persons_count >= m.max_persons")
+		args = append(args, now)
+	case entity.MeetupStatusOpen:
+		conds = append(conds, "c.meetup_id IS NULL", "m.end_ts > ?", "m.joined_persons_count < m.max_persons")
+		args = append(args, now)
+	}
+	cursor, err := query.GetCursor()
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode cursor due: %w", err)
+	}
+	if cursor != nil {
+		conds = append(conds, "(m.start_ts > ? OR (m.start_ts = ? AND m.id > ?))")
+		args = append(args, cursor.StartTs, cursor.StartTs, cursor.ID)
+	}
+	args = append(args, query.GetLimit())
+	meetups, err := s.getMeetups(ctx, conds, "ORDER BY m.start_ts, m.id LIMIT ?", args...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range meetups {
+		meetups[i].RefreshStatus(now)
+	}
+	return meetups, nil
+}
+
+func (s *Storage) GetParticipantMeetups(ctx context.Context, userID int, startAfterTs int) ([]entity.Meetup, error) {
+	conds := []string{
+		"m.start_ts > ?",
+		"(m.organizer_id = ? OR EXISTS (SELECT 1 FROM meetup_participant p WHERE p.meetup_id = m.id AND p.user_id = ?))",
+	}
+	return s.getMeetups(ctx, conds, "ORDER BY m.start_ts, m.id", startAfterTs, userID, userID)
+}
+
+func (s *Storage) GetOverlappingMeetups(ctx context.Context, venueID, eventID, startTs, endTs int) ([]entity.Meetup, error) {
+	conds := []string{
+		"c.meetup_id IS NULL",
+		"m.venue_id = ?",
+		"m.event_id = ?",
+		"m.start_ts < ?",
+		"m.end_ts > ?",
+	}
+	return s.getMeetups(ctx, conds, "ORDER BY m.start_ts, m.id", venueID, eventID, endTs, startTs)
+}
+
+func (s *Storage) GetVenueMeetups(ctx context.Context, venueID, startTs, endTs int) ([]entity.Meetup, error) {
+	conds := []string{
+		"c.meetup_id IS NULL",
+		"m.venue_id = ?",
+		"m.start_ts < ?",
+		"m.end_ts > ?",
+	}
+	return s.getMeetups(ctx, conds, "ORDER BY m.start_ts, m.id", venueID, endTs, startTs)
+}
+
+func (s *Storage) SaveMeetup(ctx context.Context, meetup entity.Meetup) (int, error) {
+	query := `
+		INSERT INTO meetup (
+			name, venue_id, venue_name, event_id, event_name, start_ts, end_ts,
+			max_persons, organizer_id, organizer_username, organizer_email
+		) VALUES (
+			:name, :venue_id, :venue_name, :event_id, :event_name, :start_ts, :end_ts,
+			:max_persons, :organizer_id, :organizer_username, :organizer_email
+		)
+	`
+	result, err := s.sqlClient.NamedExecContext(ctx, query, newMeetupRow(meetup))
+	if err != nil {
+		return 0, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("unable to get last insert id due: %w", err)
+	}
+	return int(id), nil
+}
+
+func (s *Storage) GetMeetup(ctx context.Context, meetupID int) (*entity.Meetup, error) {
+	meetups, err := s.getMeetups(ctx, []string{"m.id = ?"}, "", meetupID)
+	if err != nil {
+		return nil, err
+	}
+	if len(meetups) == 0 {
+		return nil, nil
+	}
+	return &meetups[0], nil
+}
+
+func (s *Storage) UpdateMeetup(ctx context.Context, meetup entity.Meetup) (bool, error) {
+	tx, err := s.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("unable to begin transaction due: %w", err)
+	}
+	defer tx.Rollback()
+
+	// lock the meetup row so nobody could join the meetup until the update
+	// is committed
+	row, err := lockMeetup(ctx, tx, meetup.ID)
+	if err != nil {
+		return false, err
+	}
+	if row == nil || row.IsCancelled || meetup.MaxPersons < row.JoinedPersonsCount {
+		return false, nil
+	}
+	query := `
+		UPDATE meetup SET
+			name = :name,
+			start_ts = :start_ts,
+			end_ts = :end_ts,
+			max_persons = :max_persons
+		WHERE id = :id
+	`
+	_, err = tx.NamedExecContext(ctx, query, newMeetupRow(meetup))
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("unable to commit transaction due: %w", err)
+	}
+	return true, nil
+}
+
+// CancelMeetup returns `meetup.ErrMeetupCancelled` when the meetup is already
+// cancelled, the check is done under the row lock so concurrent cancellations
+// don't hit the primary key of `meetup_cancellation`.
+func (s *Storage) CancelMeetup(ctx context.Context, m entity.Meetup) error {
+	tx, err := s.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("unable to begin transaction due: %w", err)
+	}
+	defer tx.Rollback()
+
+	// lock the meetup row so nobody could join the meetup while it is being
+	// cancelled
+	row, err := lockMeetup(ctx, tx, m.ID)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return fmt.Errorf("meetup %v is not found", m.ID)
+	}
+	if row.IsCancelled {
+		return meetup.ErrMeetupCancelled
+	}
+	query := `
+		INSERT INTO meetup_cancellation (
+			meetup_id, reason, cancelled_at, cancelled_by
+		) VALUES (
+			:id, :cancelled_reason, :cancelled_at, :cancelled_by
+		)
+	`
+	_, err = tx.NamedExecContext(ctx, query, newMeetupRow(m))
+	if err != nil {
+		return fmt.Errorf("unable to execute query due: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("unable to commit transaction due: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) JoinMeetup(ctx context.Context, meetupID int, person entity.JoinedPerson) (bool, error) {
+	tx, err := s.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("unable to begin transaction due: %w", err)
+	}
+	defer tx.Rollback()
+
+	// lock the meetup row so the cancellation & capacity checks hold until
+	// the person is added
+	row, err := lockMeetup(ctx, tx, meetupID)
+	if err != nil {
+		return false, err
+	}
+	if row == nil || row.IsCancelled || row.JoinedPersonsCount >= row.MaxPersons {
+		return false, nil
+	}
+	var count int
+	query := `SELECT COUNT(*) FROM meetup_participant WHERE meetup_id = ? AND user_id = ?`
+	err = tx.GetContext(ctx, &count, query, meetupID, person.ID)
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	if count > 0 {
+		return false, nil
+	}
+	query = `
+		INSERT INTO meetup_participant (
+			meetup_id, user_id, username, email, joined_at
+		) VALUES (
+			?, ?, ?, ?, ?
+		)
+	`
+	_, err = tx.ExecContext(ctx, query, meetupID, person.ID, person.Username, person.Email, person.JoinedAt)
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	query = `UPDATE meetup SET joined_persons_count = joined_persons_count + 1 WHERE id = ?`
+	_, err = tx.ExecContext(ctx, query, meetupID)
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("unable to commit transaction due: %w", err)
+	}
+	return true, nil
+}
+
+func (s *Storage) LeaveMeetup(ctx context.Context, meetupID int, userID int) (bool, error) {
+	tx, err := s.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("unable to begin transaction due: %w", err)
+	}
+	defer tx.Rollback()
+
+	// the meetup row is locked first just like in join to keep the lock
+	// order consistent
+	row, err := lockMeetup(ctx, tx, meetupID)
+	if err != nil {
+		return false, err
+	}
+	if row == nil {
+		return false, nil
+	}
+	query := `DELETE FROM meetup_participant WHERE meetup_id = ? AND user_id = ?`
+	result, err := tx.ExecContext(ctx, query, meetupID, userID)
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("unable to get affected rows due: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	query = `UPDATE meetup SET joined_persons_count = joined_persons_count - 1 WHERE id = ?`
+	_, err = tx.ExecContext(ctx, query, meetupID)
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("unable to commit transaction due: %w", err)
+	}
+	return true, nil
+}
+
+// lockMeetup locks the meetup row with given id until the transaction ends
+// and returns its max persons, number of joined persons & cancellation state.
+// Returns nil when the meetup is not found.
+//
+// The meetup is cancelled only while its row is locked, so the cancellation
+// state returned here holds until the transaction ends.
+func lockMeetup(ctx context.Context, tx *sqlx.Tx, meetupID int) (*meetupRow, error) {
+	var row meetupRow
+	query := `
+		SELECT
+			m.max_persons,
+			m.joined_persons_count,
+			EXISTS(SELECT 1 FROM meetup_cancellation c WHERE c.meetup_id = m.id) AS is_cancelled
+		FROM meetup m
+		WHERE m.id = ?
+		FOR UPDATE
+	`
+	err := tx.GetContext(ctx, &row, query, meetupID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to lock meetup due: %w", err)
+	}
+	return &row, nil
+}
+
+// getMeetups returns meetups matching all of given conditions along with
+// their participants, the suffix is appended after the WHERE clause.
+func (s *Storage) getMeetups(ctx context.Context, conds []string, suffix string, args ...interface{}) ([]entity.Meetup, error) {
+	query := selectMeetupQuery
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " " + suffix
+	var rows []meetupRow
+	err := s.sqlClient.SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	meetups := make([]entity.Meetup, 0, len(rows))
+	meetupIDs := make([]int, 0, len(rows))
+	indexes := map[int]int{}
+	for i, row := range rows {
+		meetups = append(meetups, row.toMeetup())
+		meetupIDs = append(meetupIDs, row.ID)
+		indexes[row.ID] = i
+	}
+	// load the participants of all meetups at once
+	query, args, err = sqlx.In(`
+		SELECT p.meetup_id, p.user_id, p.username, p.email, p.joined_at
+		FROM meetup_participant p
+		WHERE p.meetup_id IN (?)
+		ORDER BY p.meetup_id, p.joined_at, p.user_id
+	`, meetupIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build participants query due: %w", err)
+	}
+	var participantRows []participantRow
+	err = s.sqlClient.SelectContext(ctx, &participantRows, s.sqlClient.Rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get participants due: %w", err)
+	}
+	for _, row := range participantRows {
+		meetup := &meetups[indexes[row.MeetupID]]
+		meetup.JoinedPersons = append(meetup.JoinedPersons, row.toJoinedPerson())
+	}
+	return meetups, nil
+}
@@ -0,0 +1,34 @@
+package sessionstrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+type refreshTokenRow struct {
+	ID        string `db:"id"`
+	FamilyID  string `db:"family_id"`
+	UserID    int    `db:"user_id"`
+	IssuedAt  int64  `db:"issued_at"`
+	ExpiresAt int64  `db:"expires_at"`
+	RotatedAt int64  `db:"rotated_at"`
+}
+
+func (r refreshTokenRow) toRefreshToken() *entity.RefreshToken {
+	return &entity.RefreshToken{
+		ID:        r.ID,
+		FamilyID:  r.FamilyID,
+		UserID:    r.UserID,
+		IssuedAt:  r.IssuedAt,
+		ExpiresAt: r.ExpiresAt,
+		RotatedAt: r.RotatedAt,
+	}
+}
+
+func newRefreshTokenRow(t entity.RefreshToken) refreshTokenRow {
+	return refreshTokenRow{
+		ID:        t.ID,
+		FamilyID:  t.FamilyID,
+		UserID:    t.UserID,
+		IssuedAt:  t.IssuedAt,
+		ExpiresAt: t.ExpiresAt,
+		RotatedAt: t.RotatedAt,
+	}
+}
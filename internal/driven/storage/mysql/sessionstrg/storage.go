@@ -0,0 +1,145 @@
+package sessionstrg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/validator.v2"
+)
+
+type Storage struct {
+	sqlClient *sqlx.DB
+}
+
+type Config struct {
+	SQLClient *sqlx.DB `validate:"nonnil"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	s := &Storage{sqlClient: cfg.SQLClient}
+	return s, nil
+}
+
+func (s *Storage) SaveRefreshToken(ctx context.Context, token entity.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_token (
+			id, family_id, user_id, issued_at, expires_at, rotated_at
+		) VALUES (
+			:id, :family_id, :user_id, :issued_at, :expires_at, :rotated_at
+		)
+	`
+	_, err := s.sqlClient.NamedExecContext(ctx, query, newRefreshTokenRow(token))
+	if err != nil {
+		return fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) GetRefreshToken(ctx context.Context, tokenID string) (*entity.RefreshToken, error) {
+	query := `SELECT * FROM refresh_token WHERE id = ?`
+	var row refreshTokenRow
+	if err := s.sqlClient.GetContext(ctx, &row, query, tokenID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return row.toRefreshToken(), nil
+}
+
+func (s *Storage) RotateRefreshToken(ctx context.Context, oldTokenID string, newToken entity.RefreshToken) (bool, error) {
+	tx, err := s.sqlClient.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("unable to begin transaction due: %w", err)
+	}
+	defer tx.Rollback()
+
+	// mark old token as rotated, the condition guarantees only single caller
+	// could rotate the token
+	query := `
+		UPDATE refresh_token SET rotated_at = ?
+		WHERE id = ? AND rotated_at = 0 AND family_id NOT IN (
+			SELECT family_id FROM revoked_refresh_token_family
+		)
+	`
+	result, err := tx.ExecContext(ctx, query, newToken.IssuedAt, oldTokenID)
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("unable to get affected rows due: %w", err)
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	// save new token
+	query = `
+		INSERT INTO refresh_token (
+			id, family_id, user_id, issued_at, expires_at, rotated_at
+		) VALUES (
+			:id, :family_id, :user_id, :issued_at, :expires_at, :rotated_at
+		)
+	`
+	_, err = tx.NamedExecContext(ctx, query, newRefreshTokenRow(newToken))
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		return false, fmt.Errorf("unable to commit transaction due: %w", err)
+	}
+	return true, nil
+}
+
+func (s *Storage) RevokeFamily(ctx context.Context, familyID string, revokedAt int64) error {
+	query := `INSERT IGNORE INTO revoked_refresh_token_family (family_id, revoked_at) VALUES (?, ?)`
+	_, err := s.sqlClient.ExecContext(ctx, query, familyID, revokedAt)
+	if err != nil {
+		return fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) IsFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	query := `SELECT COUNT(*) FROM revoked_refresh_token_family WHERE family_id = ?`
+	var count int
+	err := s.sqlClient.GetContext(ctx, &count, query, familyID)
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (s *Storage) RevokeAccessToken(ctx context.Context, tokenID string, exp int64) error {
+	query := `REPLACE INTO revoked_access_token (id, expires_at) VALUES (?, ?)`
+	_, err := s.sqlClient.ExecContext(ctx, query, tokenID, exp)
+	if err != nil {
+		return fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return nil
+}
+
+func (s *Storage) IsAccessTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	// expired tokens are rejected anyway, so they are not needed to be
+	// checked here
+	query := `SELECT COUNT(*) FROM revoked_access_token WHERE id = ? AND expires_at > ?`
+	var count int
+	err := s.sqlClient.GetContext(ctx, &count, query, tokenID, time.Now().Unix())
+	if err != nil {
+		return false, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return count > 0, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"github.com/Haraj-backend/hex-monscape/internal/core/testutil"
 	"github.com/jmoiron/sqlx"
 )
@@ -57,3 +58,73 @@ func InsertMonster(sqlClient *sqlx.DB, monsterRow MonsterRow) error {
 	}
 	return nil
 }
+
+// InsertEvent inserts given event and returns the id assigned to it.
+func InsertEvent(sqlClient *sqlx.DB, event entity.Event) (int, error) {
+	result, err := sqlClient.Exec(`INSERT INTO event (name) VALUES (?)`, event.Name)
+	if err != nil {
+		return 0, fmt.Errorf("unable to insert event due: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("unable to get event id due: %w", err)
+	}
+	return int(id), nil
+}
+
+// InsertUser inserts given user and returns the id assigned to it.
+func InsertUser(sqlClient *sqlx.DB, user entity.User) (int, error) {
+	query := `INSERT INTO user (username, email, password_hash) VALUES (?, ?, ?)`
+	result, err := sqlClient.Exec(query, user.Username, user.Email, user.PasswordHash)
+	if err != nil {
+		return 0, fmt.Errorf("unable to insert user due: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("unable to get user id due: %w", err)
+	}
+	return int(id), nil
+}
+
+// InsertVenue inserts given venue along with its open days, weekday hours,
+// closures & supported events, then returns the id assigned to it. The
+// supported events must be inserted beforehand.
+func InsertVenue(sqlClient *sqlx.DB, venue entity.Venue) (int, error) {
+	query := `INSERT INTO venue (name, open_at, closed_at, timezone) VALUES (?, ?, ?, ?)`
+	result, err := sqlClient.Exec(query, venue.Name, venue.OpenAt, venue.ClosedAt, venue.TimeZone)
+	if err != nil {
+		return 0, fmt.Errorf("unable to insert venue due: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("unable to get venue id due: %w", err)
+	}
+	for _, day := range venue.OpenDays {
+		_, err = sqlClient.Exec(`INSERT INTO venue_open_day (venue_id, day) VALUES (?, ?)`, id, day)
+		if err != nil {
+			return 0, fmt.Errorf("unable to insert venue open day due: %w", err)
+		}
+	}
+	for _, hours := range venue.WeekdayHours {
+		query = `INSERT INTO venue_weekday_hours (venue_id, day, open_at, closed_at) VALUES (?, ?, ?, ?)`
+		_, err = sqlClient.Exec(query, id, hours.Day, hours.OpenAt, hours.ClosedAt)
+		if err != nil {
+			return 0, fmt.Errorf("unable to insert venue weekday hours due: %w", err)
+		}
+	}
+	for _, closure := range venue.Closures {
+		query = `INSERT INTO venue_closure (venue_id, start_ts, end_ts, reason) VALUES (?, ?, ?, ?)`
+		_, err = sqlClient.Exec(query, id, closure.StartTs, closure.EndTs, closure.Reason)
+		if err != nil {
+			return 0, fmt.Errorf("unable to insert venue closure due: %w", err)
+		}
+	}
+	for _, supportedEvent := range venue.SupportedEvents {
+		query = `INSERT INTO venue_supported_event (venue_id, event_id, event_capacity) VALUES (?, ?, ?)`
+		_, err = sqlClient.Exec(query, id, supportedEvent.ID, supportedEvent.EventCapacity)
+		if err != nil {
+			return 0, fmt.Errorf("unable to insert venue supported event due: %w", err)
+		}
+	}
+	return int(id), nil
+}
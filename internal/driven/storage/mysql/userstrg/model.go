@@ -0,0 +1,28 @@
+package userstrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+type userRow struct {
+	ID           int    `db:"id"`
+	Username     string `db:"username"`
+	Email        string `db:"email"`
+	PasswordHash string `db:"password_hash"`
+}
+
+func (r userRow) toUser() *entity.User {
+	return &entity.User{
+		ID:           r.ID,
+		Username:     r.Username,
+		Email:        r.Email,
+		PasswordHash: r.PasswordHash,
+	}
+}
+
+func newUserRow(u entity.User) userRow {
+	return userRow{
+		ID:           u.ID,
+		Username:     u.Username,
+		Email:        u.Email,
+		PasswordHash: u.PasswordHash,
+	}
+}
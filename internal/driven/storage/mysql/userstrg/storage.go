@@ -0,0 +1,110 @@
+package userstrg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/validator.v2"
+)
+
+const errCodeDuplicateEntry = 1062
+
+type Storage struct {
+	sqlClient *sqlx.DB
+}
+
+type Config struct {
+	SQLClient *sqlx.DB `validate:"nonnil"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	s := &Storage{sqlClient: cfg.SQLClient}
+	return s, nil
+}
+
+func (s *Storage) GetUserByID(ctx context.Context, userID int) (*entity.User, error) {
+	return s.getUser(ctx, `SELECT * FROM user WHERE id = ?`, userID)
+}
+
+func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return s.getUser(ctx, `SELECT * FROM user WHERE username = ?`, username)
+}
+
+func (s *Storage) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return s.getUser(ctx, `SELECT * FROM user WHERE email = ?`, email)
+}
+
+func (s *Storage) getUser(ctx context.Context, query string, args ...interface{}) (*entity.User, error) {
+	var row userRow
+	if err := s.sqlClient.GetContext(ctx, &row, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return row.toUser(), nil
+}
+
+func (s *Storage) CreateUser(ctx context.Context, u entity.User) (int, error) {
+	query := `
+		INSERT INTO user (
+			username, email, password_hash
+		) VALUES (
+			:username, :email, :password_hash
+		)
+	`
+	result, err := s.sqlClient.NamedExecContext(ctx, query, newUserRow(u))
+	if err != nil {
+		return 0, toStorageError(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("unable to get last insert id due: %w", err)
+	}
+	return int(id), nil
+}
+
+func (s *Storage) UpdateUser(ctx context.Context, u entity.User) error {
+	query := `
+		UPDATE user SET
+			username = :username,
+			email = :email,
+			password_hash = :password_hash
+		WHERE id = :id
+	`
+	_, err := s.sqlClient.NamedExecContext(ctx, query, newUserRow(u))
+	if err != nil {
+		return toStorageError(err)
+	}
+	return nil
+}
+
+// toStorageError translates duplicate entry error on unique keys into
+// `user.ErrUsernameTaken` or `user.ErrEmailTaken`.
+func toStorageError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == errCodeDuplicateEntry {
+		switch {
+		case strings.Contains(mysqlErr.Message, "'username'"), strings.Contains(mysqlErr.Message, ".username'"):
+			return user.ErrUsernameTaken
+		case strings.Contains(mysqlErr.Message, "'email'"), strings.Contains(mysqlErr.Message, ".email'"):
+			return user.ErrEmailTaken
+		}
+	}
+	return fmt.Errorf("unable to execute query due: %w", err)
+}
@@ -0,0 +1,107 @@
+package userstrg_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/shared"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/userstrg"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func TestCreateGetUser(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// create user
+	expUser := newTestUser()
+	id, err := strg.CreateUser(context.Background(), expUser)
+	require.NoError(t, err)
+	expUser.ID = id
+
+	// get user by id, username, and email
+	u, err := strg.GetUserByID(context.Background(), expUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, expUser, *u)
+
+	u, err = strg.GetUserByUsername(context.Background(), expUser.Username)
+	require.NoError(t, err)
+	require.Equal(t, expUser, *u)
+
+	u, err = strg.GetUserByEmail(context.Background(), expUser.Email)
+	require.NoError(t, err)
+	require.Equal(t, expUser, *u)
+
+	// get unknown user
+	u, err = strg.GetUserByUsername(context.Background(), "unknown")
+	require.NoError(t, err)
+	require.Nil(t, u)
+
+	// create user with taken username & email
+	otherUser := newTestUser()
+	otherUser.Username = expUser.Username
+	_, err = strg.CreateUser(context.Background(), otherUser)
+	require.ErrorIs(t, err, user.ErrUsernameTaken)
+
+	otherUser = newTestUser()
+	otherUser.Email = expUser.Email
+	_, err = strg.CreateUser(context.Background(), otherUser)
+	require.ErrorIs(t, err, user.ErrEmailTaken)
+}
+
+func TestUpdateUser(t *testing.T) {
+	// initialize storage
+	strg := newStorage(t)
+
+	// create users
+	expUser := newTestUser()
+	id, err := strg.CreateUser(context.Background(), expUser)
+	require.NoError(t, err)
+	expUser.ID = id
+
+	otherUser := newTestUser()
+	_, err = strg.CreateUser(context.Background(), otherUser)
+	require.NoError(t, err)
+
+	// update user
+	newUser := newTestUser()
+	newUser.ID = expUser.ID
+	err = strg.UpdateUser(context.Background(), newUser)
+	require.NoError(t, err)
+
+	u, err := strg.GetUserByID(context.Background(), expUser.ID)
+	require.NoError(t, err)
+	require.Equal(t, newUser, *u)
+
+	// update user using username of other user
+	newUser.Username = otherUser.Username
+	err = strg.UpdateUser(context.Background(), newUser)
+	require.ErrorIs(t, err, user.ErrUsernameTaken)
+}
+
+func newTestUser() entity.User {
+	// use random suffix so the test could be executed repeatedly
+	suffix := uuid.NewString()[:8]
+	return entity.User{
+		Username:     "user_" + suffix,
+		Email:        "user_" + suffix + "@eveners.com",
+		PasswordHash: "$2a$10$jolzz9dxF9u4ffwQokLN1eXV0o3P2G.bqqx7haO5P1Iitgke6huqO",
+	}
+}
+
+func newStorage(t *testing.T) *userstrg.Storage {
+	// initialize sql client
+	sqlClient, err := shared.NewTestSQLClient()
+	require.NoError(t, err)
+
+	// initialize storage
+	strg, err := userstrg.New(userstrg.Config{SQLClient: sqlClient})
+	require.NoError(t, err)
+
+	return strg
+}
@@ -0,0 +1,80 @@
+package venuestrg
+
+import "github.com/Haraj-backend/hex-monscape/internal/core/entity"
+
+type venueRow struct {
+	ID       int    `db:"id"`
+	Name     string `db:"name"`
+	OpenAt   string `db:"open_at"`
+	ClosedAt string `db:"closed_at"`
+	TimeZone string `db:"timezone"`
+}
+
+func (r venueRow) toVenue() entity.Venue {
+	return entity.Venue{
+		ID:       r.ID,
+		Name:     r.Name,
+		OpenAt:   r.OpenAt,
+		ClosedAt: r.ClosedAt,
+		TimeZone: r.TimeZone,
+	}
+}
+
+type openDayRow struct {
+	VenueID int `db:"venue_id"`
+	Day     int `db:"day"`
+}
+
+type weekdayHoursRow struct {
+	VenueID  int    `db:"venue_id"`
+	Day      int    `db:"day"`
+	OpenAt   string `db:"open_at"`
+	ClosedAt string `db:"closed_at"`
+}
+
+func (r weekdayHoursRow) toWeekdayHours() entity.WeekdayHours {
+	return entity.WeekdayHours{
+		Day:      r.Day,
+		OpenAt:   r.OpenAt,
+		ClosedAt: r.ClosedAt,
+	}
+}
+
+type closureRow struct {
+	VenueID int    `db:"venue_id"`
+	StartTs int    `db:"start_ts"`
+	EndTs   int    `db:"end_ts"`
+	Reason  string `db:"reason"`
+}
+
+func (r closureRow) toVenueClosure() entity.VenueClosure {
+	return entity.VenueClosure{
+		StartTs: r.StartTs,
+		EndTs:   r.EndTs,
+		Reason:  r.Reason,
+	}
+}
+
+func newClosureRow(venueID int, c entity.VenueClosure) closureRow {
+	return closureRow{
+		VenueID: venueID,
+		StartTs: c.StartTs,
+		EndTs:   c.EndTs,
+		Reason:  c.Reason,
+	}
+}
+
+type supportedEventRow struct {
+	VenueID       int    `db:"venue_id"`
+	EventID       int    `db:"event_id"`
+	EventName     string `db:"event_name"`
+	EventCapacity int    `db:"event_capacity"`
+}
+
+func (r supportedEventRow) toSupportedEvent() entity.SupportedEvent {
+	return entity.SupportedEvent{
+		ID:            r.EventID,
+		Name:          r.EventName,
+		EventCapacity: r.EventCapacity,
+	}
+}
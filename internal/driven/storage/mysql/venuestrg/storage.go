@@ -0,0 +1,156 @@
+package venuestrg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/validator.v2"
+)
+
+type Storage struct {
+	sqlClient *sqlx.DB
+}
+
+type Config struct {
+	SQLClient *sqlx.DB `validate:"nonnil"`
+}
+
+func (c Config) Validate() error {
+	return validator.Validate(c)
+}
+
+func New(cfg Config) (*Storage, error) {
+	err := cfg.Validate()
+	if err != nil {
+		return nil, err
+	}
+	s := &Storage{sqlClient: cfg.SQLClient}
+	return s, nil
+}
+
+func (s *Storage) GetVenues(ctx context.Context) ([]entity.Venue, error) {
+	var rows []venueRow
+	query := `SELECT * FROM venue ORDER BY id`
+	err := s.sqlClient.SelectContext(ctx, &rows, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return s.loadVenues(ctx, rows)
+}
+
+func (s *Storage) GetVenue(ctx context.Context, venueID int) (*entity.Venue, error) {
+	var row venueRow
+	query := `SELECT * FROM venue WHERE id = ?`
+	err := s.sqlClient.GetContext(ctx, &row, query, venueID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to execute query due: %w", err)
+	}
+	venues, err := s.loadVenues(ctx, []venueRow{row})
+	if err != nil {
+		return nil, err
+	}
+	return &venues[0], nil
+}
+
+func (s *Storage) AddVenueClosure(ctx context.Context, venueID int, closure entity.VenueClosure) error {
+	query := `
+		INSERT INTO venue_closure (
+			venue_id, start_ts, end_ts, reason
+		) VALUES (
+			:venue_id, :start_ts, :end_ts, :reason
+		)
+	`
+	_, err := s.sqlClient.NamedExecContext(ctx, query, newClosureRow(venueID, closure))
+	if err != nil {
+		return fmt.Errorf("unable to execute query due: %w", err)
+	}
+	return nil
+}
+
+// loadVenues converts given rows into venues along with their open days,
+// weekday hours, closures & supported events.
+func (s *Storage) loadVenues(ctx context.Context, rows []venueRow) ([]entity.Venue, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	venueIDs := make([]int, 0, len(rows))
+	for _, row := range rows {
+		venueIDs = append(venueIDs, row.ID)
+	}
+
+	var openDayRows []openDayRow
+	query := `SELECT * FROM venue_open_day WHERE venue_id IN (?) ORDER BY venue_id, day`
+	err := s.selectIn(ctx, &openDayRows, query, venueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get open days due: %w", err)
+	}
+	var hoursRows []weekdayHoursRow
+	query = `SELECT * FROM venue_weekday_hours WHERE venue_id IN (?) ORDER BY venue_id, day`
+	err = s.selectIn(ctx, &hoursRows, query, venueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get weekday hours due: %w", err)
+	}
+	var closureRows []closureRow
+	query = `
+		SELECT venue_id, start_ts, end_ts, reason
+		FROM venue_closure
+		WHERE venue_id IN (?)
+		ORDER BY venue_id, start_ts, id
+	`
+	err = s.selectIn(ctx, &closureRows, query, venueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get closures due: %w", err)
+	}
+	var eventRows []supportedEventRow
+	query = `
+		SELECT se.venue_id, se.event_id, e.name AS event_name, se.event_capacity
+		FROM venue_supported_event se
+		JOIN event e ON e.id = se.event_id
+		WHERE se.venue_id IN (?)
+		ORDER BY se.venue_id, se.event_id
+	`
+	err = s.selectIn(ctx, &eventRows, query, venueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get supported events due: %w", err)
+	}
+
+	venues := make([]entity.Venue, 0, len(rows))
+	indexes := map[int]int{}
+	for i, row := range rows {
+		venues = append(venues, row.toVenue())
+		indexes[row.ID] = i
+	}
+	for _, row := range openDayRows {
+		venue := &venues[indexes[row.VenueID]]
+		venue.OpenDays = append(venue.OpenDays, row.Day)
+	}
+	for _, row := range hoursRows {
+		venue := &venues[indexes[row.VenueID]]
+		venue.WeekdayHours = append(venue.WeekdayHours, row.toWeekdayHours())
+	}
+	for _, row := range closureRows {
+		venue := &venues[indexes[row.VenueID]]
+		venue.Closures = append(venue.Closures, row.toVenueClosure())
+	}
+	for _, row := range eventRows {
+		venue := &venues[indexes[row.VenueID]]
+		venue.SupportedEvents = append(venue.SupportedEvents, row.toSupportedEvent())
+	}
+	return venues, nil
+}
+
+// selectIn executes given query whose `IN (?)` clause is expanded with given
+// venue ids.
+func (s *Storage) selectIn(ctx context.Context, dest interface{}, query string, venueIDs []int) error {
+	query, args, err := sqlx.In(query, venueIDs)
+	if err != nil {
+		return err
+	}
+	return s.sqlClient.SelectContext(ctx, dest, s.sqlClient.Rebind(query), args...)
+}
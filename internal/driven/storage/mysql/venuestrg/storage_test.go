@@ -0,0 +1,107 @@
+package venuestrg_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/shared"
+	"github.com/Haraj-backend/hex-monscape/internal/driven/storage/mysql/venuestrg"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func TestGetVenue(t *testing.T) {
+	// initialize storage
+	sqlClient, strg := newStorage(t)
+
+	// insert venue
+	expVenue := newTestVenue(t, sqlClient)
+
+	// get venue
+	venue, err := strg.GetVenue(context.Background(), expVenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, expVenue, *venue)
+
+	// get venues
+	venues, err := strg.GetVenues(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, venues, expVenue)
+
+	// get unknown venue
+	venue, err = strg.GetVenue(context.Background(), -1)
+	require.NoError(t, err)
+	require.Nil(t, venue)
+}
+
+func TestAddVenueClosure(t *testing.T) {
+	// initialize storage
+	sqlClient, strg := newStorage(t)
+
+	// insert venue
+	expVenue := newTestVenue(t, sqlClient)
+
+	// add closure which starts before the existing one, the closures
+	// should be returned sorted by their start time
+	closure := entity.VenueClosure{
+		StartTs: 1000,
+		EndTs:   2000,
+		Reason:  "renovation",
+	}
+	err := strg.AddVenueClosure(context.Background(), expVenue.ID, closure)
+	require.NoError(t, err)
+	expVenue.Closures = append([]entity.VenueClosure{closure}, expVenue.Closures...)
+
+	venue, err := strg.GetVenue(context.Background(), expVenue.ID)
+	require.NoError(t, err)
+	require.Equal(t, expVenue, *venue)
+}
+
+func newStorage(t *testing.T) (*sqlx.DB, *venuestrg.Storage) {
+	// initialize sql client
+	sqlClient, err := shared.NewTestSQLClient()
+	require.NoError(t, err)
+
+	// initialize storage
+	strg, err := venuestrg.New(venuestrg.Config{SQLClient: sqlClient})
+	require.NoError(t, err)
+
+	return sqlClient, strg
+}
+
+func newTestVenue(t *testing.T, sqlClient *sqlx.DB) entity.Venue {
+	var supportedEvents []entity.SupportedEvent
+	for i := 1; i <= 2; i++ {
+		event := entity.Event{Name: fmt.Sprintf("event_%v", uuid.NewString())}
+		id, err := shared.InsertEvent(sqlClient, event)
+		require.NoError(t, err)
+		supportedEvents = append(supportedEvents, entity.SupportedEvent{
+			ID:            id,
+			Name:          event.Name,
+			EventCapacity: i,
+		})
+	}
+	venue := entity.Venue{
+		Name:     fmt.Sprintf("venue_%v", uuid.NewString()),
+		OpenDays: []int{0, 1, 2, 3, 4},
+		OpenAt:   "08:00",
+		ClosedAt: "22:00",
+		TimeZone: "Asia/Jakarta",
+		WeekdayHours: []entity.WeekdayHours{
+			{Day: 4, OpenAt: "13:00", ClosedAt: "22:00"},
+		},
+		Closures: []entity.VenueClosure{
+			{StartTs: 5000, EndTs: 6000, Reason: "public holiday"},
+		},
+		SupportedEvents: supportedEvents,
+	}
+	id, err := shared.InsertVenue(sqlClient, venue)
+	require.NoError(t, err)
+	venue.ID = id
+
+	return venue
+}
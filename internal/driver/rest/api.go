@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
@@ -15,10 +17,14 @@ import (
 	"github.com/go-chi/render"
 	"gopkg.in/validator.v2"
 
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/battle"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/event"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/play"
 	"github.com/Haraj-backend/hex-monscape/internal/core/service/session"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/user"
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/venue"
 )
 
 type APIConfig struct {
@@ -26,6 +32,15 @@ type APIConfig struct {
 	BattleService  battle.Service  `validate:"nonnil"`
 	EventService   event.Service   `validate:"nonnil"`
 	SessionService session.Service `validate:"nonnil"`
+	UserService    user.Service    `validate:"nonnil"`
+	VenueService   venue.Service   `validate:"nonnil"`
+	MeetupService  meetup.Service  `validate:"nonnil"`
+	// RateLimitStorage holds request rate state of the clients
+	RateLimitStorage RateLimitStorage `validate:"nonnil"`
+	Clock            Clock            `validate:"nonnil"`
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For header is
+	// used to resolve the client ip, when empty the header is ignored
+	TrustedProxies []*net.IPNet
 	IsWebEnabled   bool
 }
 
@@ -39,21 +54,33 @@ func NewAPI(cfg APIConfig) (*API, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 	a := &API{
-		playService:    cfg.PlayingService,
-		battleService:  cfg.BattleService,
-		eventService:   cfg.EventService,
-		sessionService: cfg.SessionService,
-		isWebEnabled:   cfg.IsWebEnabled,
+		playService:      cfg.PlayingService,
+		battleService:    cfg.BattleService,
+		eventService:     cfg.EventService,
+		sessionService:   cfg.SessionService,
+		userService:      cfg.UserService,
+		venueService:     cfg.VenueService,
+		meetupService:    cfg.MeetupService,
+		rateLimitStorage: cfg.RateLimitStorage,
+		clock:            cfg.Clock,
+		trustedProxies:   cfg.TrustedProxies,
+		isWebEnabled:     cfg.IsWebEnabled,
 	}
 	return a, nil
 }
 
 type API struct {
-	playService    play.Service
-	battleService  battle.Service
-	eventService   event.Service
-	sessionService session.Service
-	isWebEnabled   bool
+	playService      play.Service
+	battleService    battle.Service
+	eventService     event.Service
+	sessionService   session.Service
+	userService      user.Service
+	venueService     venue.Service
+	meetupService    meetup.Service
+	rateLimitStorage RateLimitStorage
+	clock            Clock
+	trustedProxies   []*net.IPNet
+	isWebEnabled     bool
 }
 
 func (a *API) GetHandler() http.Handler {
@@ -63,6 +90,7 @@ func (a *API) GetHandler() http.Handler {
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	r.Use(a.rateLimit)
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 
 	if a.isWebEnabled {
@@ -73,7 +101,34 @@ func (a *API) GetHandler() http.Handler {
 	r.Get("/health", a.serveHealthCheck)
 	r.Get("/partners", a.serveGetAvailablePartners)
 	r.Post("/session", a.serveCreateSession)
-	r.Get("/events", a.serveGetEvents)
+	r.Get("/session", a.serveGetSession)
+	r.Delete("/session", a.serveDeleteSession)
+	r.Post("/session/refresh", a.serveRefreshSession)
+	r.Post("/users", a.serveRegisterUser)
+	r.Group(func(r chi.Router) {
+		r.Use(a.authenticate)
+		r.Get("/events", a.serveGetEvents)
+		r.Get("/users/me", a.serveGetMe)
+		r.Put("/users/me", a.serveUpdateProfile)
+		r.Route("/venues", func(r chi.Router) {
+			r.Get("/", a.serveGetVenues)
+			r.Get("/{venue_id}", a.serveGetVenue)
+			r.Get("/{venue_id}/availability", a.serveGetVenueAvailability)
+			r.Post("/{venue_id}/closures", a.serveAddVenueClosure)
+		})
+		r.Route("/meetups", func(r chi.Router) {
+			r.Post("/", a.serveCreateMeetup)
+			r.Get("/", a.serveGetMeetups)
+			r.Get("/incoming", a.serveGetIncomingMeetups)
+			r.Route("/{meetup_id}", func(r chi.Router) {
+				r.Get("/", a.serveGetMeetup)
+				r.Put("/", a.serveUpdateMeetup)
+				r.Delete("/", a.serveCancelMeetup)
+				r.Put("/join", a.serveJoinMeetup)
+				r.Put("/leave", a.serveLeaveMeetup)
+			})
+		})
+	})
 	r.Route("/games", func(r chi.Router) {
 		r.Post("/", a.serveNewGame)
 		r.Route("/{game_id}", func(r chi.Router) {
@@ -153,7 +208,29 @@ func (a *API) serveCreateSession(w http.ResponseWriter, r *http.Request) {
 		render.Render(w, r, NewErrorResp(err))
 		return
 	}
-	session, err := a.sessionService.CreateSession(ctx, rb.Username, rb.Password)
+	sess, err := a.sessionService.CreateSession(ctx, rb.Username, rb.Password, a.getClientIP(r))
+	if err != nil {
+		var lockedErr *session.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			setRetryAfter(w, lockedErr.RetryAfter)
+			render.Render(w, r, NewErrorResp(NewLoginLockedError()))
+			return
+		}
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(sess))
+}
+
+func (a *API) serveGetSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accessToken, ok := getBearerToken(r)
+	if !ok {
+		render.Render(w, r, NewErrorResp(NewInvalidAccessTokenError()))
+		return
+	}
+	session, err := a.sessionService.GetSession(ctx, accessToken)
 	if err != nil {
 		handleServiceError(w, r, err)
 		return
@@ -161,6 +238,381 @@ func (a *API) serveCreateSession(w http.ResponseWriter, r *http.Request) {
 	render.Render(w, r, NewSuccessResp(session))
 }
 
+func (a *API) serveRefreshSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var rb refreshSessionReqBody
+	err := json.NewDecoder(r.Body).Decode(&rb)
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError(err.Error())))
+		return
+	}
+	err = rb.Validate()
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	session, err := a.sessionService.RefreshSession(ctx, rb.RefreshToken)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(session))
+}
+
+func (a *API) serveDeleteSession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	accessToken, ok := getBearerToken(r)
+	if !ok {
+		render.Render(w, r, NewErrorResp(NewInvalidAccessTokenError()))
+		return
+	}
+	err := a.sessionService.DeleteSession(ctx, accessToken)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(nil))
+}
+
+func (a *API) serveRegisterUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var rb registerUserReqBody
+	err := json.NewDecoder(r.Body).Decode(&rb)
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError(err.Error())))
+		return
+	}
+	err = rb.Validate()
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	user, err := a.userService.RegisterUser(ctx, entity.UserConfig{
+		Username: rb.Username,
+		Email:    rb.Email,
+		Password: rb.Password,
+	})
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newUserRespBody(*user)))
+}
+
+func (a *API) serveGetMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	user, err := a.userService.GetMe(ctx)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newUserRespBody(*user)))
+}
+
+func (a *API) serveUpdateProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var rb updateProfileReqBody
+	err := json.NewDecoder(r.Body).Decode(&rb)
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError(err.Error())))
+		return
+	}
+	user, err := a.userService.UpdateProfile(ctx, entity.UpdateProfileRequest{
+		Username:        rb.Username,
+		Email:           rb.Email,
+		CurrentPassword: rb.CurrentPassword,
+		NewPassword:     rb.NewPassword,
+	})
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newUserRespBody(*user)))
+}
+
+func (a *API) serveGetVenues(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var filter entity.VenueFilter
+	err := parseQueryInts(r, map[string]*int{
+		"event_id":        &filter.EventID,
+		"meetup_start_ts": &filter.MeetupStartTs,
+		"meetup_end_ts":   &filter.MeetupEndTs,
+	})
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	venues, err := a.venueService.GetVenues(ctx, filter)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newVenueRespBodies(venues)))
+}
+
+func (a *API) serveGetVenue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	venueID, err := strconv.Atoi(chi.URLParam(r, "venue_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("venue_id")))
+		return
+	}
+	venue, err := a.venueService.GetVenue(ctx, venueID)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newVenueRespBody(*venue)))
+}
+
+func (a *API) serveGetVenueAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	venueID, err := strconv.Atoi(chi.URLParam(r, "venue_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("venue_id")))
+		return
+	}
+	query := entity.AvailabilityQuery{VenueID: venueID}
+	err = parseQueryInts(r, map[string]*int{
+		"event_id":    &query.EventID,
+		"from":        &query.FromTs,
+		"to":          &query.ToTs,
+		"granularity": &query.Granularity,
+	})
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	availability, err := a.venueService.GetVenueAvailability(ctx, query)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newVenueAvailabilityRespBody(*availability)))
+}
+
+func (a *API) serveAddVenueClosure(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	venueID, err := strconv.Atoi(chi.URLParam(r, "venue_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("venue_id")))
+		return
+	}
+	var rb addVenueClosureReqBody
+	err = json.NewDecoder(r.Body).Decode(&rb)
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError(err.Error())))
+		return
+	}
+	err = rb.Validate()
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	resp, err := a.venueService.AddVenueClosure(ctx, venueID, entity.VenueClosure{
+		StartTs: rb.StartTs,
+		EndTs:   rb.EndTs,
+		Reason:  rb.Reason,
+	})
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newAddVenueClosureRespBody(*resp)))
+}
+
+func (a *API) serveCreateMeetup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var rb createMeetupReqBody
+	err := json.NewDecoder(r.Body).Decode(&rb)
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError(err.Error())))
+		return
+	}
+	err = rb.Validate()
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	meetup, err := a.meetupService.CreateMeetup(ctx, entity.CreateMeetupRequest{
+		Name:       rb.Name,
+		VenueID:    rb.VenueID,
+		EventID:    rb.EventID,
+		StartTs:    rb.StartTs,
+		EndTs:      rb.EndTs,
+		MaxPersons: rb.MaxPersons,
+	})
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newMeetupRespBody(*meetup)))
+}
+
+func (a *API) serveGetMeetups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := entity.MeetupQuery{
+		Cursor: r.URL.Query().Get("cursor"),
+		Status: entity.MeetupStatusOpen,
+	}
+	err := parseQueryInts(r, map[string]*int{
+		"limit":        &query.Limit,
+		"event_id":     &query.EventID,
+		"venue_id":     &query.VenueID,
+		"organizer_id": &query.OrganizerID,
+		"from":         &query.FromTs,
+		"to":           &query.ToTs,
+	})
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	// only open meetups are listed by default, `all` lifts the status filter
+	switch status := r.URL.Query().Get("status"); status {
+	case "":
+	case "all":
+		query.Status = ""
+	default:
+		query.Status = entity.MeetupStatus(status)
+	}
+	page, err := a.meetupService.GetMeetups(ctx, query)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newMeetupPageRespBody(*page)))
+}
+
+func (a *API) serveGetMeetup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	meetupID, err := strconv.Atoi(chi.URLParam(r, "meetup_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("meetup_id")))
+		return
+	}
+	meetup, err := a.meetupService.GetMeetup(ctx, meetupID)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newMeetupRespBody(*meetup)))
+}
+
+func (a *API) serveUpdateMeetup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	meetupID, err := strconv.Atoi(chi.URLParam(r, "meetup_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("meetup_id")))
+		return
+	}
+	var rb updateMeetupReqBody
+	err = json.NewDecoder(r.Body).Decode(&rb)
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError(err.Error())))
+		return
+	}
+	meetup, err := a.meetupService.UpdateMeetup(ctx, meetupID, entity.UpdateMeetupRequest{
+		Name:       rb.Name,
+		StartTs:    rb.StartTs,
+		EndTs:      rb.EndTs,
+		MaxPersons: rb.MaxPersons,
+	})
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newMeetupRespBody(*meetup)))
+}
+
+func (a *API) serveCancelMeetup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	meetupID, err := strconv.Atoi(chi.URLParam(r, "meetup_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("meetup_id")))
+		return
+	}
+	cancelledReason := strings.TrimSpace(r.URL.Query().Get("cancelled_reason"))
+	if len(cancelledReason) == 0 {
+		render.Render(w, r, NewErrorResp(NewCancelledReasonRequiredError()))
+		return
+	}
+	resp, err := a.meetupService.CancelMeetup(ctx, meetupID, cancelledReason)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(resp))
+}
+
+func (a *API) serveJoinMeetup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	meetupID, err := strconv.Atoi(chi.URLParam(r, "meetup_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("meetup_id")))
+		return
+	}
+	meetup, err := a.meetupService.JoinMeetup(ctx, meetupID)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newMeetupRespBody(*meetup)))
+}
+
+func (a *API) serveLeaveMeetup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	meetupID, err := strconv.Atoi(chi.URLParam(r, "meetup_id"))
+	if err != nil {
+		render.Render(w, r, NewErrorResp(NewBadRequestError("meetup_id")))
+		return
+	}
+	err = a.meetupService.LeaveMeetup(ctx, meetupID)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(nil))
+}
+
+func (a *API) serveGetIncomingMeetups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var filter entity.IncomingMeetupFilter
+	err := parseQueryIntLists(r, map[string]*[]int{
+		"event_ids": &filter.EventIDs,
+		"venue_ids": &filter.VenueIDs,
+	})
+	if err != nil {
+		render.Render(w, r, NewErrorResp(err))
+		return
+	}
+	if status := r.URL.Query().Get("status"); status != "all" {
+		filter.Status = entity.MeetupStatus(status)
+	}
+	meetups, err := a.meetupService.GetIncomingMeetups(ctx, filter)
+	if err != nil {
+		handleServiceError(w, r, err)
+		return
+	}
+	render.Render(w, r, NewSuccessResp(newMeetupRespBodies(meetups)))
+}
+
 func (a *API) serveNewGame(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -286,6 +738,64 @@ func handleServiceError(w http.ResponseWriter, r *http.Request, err error) {
 		err = NewPartnerNotFoundError()
 	case session.ErrInvalidCreds:
 		err = NewSessionInvalidCredsError()
+	case session.ErrInvalidAccessToken, entity.ErrMissingCaller:
+		err = NewInvalidAccessTokenError()
+	case session.ErrInvalidRefreshToken:
+		err = NewInvalidRefreshTokenError()
+	case user.ErrUserNotFound:
+		err = NewUserNotFoundError()
+	case user.ErrUsernameTaken:
+		err = NewUsernameTakenError()
+	case user.ErrEmailTaken:
+		err = NewEmailTakenError()
+	case user.ErrInvalidCurrentPassword:
+		err = NewInvalidCurrentPasswordError()
+	case entity.ErrInvalidUsername:
+		err = NewInvalidUsernameError()
+	case entity.ErrInvalidEmail:
+		err = NewInvalidEmailError()
+	case entity.ErrWeakPassword:
+		err = NewWeakPasswordError()
+	case venue.ErrVenueNotFound, meetup.ErrVenueNotFound:
+		err = NewVenueNotFoundError()
+	case meetup.ErrMeetupNotFound:
+		err = NewMeetupNotFoundError()
+	case meetup.ErrInvalidEvent, venue.ErrInvalidEvent:
+		err = NewInvalidEventError()
+	case meetup.ErrExceedVenueCapacity:
+		err = NewExceedVenueCapacityError()
+	case meetup.ErrVenueIsClosed:
+		err = NewVenueIsClosedError()
+	case meetup.ErrMeetupCancelled:
+		err = NewMeetupCancelledError()
+	case meetup.ErrMeetupFinished:
+		err = NewMeetupFinishedError()
+	case meetup.ErrMeetupStarted:
+		err = NewMeetupStartedError()
+	case meetup.ErrStartTimeInPast:
+		err = NewStartTimeInPastError()
+	case meetup.ErrMeetupClosed:
+		err = NewMeetupClosedError()
+	case meetup.ErrAlreadyJoined:
+		err = NewAlreadyJoinedError()
+	case meetup.ErrMeetupOverlaps:
+		err = NewMeetupOverlapsError()
+	case meetup.ErrUserNotParticipant:
+		err = NewUserNotParticipantError()
+	case meetup.ErrForbidden, venue.ErrForbidden:
+		err = NewForbiddenError()
+	case meetup.ErrMaxPersonsLessThanJoinedPersons:
+		err = NewMaxPersonsLessThanJoinedPersonsError()
+	case entity.ErrInvalidTimeRange:
+		err = NewInvalidTimeRangeError()
+	case entity.ErrInvalidGranularity:
+		err = NewInvalidGranularityError()
+	case entity.ErrInvalidLimit:
+		err = NewInvalidLimitError()
+	case entity.ErrInvalidCursor:
+		err = NewInvalidCursorError()
+	case entity.ErrInvalidStatus:
+		err = NewInvalidStatusError()
 	default:
 		err = NewInternalServerError(err.Error())
 	}
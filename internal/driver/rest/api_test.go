@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/service/meetup"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleServiceError(t *testing.T) {
+	// define test cases
+	testCases := []struct {
+		Name          string
+		Err           error
+		ExpStatusCode int
+		ExpErr        string
+	}{
+		{
+			Name:          "Meetup Closed",
+			Err:           meetup.ErrMeetupClosed,
+			ExpStatusCode: http.StatusConflict,
+			ExpErr:        "ERR_MEETUP_CLOSED",
+		},
+		{
+			Name:          "Meetup Overlaps",
+			Err:           meetup.ErrMeetupOverlaps,
+			ExpStatusCode: http.StatusConflict,
+			ExpErr:        "ERR_MEETUP_OVERLAPS",
+		},
+		{
+			Name:          "Start Time In Past",
+			Err:           meetup.ErrStartTimeInPast,
+			ExpStatusCode: http.StatusBadRequest,
+			ExpErr:        "ERR_START_TIME_IN_PAST",
+		},
+		{
+			Name:          "Unknown Error",
+			Err:           errors.New("unknown error"),
+			ExpStatusCode: http.StatusInternalServerError,
+			ExpErr:        "ERR_INTERNAL_ERROR",
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPut, "/meetups/1/join", nil)
+			handleServiceError(w, r, testCase.Err)
+
+			require.Equal(t, testCase.ExpStatusCode, w.Code, "mismatch status code")
+			var respBody RespBody
+			err := json.Unmarshal(w.Body.Bytes(), &respBody)
+			require.NoError(t, err)
+			require.False(t, respBody.OK, "response is ok")
+			require.Equal(t, testCase.ExpErr, respBody.Err, "mismatch error")
+		})
+	}
+}
@@ -79,3 +79,259 @@ func NewSessionInvalidCredsError() *Error {
 		Message:    "invalid username or password",
 	}
 }
+
+func NewInvalidAccessTokenError() *Error {
+	return &Error{
+		StatusCode: http.StatusUnauthorized,
+		Err:        "ERR_INVALID_ACCESS_TOKEN",
+		Message:    "Invalid access token",
+	}
+}
+
+func NewInvalidRefreshTokenError() *Error {
+	return &Error{
+		StatusCode: http.StatusUnauthorized,
+		Err:        "ERR_INVALID_REFRESH_TOKEN",
+		Message:    "Invalid refresh token",
+	}
+}
+
+func NewInvalidEventError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_EVENT",
+		Message:    "Event is not supported by the venue",
+	}
+}
+
+func NewExceedVenueCapacityError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_EXCEED_VENUE_CAPACITY",
+		Message:    "Venue capacity is full on the designated meetup time",
+	}
+}
+
+func NewVenueIsClosedError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_VENUE_IS_CLOSED",
+		Message:    "Venue is closed on the designated meetup time",
+	}
+}
+
+func NewUserNotFoundError() *Error {
+	return &Error{
+		StatusCode: http.StatusNotFound,
+		Err:        "ERR_USER_NOT_FOUND",
+		Message:    "User is not found",
+	}
+}
+
+func NewUsernameTakenError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_USERNAME_TAKEN",
+		Message:    "Username is already taken",
+	}
+}
+
+func NewEmailTakenError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_EMAIL_TAKEN",
+		Message:    "Email is already taken",
+	}
+}
+
+func NewInvalidCurrentPasswordError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_CURRENT_PASSWORD",
+		Message:    "Current password is invalid",
+	}
+}
+
+func NewInvalidUsernameError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_USERNAME",
+		Message:    "Username must be 3-30 characters of lowercase letters, digits, or underscore",
+	}
+}
+
+func NewInvalidEmailError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_EMAIL",
+		Message:    "Email is invalid",
+	}
+}
+
+func NewWeakPasswordError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_WEAK_PASSWORD",
+		Message:    "Password must be 8-72 characters and contain at least one letter and one digit",
+	}
+}
+
+func NewTooManyRequestsError() *Error {
+	return &Error{
+		StatusCode: http.StatusTooManyRequests,
+		Err:        "ERR_TOO_MANY_REQUESTS",
+		Message:    "Too many requests, please retry later",
+	}
+}
+
+func NewLoginLockedError() *Error {
+	return &Error{
+		StatusCode: http.StatusTooManyRequests,
+		Err:        "ERR_LOGIN_LOCKED",
+		Message:    "Too many failed login attempts, please retry later",
+	}
+}
+
+func NewVenueNotFoundError() *Error {
+	return &Error{
+		StatusCode: http.StatusNotFound,
+		Err:        "ERR_VENUE_NOT_FOUND",
+		Message:    "Venue is not found",
+	}
+}
+
+func NewMeetupNotFoundError() *Error {
+	return &Error{
+		StatusCode: http.StatusNotFound,
+		Err:        "ERR_MEETUP_NOT_FOUND",
+		Message:    "Meetup is not found",
+	}
+}
+
+func NewInvalidTimeRangeError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_TIME_RANGE",
+		Message:    "End time must be after start time",
+	}
+}
+
+func NewMeetupCancelledError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_MEETUP_CANCELLED",
+		Message:    "Meetup is cancelled",
+	}
+}
+
+func NewMeetupFinishedError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_MEETUP_FINISHED",
+		Message:    "Meetup is finished",
+	}
+}
+
+func NewMeetupStartedError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_MEETUP_STARTED",
+		Message:    "Meetup is started",
+	}
+}
+
+func NewStartTimeInPastError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_START_TIME_IN_PAST",
+		Message:    "Start time must be in the future",
+	}
+}
+
+func NewMeetupClosedError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_MEETUP_CLOSED",
+		Message:    "Meetup is closed",
+	}
+}
+
+func NewAlreadyJoinedError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_ALREADY_JOINED",
+		Message:    "User already joined the meetup",
+	}
+}
+
+func NewMeetupOverlapsError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_MEETUP_OVERLAPS",
+		Message:    "Meetup overlaps with other meetup that user already joined",
+	}
+}
+
+func NewUserNotParticipantError() *Error {
+	return &Error{
+		StatusCode: http.StatusForbidden,
+		Err:        "ERR_USER_NOT_PARTICIPANT",
+		Message:    "User is not a participant",
+	}
+}
+
+func NewForbiddenError() *Error {
+	return &Error{
+		StatusCode: http.StatusForbidden,
+		Err:        "ERR_FORBIDDEN",
+		Message:    "User is not authorized to access this resource",
+	}
+}
+
+func NewMaxPersonsLessThanJoinedPersonsError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_MAX_PERSONS_LESS_THAN_JOINED_PERSONS",
+		Message:    "Max persons is less than number of joined persons",
+	}
+}
+
+func NewCancelledReasonRequiredError() *Error {
+	return &Error{
+		StatusCode: http.StatusConflict,
+		Err:        "ERR_CANCELLED_REASON_REQUIRED",
+		Message:    "Cancelled reason is required",
+	}
+}
+
+func NewInvalidGranularityError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_GRANULARITY",
+		Message:    "Granularity must be at least 60 seconds and yield at most 1000 slots",
+	}
+}
+
+func NewInvalidLimitError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_LIMIT",
+		Message:    "Limit must be between 1 and 1000",
+	}
+}
+
+func NewInvalidCursorError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_CURSOR",
+		Message:    "Cursor is invalid",
+	}
+}
+
+func NewInvalidStatusError() *Error {
+	return &Error{
+		StatusCode: http.StatusBadRequest,
+		Err:        "ERR_INVALID_STATUS",
+		Message:    "Status is invalid",
+	}
+}
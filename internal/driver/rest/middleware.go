@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
+	"github.com/go-chi/render"
+)
+
+// authenticate is middleware that validates the bearer access token in the
+// `Authorization` header, then puts the identity of its owner into request
+// context so it could be read by core services.
+func (a *API) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		accessToken, ok := getBearerToken(r)
+		if !ok {
+			render.Render(w, r, NewErrorResp(NewInvalidAccessTokenError()))
+			return
+		}
+		caller, err := a.sessionService.Authenticate(ctx, accessToken)
+		if err != nil {
+			handleServiceError(w, r, err)
+			return
+		}
+		ctx = entity.NewCallerContext(ctx, *caller)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// getBearerToken returns access token from `Authorization: Bearer {access_token}`
+// header. Returns false when the header is missing or malformed.
+func getBearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, len(token) > 0
+}
+
+// rateLimit is middleware that limits the request rate of every client ip
+// using token bucket. It responds with `429 Too Many Requests` along with
+// `Retry-After` header when the client exceeds the limit.
+func (a *API) rateLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		ok, retryAfter, err := a.rateLimitStorage.TakeToken(ctx, a.getClientIP(r), a.clock.Now())
+		if err != nil {
+			render.Render(w, r, NewErrorResp(NewInternalServerError(err.Error())))
+			return
+		}
+		if !ok {
+			setRetryAfter(w, retryAfter)
+			render.Render(w, r, NewErrorResp(NewTooManyRequestsError()))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// getClientIP returns ip address of the client who sends the request.
+//
+// The X-Forwarded-For header is only honored when the request comes from
+// trusted proxy, otherwise any client could spoof its ip through the header.
+// The header is walked from the right since the rightmost entries are the
+// ones appended by our own proxies, the first untrusted entry is the client.
+func (a *API) getClientIP(r *http.Request) string {
+	clientIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		clientIP = r.RemoteAddr
+	}
+	if !a.isTrustedProxy(clientIP) {
+		return clientIP
+	}
+	var hops []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			// malformed entry, stop at the last hop we could trust
+			break
+		}
+		clientIP = hop
+		if !a.isTrustedProxy(hop) {
+			break
+		}
+	}
+	return clientIP
+}
+
+// isTrustedProxy returns true when the given ip belongs to trusted proxies.
+func (a *API) isTrustedProxy(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	for _, proxy := range a.trustedProxies {
+		if proxy.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
+
+// setRetryAfter sets `Retry-After` header in seconds, the value is rounded up
+// so the client won't retry too early.
+func setRetryAfter(w http.ResponseWriter, retryAfter time.Duration) {
+	secs := int(math.Ceil(retryAfter.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetClientIP(t *testing.T) {
+	_, proxies, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+	a := &API{trustedProxies: []*net.IPNet{proxies}}
+
+	// define test cases
+	testCases := []struct {
+		Name          string
+		RemoteAddr    string
+		XForwardedFor []string
+		ExpClientIP   string
+	}{
+		{
+			Name:        "Without Header",
+			RemoteAddr:  "203.0.113.7:1234",
+			ExpClientIP: "203.0.113.7",
+		},
+		{
+			Name:          "Header From Untrusted Peer",
+			RemoteAddr:    "203.0.113.7:1234",
+			XForwardedFor: []string{"198.51.100.1"},
+			ExpClientIP:   "203.0.113.7",
+		},
+		{
+			Name:          "Header From Trusted Peer",
+			RemoteAddr:    "10.0.0.1:1234",
+			XForwardedFor: []string{"198.51.100.1"},
+			ExpClientIP:   "198.51.100.1",
+		},
+		{
+			Name:          "Spoofed Entry Before Client",
+			RemoteAddr:    "10.0.0.1:1234",
+			XForwardedFor: []string{"192.0.2.9, 198.51.100.1, 10.0.0.2"},
+			ExpClientIP:   "198.51.100.1",
+		},
+		{
+			Name:          "Multiple Headers",
+			RemoteAddr:    "10.0.0.1:1234",
+			XForwardedFor: []string{"192.0.2.9", "198.51.100.1, 10.0.0.2"},
+			ExpClientIP:   "198.51.100.1",
+		},
+		{
+			Name:          "Malformed Entry",
+			RemoteAddr:    "10.0.0.1:1234",
+			XForwardedFor: []string{"198.51.100.1, unknown, 10.0.0.2"},
+			ExpClientIP:   "10.0.0.2",
+		},
+	}
+	// execute test cases
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/events", nil)
+			r.RemoteAddr = testCase.RemoteAddr
+			for _, value := range testCase.XForwardedFor {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+			require.Equal(t, testCase.ExpClientIP, a.getClientIP(r), "mismatch client ip")
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	// the timestamp is far from the wall clock, so the test fails when the
+	// middleware uses the wall clock instead of the injected one
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	strg := &mockRateLimitStorage{retryAfter: 1500 * time.Millisecond}
+	a := &API{rateLimitStorage: strg, clock: &mockClock{now: now}}
+	handler := a.rateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// the request is rejected with retry after rounded up
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusTooManyRequests, w.Code, "mismatch status code")
+	require.Equal(t, "2", w.Header().Get("Retry-After"), "mismatch retry after")
+	require.Equal(t, now, strg.takenAt, "mismatch token taken time")
+}
+
+type mockRateLimitStorage struct {
+	retryAfter time.Duration
+	takenAt    time.Time
+}
+
+func (s *mockRateLimitStorage) TakeToken(ctx context.Context, key string, now time.Time) (bool, time.Duration, error) {
+	s.takenAt = now
+	return false, s.retryAfter, nil
+}
+
+type mockClock struct {
+	now time.Time
+}
+
+func (c *mockClock) Now() time.Time {
+	return c.now
+}
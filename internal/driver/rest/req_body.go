@@ -1,6 +1,13 @@
 package rest
 
-import "gopkg.in/validator.v2"
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/validator.v2"
+)
 
 type newGameReqBody struct {
 	PlayerName string `json:"player_name" validate:"nonzero"`
@@ -27,3 +34,124 @@ func (rb newSessionReqBody) Validate() error {
 	}
 	return nil
 }
+
+type refreshSessionReqBody struct {
+	RefreshToken string `json:"refresh_token" validate:"nonzero"`
+}
+
+func (rb refreshSessionReqBody) Validate() error {
+	err := validator.Validate(rb)
+	if err != nil {
+		return NewBadRequestError(err.Error())
+	}
+	return nil
+}
+
+type registerUserReqBody struct {
+	Username string `json:"username" validate:"nonzero"`
+	Email    string `json:"email" validate:"nonzero"`
+	Password string `json:"password" validate:"nonzero"`
+}
+
+func (rb registerUserReqBody) Validate() error {
+	err := validator.Validate(rb)
+	if err != nil {
+		return NewBadRequestError(err.Error())
+	}
+	return nil
+}
+
+type updateProfileReqBody struct {
+	Username        string `json:"username"`
+	Email           string `json:"email"`
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type addVenueClosureReqBody struct {
+	StartTs int    `json:"start_ts" validate:"nonzero"`
+	EndTs   int    `json:"end_ts" validate:"nonzero"`
+	Reason  string `json:"reason" validate:"nonzero"`
+}
+
+func (rb addVenueClosureReqBody) Validate() error {
+	err := validator.Validate(rb)
+	if err != nil {
+		return NewBadRequestError(err.Error())
+	}
+	return nil
+}
+
+type createMeetupReqBody struct {
+	Name       string `json:"name" validate:"nonzero"`
+	VenueID    int    `json:"venue_id" validate:"nonzero"`
+	EventID    int    `json:"event_id" validate:"nonzero"`
+	StartTs    int    `json:"start_ts" validate:"nonzero"`
+	EndTs      int    `json:"end_ts" validate:"nonzero"`
+	MaxPersons int    `json:"max_persons" validate:"min=1"`
+}
+
+func (rb createMeetupReqBody) Validate() error {
+	err := validator.Validate(rb)
+	if err != nil {
+		return NewBadRequestError(err.Error())
+	}
+	return nil
+}
+
+type updateMeetupReqBody struct {
+	Name       string `json:"name"`
+	StartTs    int    `json:"start_ts"`
+	EndTs      int    `json:"end_ts"`
+	MaxPersons int    `json:"max_persons"`
+}
+
+// parseQueryInts parses integer query params into given fields, the fields of
+// missing params are left unchanged. Returns bad request error for the first
+// invalid param in alphabetical order.
+func parseQueryInts(r *http.Request, fields map[string]*int) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := r.URL.Query().Get(name)
+		if len(value) == 0 {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return NewBadRequestError(name)
+		}
+		*fields[name] = n
+	}
+	return nil
+}
+
+// parseQueryIntLists parses comma separated integer list query params into
+// given fields, the fields of missing params are left unchanged. Returns bad
+// request error for the first invalid param in alphabetical order.
+func parseQueryIntLists(r *http.Request, fields map[string]*[]int) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := r.URL.Query().Get(name)
+		if len(value) == 0 {
+			continue
+		}
+		var list []int
+		for _, item := range strings.Split(value, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(item))
+			if err != nil {
+				return NewBadRequestError(name)
+			}
+			list = append(list, n)
+		}
+		*fields[name] = list
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Haraj-backend/hex-monscape/internal/core/entity"
 	"github.com/Rican7/conjson"
 	"github.com/Rican7/conjson/transform"
 	"github.com/go-chi/render"
@@ -26,11 +27,14 @@ func (rb *RespBody) Render(w http.ResponseWriter, r *http.Request) error {
 }
 
 func NewSuccessResp(data interface{}) *RespBody {
-	return &RespBody{
+	rb := &RespBody{
 		StatusCode: http.StatusOK,
 		OK:         true,
-		Data:       conjson.NewMarshaler(data, transform.ConventionalKeys()),
 	}
+	if data != nil {
+		rb.Data = conjson.NewMarshaler(data, transform.ConventionalKeys())
+	}
+	return rb
 }
 
 func NewErrorResp(err error) *RespBody {
@@ -45,3 +49,221 @@ func NewErrorResp(err error) *RespBody {
 		Message:    restErr.Message,
 	}
 }
+
+// userRespBody is the user exposed to the client, the password hash is
+// never included.
+type userRespBody struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func newUserRespBody(u entity.User) userRespBody {
+	return userRespBody{
+		ID:       u.ID,
+		Username: u.Username,
+		Email:    u.Email,
+	}
+}
+
+type venueRespBody struct {
+	ID              int                      `json:"id"`
+	Name            string                   `json:"name"`
+	OpenDays        []int                    `json:"open_days"`
+	OpenAt          string                   `json:"open_at"`
+	ClosedAt        string                   `json:"closed_at"`
+	TimeZone        string                   `json:"timezone"`
+	WeekdayHours    []weekdayHoursRespBody   `json:"weekday_hours"`
+	Closures        []venueClosureRespBody   `json:"closures"`
+	SupportedEvents []supportedEventRespBody `json:"supported_events"`
+}
+
+func newVenueRespBody(v entity.Venue) venueRespBody {
+	rb := venueRespBody{
+		ID:       v.ID,
+		Name:     v.Name,
+		OpenDays: v.OpenDays,
+		OpenAt:   v.OpenAt,
+		ClosedAt: v.ClosedAt,
+		TimeZone: v.TimeZone,
+	}
+	for _, hours := range v.WeekdayHours {
+		rb.WeekdayHours = append(rb.WeekdayHours, weekdayHoursRespBody(hours))
+	}
+	for _, closure := range v.Closures {
+		rb.Closures = append(rb.Closures, venueClosureRespBody(closure))
+	}
+	for _, supportedEvent := range v.SupportedEvents {
+		rb.SupportedEvents = append(rb.SupportedEvents, supportedEventRespBody(supportedEvent))
+	}
+	return rb
+}
+
+func newVenueRespBodies(venues []entity.Venue) []venueRespBody {
+	rbs := make([]venueRespBody, 0, len(venues))
+	for _, venue := range venues {
+		rbs = append(rbs, newVenueRespBody(venue))
+	}
+	return rbs
+}
+
+type weekdayHoursRespBody struct {
+	Day      int    `json:"day"`
+	OpenAt   string `json:"open_at"`
+	ClosedAt string `json:"closed_at"`
+}
+
+type venueClosureRespBody struct {
+	StartTs int    `json:"start_ts"`
+	EndTs   int    `json:"end_ts"`
+	Reason  string `json:"reason"`
+}
+
+type supportedEventRespBody struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	EventCapacity int    `json:"meetups_capacity"`
+}
+
+type venueAvailabilityRespBody struct {
+	VenueID       int                        `json:"venue_id"`
+	EventID       int                        `json:"event_id"`
+	TimeZone      string                     `json:"timezone"`
+	EventCapacity int                        `json:"meetups_capacity"`
+	Slots         []availabilitySlotRespBody `json:"slots"`
+}
+
+func newVenueAvailabilityRespBody(a entity.VenueAvailability) venueAvailabilityRespBody {
+	rb := venueAvailabilityRespBody{
+		VenueID:       a.VenueID,
+		EventID:       a.EventID,
+		TimeZone:      a.TimeZone,
+		EventCapacity: a.EventCapacity,
+	}
+	for _, slot := range a.Slots {
+		rb.Slots = append(rb.Slots, availabilitySlotRespBody(slot))
+	}
+	return rb
+}
+
+type availabilitySlotRespBody struct {
+	StartTs           int  `json:"start_ts"`
+	EndTs             int  `json:"end_ts"`
+	IsOpen            bool `json:"is_open"`
+	RemainingCapacity int  `json:"remaining_capacity"`
+}
+
+type addVenueClosureRespBody struct {
+	Closure            venueClosureRespBody        `json:"closure"`
+	ConflictingMeetups []conflictingMeetupRespBody `json:"conflicting_meetups"`
+}
+
+func newAddVenueClosureRespBody(resp entity.AddVenueClosureResponse) addVenueClosureRespBody {
+	rb := addVenueClosureRespBody{
+		Closure:            venueClosureRespBody(resp.Closure),
+		ConflictingMeetups: make([]conflictingMeetupRespBody, 0, len(resp.ConflictingMeetups)),
+	}
+	for _, meetup := range resp.ConflictingMeetups {
+		rb.ConflictingMeetups = append(rb.ConflictingMeetups, conflictingMeetupRespBody{
+			ID:   meetup.ID,
+			Name: meetup.Name,
+			Event: meetupEventRespBody{
+				ID:   meetup.Event.ID,
+				Name: meetup.Event.Name,
+			},
+			StartTs: meetup.StartTs,
+			EndTs:   meetup.EndTs,
+			Organizer: meetupOrganizerRespBody{
+				ID:       meetup.Organizer.ID,
+				Username: meetup.Organizer.Username,
+				Email:    meetup.Organizer.Email,
+			},
+			Status: meetup.Status,
+		})
+	}
+	return rb
+}
+
+type conflictingMeetupRespBody struct {
+	ID        int                     `json:"id"`
+	Name      string                  `json:"name"`
+	Event     meetupEventRespBody     `json:"event"`
+	StartTs   int                     `json:"start_ts"`
+	EndTs     int                     `json:"end_ts"`
+	Organizer meetupOrganizerRespBody `json:"organizer"`
+	Status    entity.MeetupStatus     `json:"status"`
+}
+
+type meetupEventRespBody struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type meetupOrganizerRespBody struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// meetupPageRespBody is the page of meetups listing, the next cursor is
+// omitted on the last page.
+type meetupPageRespBody struct {
+	Meetups    []entity.GetMeetupsResponse `json:"meetups"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
+}
+
+func newMeetupPageRespBody(p entity.MeetupPage) meetupPageRespBody {
+	return meetupPageRespBody{
+		Meetups:    p.Meetups,
+		NextCursor: p.NextCursor,
+	}
+}
+
+// meetupRespBody is the meetup exposed to the client, the joined persons are
+// omitted when they are hidden from the viewer & the cancellation details are
+// omitted when the meetup is not cancelled.
+type meetupRespBody struct {
+	ID                 int                    `json:"id"`
+	Name               string                 `json:"name"`
+	Venue              entity.MeetupVenue     `json:"venue"`
+	Event              entity.MeetupEvent     `json:"event"`
+	StartTs            int                    `json:"start_ts"`
+	EndTs              int                    `json:"end_ts"`
+	MaxPersons         int                    `json:"max_persons"`
+	Organizer          entity.MeetupOrganizer `json:"organizer"`
+	JoinedPersons      []entity.JoinedPerson  `json:"joined_persons,omitempty"`
+	JoinedPersonsCount int                    `json:"joined_persons_count"`
+	IsJoined           bool                   `json:"is_joined"`
+	Status             entity.MeetupStatus    `json:"status"`
+	CancelledReason    string                 `json:"cancelled_reason,omitempty"`
+	CancelledAt        int64                  `json:"cancelled_at,omitempty"`
+	CancelledBy        int                    `json:"cancelled_by,omitempty"`
+}
+
+func newMeetupRespBody(m entity.Meetup) meetupRespBody {
+	return meetupRespBody{
+		ID:                 m.ID,
+		Name:               m.Name,
+		Venue:              m.Venue,
+		Event:              m.Event,
+		StartTs:            m.StartTs,
+		EndTs:              m.EndTs,
+		MaxPersons:         m.MaxPersons,
+		Organizer:          m.Organizer,
+		JoinedPersons:      m.JoinedPersons,
+		JoinedPersonsCount: m.JoinedPersonsCount,
+		IsJoined:           m.IsJoined,
+		Status:             m.Status,
+		CancelledReason:    m.CancelledReason,
+		CancelledAt:        m.CancelledAt,
+		CancelledBy:        m.CancelledBy,
+	}
+}
+
+func newMeetupRespBodies(meetups []entity.Meetup) []meetupRespBody {
+	rbs := make([]meetupRespBody, 0, len(meetups))
+	for _, meetup := range meetups {
+		rbs = append(rbs, newMeetupRespBody(meetup))
+	}
+	return rbs
+}
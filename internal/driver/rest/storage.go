@@ -0,0 +1,19 @@
+package rest
+
+import (
+	"context"
+	"time"
+)
+
+type RateLimitStorage interface {
+	// TakeToken takes a token from the bucket of given key at given time.
+	// Returns false along with the duration until the next token is available
+	// when the bucket is empty.
+	TakeToken(ctx context.Context, key string, now time.Time) (bool, time.Duration, error)
+}
+
+type Clock interface {
+	// Now returns the current time, it is used for refilling the rate limit
+	// buckets.
+	Now() time.Time
+}